@@ -61,3 +61,68 @@ Create the name of the service account to use
     {{ default "default" .Values.serviceAccount.name }}
 {{- end -}}
 {{- end -}}
+
+{{/*
+Rules of the osm-controller on namespaced resources, granted in every namespace,
+or in the namespaces the mesh is restricted to with watchedNamespaces
+*/}}
+{{- define "osm.namespacedRules" -}}
+- apiGroups: ["apps"]
+  resources: ["daemonsets", "deployments", "replicasets", "statefulsets"]
+  verbs: ["list", "get", "watch"]
+- apiGroups: ["extensions"]
+  resources: ["ingresses"]
+  verbs: ["list", "get", "watch"]
+- apiGroups: ["batch"]
+  resources: ["jobs"]
+  verbs: ["list", "get", "watch"]
+- apiGroups: [""]
+  resources: ["endpoints", "pods", "services", "secrets", "configmaps"]
+  verbs: ["list", "get", "watch"]
+- apiGroups: ["discovery.k8s.io"]
+  resources: ["endpointslices"]
+  verbs: ["list", "get", "watch"]
+
+# Port forwarding is needed for the OSM pod to be able to connect
+# to participating Envoys and fetch their configuration.
+# This is used by the OSM debugging system.
+- apiGroups: [""]
+  resources: ["pods", "pods/log", "pods/portforward"]
+  verbs: ["get", "list", "create"]
+
+- apiGroups: [""]
+  resources: ["secrets", "configmaps"]
+  verbs: ["create", "update"]
+
+# Services exported by a remote cluster are mirrored as local services and endpoints.
+- apiGroups: [""]
+  resources: ["services", "endpoints"]
+  verbs: ["create", "update", "delete"]
+- apiGroups: [""]
+  resources: ["events"]
+  verbs: ["create", "patch"]
+# NetworkPolicies are generated from the SMI traffic policies.
+- apiGroups: ["networking.k8s.io"]
+  resources: ["networkpolicies"]
+  verbs: ["list", "get", "watch", "create", "update", "delete"]
+- apiGroups: ["split.smi-spec.io"]
+  resources: ["trafficsplits"]
+  verbs: ["list", "get", "watch"]
+- apiGroups: ["access.smi-spec.io"]
+  resources: ["traffictargets"]
+  verbs: ["list", "get", "watch"]
+- apiGroups: ["specs.smi-spec.io"]
+  resources: ["httproutegroups"]
+  verbs: ["list", "get", "watch"]
+
+# Backpressure is an experimental extension of SMI.
+# This will be removed once it becomes part of SMI.
+- apiGroups: ["policy.openservicemesh.io"]
+  resources: ["backpressures", "tlsoriginations", "sidecarscopes"]
+  verbs: ["list", "get", "watch"]
+
+# Leases are used to elect the leader among the replicas when sharding is enabled.
+- apiGroups: ["coordination.k8s.io"]
+  resources: ["leases"]
+  verbs: ["get", "create", "update"]
+{{- end -}}
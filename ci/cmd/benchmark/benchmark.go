@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/ci/cmd/maestro"
+	"github.com/openservicemesh/osm/demo/cmd/common"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var log = logger.NewPretty("ci/benchmark")
+
+const (
+	// servicesEnvVar is the environment variable for the number of synthetic services.
+	servicesEnvVar = "BENCHMARK_SERVICES"
+
+	// proxiesEnvVar is the environment variable for the number of simulated proxies.
+	proxiesEnvVar = "BENCHMARK_PROXIES"
+
+	// namespaceEnvVar is the environment variable for the namespace the synthetic workload is created in.
+	namespaceEnvVar = "BENCHMARK_NAMESPACE"
+
+	// meshNameEnvVar is the environment variable for the name of the mesh under test.
+	meshNameEnvVar = "MESH_NAME"
+
+	// timeoutSecondsEnvVar is the environment variable for the time each phase of the benchmark may take.
+	timeoutSecondsEnvVar = "BENCHMARK_TIMEOUT_SECONDS"
+
+	// reportFileEnvVar is the environment variable for the file the JSON report is written to.
+	reportFileEnvVar = "BENCHMARK_REPORT_FILE"
+
+	// maxTimeToConfigSecondsEnvVar is the environment variable for the maximum p99 time for a proxy to receive its full configuration.
+	maxTimeToConfigSecondsEnvVar = "BENCHMARK_MAX_TIME_TO_CONFIG_SECONDS"
+
+	// maxPropagationSecondsEnvVar is the environment variable for the maximum p99 time to propagate a policy change.
+	maxPropagationSecondsEnvVar = "BENCHMARK_MAX_PROPAGATION_SECONDS"
+)
+
+var (
+	osmControllerPodSelector = fmt.Sprintf("app=%s", constants.OSMControllerName)
+
+	osmNamespace = common.GetEnv(maestro.OSMNamespaceEnvVar, "osm-system")
+	meshName     = common.GetEnv(meshNameEnvVar, "osm")
+	benchmarkNS  = common.GetEnv(namespaceEnvVar, "ci-benchmark")
+	reportFile   = common.GetEnv(reportFileEnvVar, "")
+
+	// pollInterval is how often the benchmark checks the progress of the simulated proxies
+	pollInterval = 100 * time.Millisecond
+
+	errNoControllerPods = errors.New("no running controller pods found")
+	errNoCertificate    = errors.New("no certificate in the XDS certificate file")
+	errInvalidRootCert  = errors.New("invalid root certificate in the bootstrap container")
+
+	errThresholdsExceeded = errors.New("the benchmark exceeded its thresholds")
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Error().Err(err).Msg("Benchmark failed")
+		os.Exit(1)
+	}
+}
+
+// run runs the benchmark and returns once the resources it created are cleaned up.
+// Environment variables are read before any resource is created, as invalid values are fatal.
+func run() error {
+	services := getIntEnv(servicesEnvVar, 10)
+	proxies := getIntEnv(proxiesEnvVar, 50)
+	timeout := time.Duration(getIntEnv(timeoutSecondsEnvVar, 300)) * time.Second
+	maxTimeToConfigSeconds := getIntEnv(maxTimeToConfigSecondsEnvVar, 0)
+	maxPropagationSeconds := getIntEnv(maxPropagationSecondsEnvVar, 0)
+	if services < 1 || proxies < services {
+		return errors.Errorf("%s=%d must be at least 1 and no more than %s=%d", servicesEnvVar, services, proxiesEnvVar, proxies)
+	}
+
+	kubeConfig := maestro.GetKubernetesConfig()
+	kubeClient := maestro.GetKubernetesClient()
+
+	controllerPods, err := getControllerPods(kubeClient)
+	if err != nil {
+		return errors.Wrapf(err, "Error getting %s pods in namespace %s", constants.OSMControllerName, osmNamespace)
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	// Each controller replica is reached through its own port forward;
+	// with sharding enabled a proxy is only accepted by the replica serving it.
+	var adsAddresses, metricsAddresses []string
+	// Proxies can bootstrap with any replica; the webhook server of the first one is used
+	bootstrapPort, err := maestro.ForwardPort(kubeConfig, &controllerPods[0], constants.InjectorWebhookPort, stop)
+	if err != nil {
+		return errors.Wrapf(err, "Error forwarding the webhook port of pod %s", controllerPods[0].Name)
+	}
+
+	for i := range controllerPods {
+		adsPort, err := maestro.ForwardPort(kubeConfig, &controllerPods[i], constants.OSMControllerPort, stop)
+		if err != nil {
+			return errors.Wrapf(err, "Error forwarding the xDS port of pod %s", controllerPods[i].Name)
+		}
+		metricsPort, err := maestro.ForwardPort(kubeConfig, &controllerPods[i], constants.MetricsServerPort, stop)
+		if err != nil {
+			return errors.Wrapf(err, "Error forwarding the metrics port of pod %s", controllerPods[i].Name)
+		}
+		adsAddresses = append(adsAddresses, fmt.Sprintf("localhost:%d", adsPort))
+		metricsAddresses = append(metricsAddresses, fmt.Sprintf("localhost:%d", metricsPort))
+	}
+
+	defer maestro.DeleteNamespaces(kubeClient, benchmarkNS)
+
+	benchmarkStart := time.Now()
+	usageBefore, err := scrapeControllers(metricsAddresses)
+	if err != nil {
+		return errors.Wrap(err, "Error scraping controller metrics")
+	}
+
+	log.Info().Msgf("Creating %d services and %d proxies in namespace %s", services, proxies, benchmarkNS)
+	if err := createWorkload(kubeClient, benchmarkNS, meshName, services, proxies); err != nil {
+		return errors.Wrap(err, "Error creating the synthetic workload")
+	}
+
+	simulatedProxies, err := getSimulatedProxies(kubeClient, benchmarkNS, proxies, timeout, fmt.Sprintf("localhost:%d", bootstrapPort))
+	if err != nil {
+		return errors.Wrap(err, "Error getting the XDS certificates of the proxies")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for idx, proxy := range simulatedProxies {
+		go proxy.run(ctx, adsAddresses, idx)
+	}
+
+	log.Info().Msgf("Waiting up to %s for %d proxies to receive their configuration", timeout, len(simulatedProxies))
+	waitFor(timeout, func() bool { return countConfigured(simulatedProxies) == len(simulatedProxies) })
+
+	log.Info().Msg("Applying a traffic policy to all services")
+	policyAppliedAt := time.Now()
+	if err := applyTrafficPolicy(kubeConfig, benchmarkNS, services); err != nil {
+		return errors.Wrap(err, "Error applying the traffic policy")
+	}
+	waitFor(timeout, func() bool { return countUpdatedSince(simulatedProxies, policyAppliedAt) == len(simulatedProxies) })
+
+	usageAfter, err := scrapeControllers(metricsAddresses)
+	if err != nil {
+		return errors.Wrap(err, "Error scraping controller metrics")
+	}
+
+	r := newReport(services, simulatedProxies, len(controllerPods), policyAppliedAt)
+	r.Controller = getResourceUsage(usageBefore, usageAfter, time.Since(benchmarkStart))
+	if err := r.write(os.Stdout, reportFile); err != nil {
+		log.Error().Err(err).Msg("Error writing the benchmark report")
+	}
+
+	if failures := r.check(maxTimeToConfigSeconds, maxPropagationSeconds); len(failures) > 0 {
+		for _, failure := range failures {
+			log.Error().Msg(failure)
+		}
+		return errThresholdsExceeded
+	}
+	return nil
+}
+
+func getControllerPods(kubeClient kubernetes.Interface) ([]corev1.Pod, error) {
+	podList, err := kubeClient.CoreV1().Pods(osmNamespace).List(context.Background(), metav1.ListOptions{LabelSelector: osmControllerPodSelector})
+	if err != nil {
+		return nil, err
+	}
+
+	var pods []corev1.Pod
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodRunning {
+			pods = append(pods, pod)
+		}
+	}
+	if len(pods) == 0 {
+		return nil, errNoControllerPods
+	}
+	return pods, nil
+}
+
+// waitFor polls the given condition until it holds or the timeout elapses.
+func waitFor(timeout time.Duration, condition func() bool) {
+	deadline := time.Now().Add(timeout)
+	for !condition() && time.Now().Before(deadline) {
+		time.Sleep(pollInterval)
+	}
+}
+
+func getIntEnv(envVar string, defaultValue int) int {
+	value := common.GetEnv(envVar, strconv.Itoa(defaultValue))
+	intValue, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("Could not convert environment variable %s='%s' to int", envVar, value)
+	}
+	return intValue
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+)
+
+var _ = Describe("Test benchmark", func() {
+
+	Context("Test summarize", func() {
+		It("returns the nearest-rank percentiles", func() {
+			var latencies []time.Duration
+			for i := 100; i > 0; i-- {
+				latencies = append(latencies, time.Duration(i)*time.Millisecond)
+			}
+			actual := summarize(latencies)
+			expected := latencySummary{Count: 100, P50: 50, P90: 90, P99: 99, Max: 100}
+			Expect(actual).To(Equal(expected))
+		})
+
+		It("returns zeros for no latencies", func() {
+			Expect(summarize(nil)).To(Equal(latencySummary{}))
+		})
+	})
+
+	Context("Test parseMetrics", func() {
+		It("sums the samples of each metric", func() {
+			text := `# HELP process_cpu_seconds_total Total user and system CPU time spent in seconds.
+# TYPE process_cpu_seconds_total counter
+process_cpu_seconds_total 1.5
+process_resident_memory_bytes 2.048e+07
+osm_k8s_api_event_counter{osm_namespace="osm-system",osm_pod="a"} 3
+osm_k8s_api_event_counter{osm_namespace="osm-system",osm_pod="b"} 4 1600000000000
+`
+			actual, err := parseMetrics(strings.NewReader(text))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(Equal(map[string]float64{
+				cpuSecondsMetric:            1.5,
+				residentMemoryMetric:        20480000,
+				"osm_k8s_api_event_counter": 7,
+			}))
+		})
+
+		It("returns an error for an invalid value", func() {
+			_, err := parseMetrics(strings.NewReader("go_goroutines abc"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Test getResourceUsage", func() {
+		It("returns the resources used between two scrapes", func() {
+			before := map[string]float64{cpuSecondsMetric: 1, residentMemoryMetric: 100}
+			after := map[string]float64{cpuSecondsMetric: 5, residentMemoryMetric: 300, goroutinesMetric: 42}
+			actual := getResourceUsage(before, after, 8*time.Second)
+			Expect(actual).To(Equal(resourceUsage{
+				CPUSeconds:             4,
+				AverageCPUCores:        0.5,
+				ResidentMemoryBytes:    300,
+				ResidentMemoryIncrease: 200,
+				Goroutines:             42,
+			}))
+		})
+	})
+
+	Context("Test getTLSConfig", func() {
+		It("returns the credentials of the XDS certificate file", func() {
+			ca, err := tresor.NewCA("Fake CA", 1*time.Hour, "US", "Fake Locality", "Fake Org")
+			Expect(err).ToNot(HaveOccurred())
+			certManager, err := tresor.NewCertManager(ca, 1*time.Hour, "Fake Org")
+			Expect(err).ToNot(HaveOccurred())
+			cert, err := certManager.IssueCertificate("abc.sa.ns", nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			certificateYAML := fmt.Sprintf(`resources:
+- '@type': type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.Secret
+  name: xds_certificate
+  tls_certificate:
+    certificate_chain:
+      inline_bytes: %s
+    private_key:
+      inline_bytes: %s
+`,
+				base64.StdEncoding.EncodeToString(cert.GetCertificateChain()),
+				base64.StdEncoding.EncodeToString(cert.GetPrivateKey()))
+
+			tlsConfig, err := getTLSConfig([]byte(certificateYAML), cert.GetIssuingCA())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tlsConfig.ServerName).To(Equal(xdsServerName))
+			Expect(tlsConfig.Certificates).To(HaveLen(1))
+			Expect(tlsConfig.RootCAs).ToNot(BeNil())
+		})
+
+		It("returns an error without a certificate", func() {
+			_, err := getTLSConfig([]byte("resources: []"), nil)
+			Expect(err).To(Equal(errNoCertificate))
+		})
+	})
+})
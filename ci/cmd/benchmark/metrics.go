@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	cpuSecondsMetric     = "process_cpu_seconds_total"
+	residentMemoryMetric = "process_resident_memory_bytes"
+	goroutinesMetric     = "go_goroutines"
+)
+
+// resourceUsage is the CPU and memory used by the controller replicas during the benchmark
+type resourceUsage struct {
+	CPUSeconds             float64 `json:"cpuSeconds"`
+	AverageCPUCores        float64 `json:"averageCpuCores"`
+	ResidentMemoryBytes    float64 `json:"residentMemoryBytes"`
+	ResidentMemoryIncrease float64 `json:"residentMemoryIncreaseBytes"`
+	Goroutines             float64 `json:"goroutines"`
+}
+
+// scrapeControllers returns the sum of the metrics of the controller replicas with the given metrics addresses.
+func scrapeControllers(addresses []string) (map[string]float64, error) {
+	total := make(map[string]float64)
+	for _, address := range addresses {
+		resp, err := http.Get(fmt.Sprintf("http://%s/metrics", address))
+		if err != nil {
+			return nil, err
+		}
+		metrics, err := parseMetrics(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error parsing metrics from %s", address)
+		}
+		for name, value := range metrics {
+			total[name] += value
+		}
+	}
+	return total, nil
+}
+
+// parseMetrics parses metrics in the Prometheus text format, summing the samples of each metric across labels.
+func parseMetrics(r io.Reader) (map[string]float64, error) {
+	metrics := make(map[string]float64)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// A sample is: <name>{<labels>} <value> [<timestamp>]
+		name := line
+		if idx := strings.IndexAny(line, "{ "); idx != -1 {
+			name = line[:idx]
+		}
+		if idx := strings.LastIndex(line, "}"); idx != -1 {
+			line = line[idx+1:]
+		} else {
+			line = line[len(name):]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			return nil, errors.Errorf("Invalid sample for metric %s", name)
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid value for metric %s", name)
+		}
+		metrics[name] += value
+	}
+	return metrics, scanner.Err()
+}
+
+// getResourceUsage returns the resources used between the two given scrapes of the controller metrics.
+func getResourceUsage(before, after map[string]float64, elapsed time.Duration) resourceUsage {
+	usage := resourceUsage{
+		CPUSeconds:             after[cpuSecondsMetric] - before[cpuSecondsMetric],
+		ResidentMemoryBytes:    after[residentMemoryMetric],
+		ResidentMemoryIncrease: after[residentMemoryMetric] - before[residentMemoryMetric],
+		Goroutines:             after[goroutinesMetric],
+	}
+	if elapsed > 0 {
+		usage.AverageCPUCores = usage.CPUSeconds / elapsed.Seconds()
+	}
+	return usage
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"sync"
+	"time"
+
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+// requestedTypes are the xDS types a simulated proxy subscribes to when it connects.
+// SDS is left out: Envoy requests secrets by name once it has received its listeners and clusters.
+var requestedTypes = []envoy.TypeURI{envoy.TypeCDS, envoy.TypeEDS, envoy.TypeLDS, envoy.TypeRDS}
+
+// retryInterval is the time a simulated proxy waits before reconnecting after its stream was closed
+var retryInterval = time.Second
+
+// simulatedProxy is an xDS client connecting to the controller with the credentials of an Envoy sidecar
+type simulatedProxy struct {
+	name      string
+	tlsConfig *tls.Config
+
+	sync.Mutex
+
+	// connectedAt is when the stream accepted by the controller was opened
+	connectedAt time.Time
+
+	// configuredAt is when the proxy had received a response for each of the requested types
+	configuredAt time.Time
+
+	// pushLatencies are the times between each request of the proxy and the response to it
+	pushLatencies []time.Duration
+
+	// responses are the times at which the proxy received responses
+	responses []time.Time
+
+	// rejections is the number of streams closed by replicas not serving this proxy
+	rejections int
+}
+
+func newSimulatedProxy(name string, tlsConfig *tls.Config) *simulatedProxy {
+	return &simulatedProxy{
+		name:      name,
+		tlsConfig: tlsConfig,
+	}
+}
+
+// run connects the proxy to the controller until the context is canceled.
+// The proxy starts with the address at the given index and moves to the next one when it is rejected.
+func (p *simulatedProxy) run(ctx context.Context, addresses []string, idx int) {
+	for {
+		address := addresses[idx%len(addresses)]
+		err := p.stream(ctx, address)
+		if ctx.Err() != nil {
+			return
+		}
+		if status.Code(err) == codes.Unavailable {
+			p.Lock()
+			p.rejections++
+			p.Unlock()
+			idx++
+			continue
+		}
+		log.Error().Err(err).Msgf("Stream of proxy %s to %s closed", p.name, address)
+		time.Sleep(retryInterval)
+	}
+}
+
+// stream opens an ADS stream, requests the configuration of the proxy and ACKs every response.
+func (p *simulatedProxy) stream(ctx context.Context, address string) error {
+	conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(credentials.NewTLS(p.tlsConfig)), grpc.WithBlock())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := xds_discovery.NewAggregatedDiscoveryServiceClient(conn).StreamAggregatedResources(ctx)
+	if err != nil {
+		return err
+	}
+
+	openedAt := time.Now()
+	requestedAt := make(map[string]time.Time)
+	for _, typeURI := range requestedTypes {
+		requestedAt[typeURI.String()] = time.Now()
+		if err := stream.Send(&xds_discovery.DiscoveryRequest{TypeUrl: typeURI.String()}); err != nil {
+			return err
+		}
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		p.recordResponse(resp.TypeUrl, openedAt, requestedAt)
+
+		ack := &xds_discovery.DiscoveryRequest{
+			TypeUrl:       resp.TypeUrl,
+			VersionInfo:   resp.VersionInfo,
+			ResponseNonce: resp.Nonce,
+		}
+		if err := stream.Send(ack); err != nil {
+			return err
+		}
+	}
+}
+
+// recordResponse records the response of the given type received on the stream opened at the given time.
+func (p *simulatedProxy) recordResponse(typeURL string, openedAt time.Time, requestedAt map[string]time.Time) {
+	now := time.Now()
+
+	p.Lock()
+	defer p.Unlock()
+
+	p.responses = append(p.responses, now)
+	if p.connectedAt.IsZero() {
+		p.connectedAt = openedAt
+	}
+
+	// Only the first response to each request answers it; later responses are pushed by the controller
+	if sentAt, ok := requestedAt[typeURL]; ok {
+		p.pushLatencies = append(p.pushLatencies, now.Sub(sentAt))
+		delete(requestedAt, typeURL)
+		if len(requestedAt) == 0 && p.configuredAt.IsZero() {
+			p.configuredAt = now
+		}
+	}
+}
+
+// getTimeToConfig returns the time the proxy took to receive its full configuration, and whether it did.
+func (p *simulatedProxy) getTimeToConfig() (time.Duration, bool) {
+	p.Lock()
+	defer p.Unlock()
+	if p.configuredAt.IsZero() {
+		return 0, false
+	}
+	return p.configuredAt.Sub(p.connectedAt), true
+}
+
+// getFirstResponseSince returns the time of the first response received after the given time, and whether there was one.
+func (p *simulatedProxy) getFirstResponseSince(t time.Time) (time.Time, bool) {
+	p.Lock()
+	defer p.Unlock()
+	for _, respondedAt := range p.responses {
+		if respondedAt.After(t) {
+			return respondedAt, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func countConfigured(proxies []*simulatedProxy) int {
+	count := 0
+	for _, proxy := range proxies {
+		if _, ok := proxy.getTimeToConfig(); ok {
+			count++
+		}
+	}
+	return count
+}
+
+func countUpdatedSince(proxies []*simulatedProxy, t time.Time) int {
+	count := 0
+	for _, proxy := range proxies {
+		if _, ok := proxy.getFirstResponseSince(t); ok {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"sort"
+	"time"
+)
+
+// report is the result of a benchmark run
+type report struct {
+	Services           int `json:"services"`
+	Proxies            int `json:"proxies"`
+	ControllerReplicas int `json:"controllerReplicas"`
+
+	// ConfiguredProxies is the number of proxies which received their full configuration
+	ConfiguredProxies int `json:"configuredProxies"`
+
+	// UpdatedProxies is the number of proxies which received an update after the policy change
+	UpdatedProxies int `json:"updatedProxies"`
+
+	// Rejections is the number of streams closed by replicas not serving the proxy
+	Rejections int `json:"rejections"`
+
+	// TimeToConfig is the time from opening a stream to having received a response for each requested type
+	TimeToConfig latencySummary `json:"timeToConfig"`
+
+	// PushLatency is the time from sending a request to receiving its response
+	PushLatency latencySummary `json:"pushLatency"`
+
+	// PolicyPropagation is the time from applying the policy change to a proxy receiving an update
+	PolicyPropagation latencySummary `json:"policyPropagation"`
+
+	Controller resourceUsage `json:"controller"`
+}
+
+// latencySummary summarizes a distribution of latencies, in milliseconds
+type latencySummary struct {
+	Count int     `json:"count"`
+	P50   float64 `json:"p50Ms"`
+	P90   float64 `json:"p90Ms"`
+	P99   float64 `json:"p99Ms"`
+	Max   float64 `json:"maxMs"`
+}
+
+func newReport(services int, proxies []*simulatedProxy, controllerReplicas int, policyAppliedAt time.Time) *report {
+	r := &report{
+		Services:           services,
+		Proxies:            len(proxies),
+		ControllerReplicas: controllerReplicas,
+	}
+
+	var timesToConfig, pushLatencies, propagation []time.Duration
+	for _, proxy := range proxies {
+		if timeToConfig, ok := proxy.getTimeToConfig(); ok {
+			timesToConfig = append(timesToConfig, timeToConfig)
+		}
+		if updatedAt, ok := proxy.getFirstResponseSince(policyAppliedAt); ok {
+			propagation = append(propagation, updatedAt.Sub(policyAppliedAt))
+		}
+
+		proxy.Lock()
+		pushLatencies = append(pushLatencies, proxy.pushLatencies...)
+		r.Rejections += proxy.rejections
+		proxy.Unlock()
+	}
+
+	r.ConfiguredProxies = len(timesToConfig)
+	r.UpdatedProxies = len(propagation)
+	r.TimeToConfig = summarize(timesToConfig)
+	r.PushLatency = summarize(pushLatencies)
+	r.PolicyPropagation = summarize(propagation)
+	return r
+}
+
+// summarize returns the percentiles of the given latencies.
+func summarize(latencies []time.Duration) latencySummary {
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return latencySummary{
+		Count: len(sorted),
+		P50:   toMilliseconds(percentile(sorted, 50)),
+		P90:   toMilliseconds(percentile(sorted, 90)),
+		P99:   toMilliseconds(percentile(sorted, 99)),
+		Max:   toMilliseconds(percentile(sorted, 100)),
+	}
+}
+
+// percentile returns the nearest-rank percentile of the given sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func toMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// write writes the report as JSON to the given writer, and to the given file unless it is empty.
+func (r *report) write(w io.Writer, file string) error {
+	out, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, string(out)); err != nil {
+		return err
+	}
+	if file == "" {
+		return nil
+	}
+	return ioutil.WriteFile(file, out, 0644)
+}
+
+// check returns the reasons the benchmark failed, given the maximum p99 times in seconds; a maximum of 0 is not checked.
+func (r *report) check(maxTimeToConfigSeconds, maxPropagationSeconds int) []string {
+	var failures []string
+	if r.ConfiguredProxies != r.Proxies {
+		failures = append(failures, fmt.Sprintf("Only %d of %d proxies received their configuration", r.ConfiguredProxies, r.Proxies))
+	}
+	if r.UpdatedProxies != r.Proxies {
+		failures = append(failures, fmt.Sprintf("Only %d of %d proxies received the policy change", r.UpdatedProxies, r.Proxies))
+	}
+	if maxTimeToConfigSeconds > 0 && r.TimeToConfig.P99 > float64(maxTimeToConfigSeconds*1000) {
+		failures = append(failures, fmt.Sprintf("p99 time to configuration is %.0fms; the maximum is %ds", r.TimeToConfig.P99, maxTimeToConfigSeconds))
+	}
+	if maxPropagationSeconds > 0 && r.PolicyPropagation.P99 > float64(maxPropagationSeconds*1000) {
+		failures = append(failures, fmt.Sprintf("p99 policy propagation is %.0fms; the maximum is %ds", r.PolicyPropagation.P99, maxPropagationSeconds))
+	}
+	return failures
+}
@@ -0,0 +1,329 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	target "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha2"
+	spec "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha3"
+	smiAccessClient "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned"
+	smiSpecClient "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned"
+	"gopkg.in/yaml.v2"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/namespace"
+)
+
+const (
+	selectorKey = "app"
+
+	// xdsServerName is the name the xDS server certificate is issued for
+	xdsServerName = "ads"
+
+	// bootstrapCertificatePath is the path of the webhook server on which proxies exchange their bootstrap token
+	// for the certificate they connect to XDS with
+	bootstrapCertificatePath = "/bootstrap/certificate"
+
+	// bootstrapTokenAudience is the audience of the bootstrap tokens of the proxies
+	bootstrapTokenAudience = "openservicemesh.io/bootstrap"
+
+	// bootstrapTokenExpirationSeconds is the validity period of the requested bootstrap tokens
+	bootstrapTokenExpirationSeconds = 600
+
+	// bootstrapCABundleEnvVar is the environment variable of the injected bootstrap container holding the root certificate of the mesh
+	bootstrapCABundleEnvVar = "BOOTSTRAP_CA_BUNDLE"
+
+	// pauseImage is the image of the synthetic pods; the pods are never scheduled
+	pauseImage = "k8s.gcr.io/pause:3.2"
+
+	// unschedulableNodeLabel is the node selector keeping the synthetic pods pending,
+	// so that the simulated proxies are the only clients of the controller for these pods
+	unschedulableNodeLabel = "openservicemesh.io/benchmark"
+
+	trafficPolicyName = "benchmark"
+	routeMatchName    = "all"
+)
+
+// namespaceSyncWait is the time given to the injector to observe the new namespace before pods are created in it
+var namespaceSyncWait = 5 * time.Second
+
+// xdsCertificate is the SDS file holding the certificate a proxy connects to XDS with
+type xdsCertificate struct {
+	Resources []struct {
+		TLSCertificate struct {
+			CertificateChain dataSource `yaml:"certificate_chain"`
+			PrivateKey       dataSource `yaml:"private_key"`
+		} `yaml:"tls_certificate"`
+	} `yaml:"resources"`
+}
+
+type dataSource struct {
+	InlineBytes string `yaml:"inline_bytes"`
+}
+
+func getServiceName(idx int) string {
+	return fmt.Sprintf("svc-%d", idx)
+}
+
+// createWorkload creates a namespace monitored by the mesh with the given number of services,
+// and the given number of pods spread across the services.
+func createWorkload(kubeClient kubernetes.Interface, ns, meshName string, services, proxies int) error {
+	nsObj := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   ns,
+			Labels: map[string]string{namespace.MonitorLabel: meshName},
+		},
+	}
+	if _, err := kubeClient.CoreV1().Namespaces().Create(context.Background(), nsObj, metav1.CreateOptions{}); err != nil {
+		return errors.Wrapf(err, "Error creating namespace %s", ns)
+	}
+	time.Sleep(namespaceSyncWait)
+
+	for i := 0; i < services; i++ {
+		name := getServiceName(i)
+		sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if _, err := kubeClient.CoreV1().ServiceAccounts(ns).Create(context.Background(), sa, metav1.CreateOptions{}); err != nil {
+			return errors.Wrapf(err, "Error creating service account %s/%s", ns, name)
+		}
+
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{selectorKey: name},
+				Ports: []corev1.ServicePort{{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt(80),
+				}},
+			},
+		}
+		if _, err := kubeClient.CoreV1().Services(ns).Create(context.Background(), svc, metav1.CreateOptions{}); err != nil {
+			return errors.Wrapf(err, "Error creating service %s/%s", ns, name)
+		}
+	}
+
+	for i := 0; i < proxies; i++ {
+		serviceName := getServiceName(i % services)
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   fmt.Sprintf("proxy-%d", i),
+				Labels: map[string]string{selectorKey: serviceName},
+			},
+			Spec: corev1.PodSpec{
+				ServiceAccountName: serviceName,
+				NodeSelector:       map[string]string{unschedulableNodeLabel: "unschedulable"},
+				Containers: []corev1.Container{{
+					Name:  "app",
+					Image: pauseImage,
+					Ports: []corev1.ContainerPort{{ContainerPort: 80}},
+				}},
+			},
+		}
+		if _, err := kubeClient.CoreV1().Pods(ns).Create(context.Background(), pod, metav1.CreateOptions{}); err != nil {
+			return errors.Wrapf(err, "Error creating pod %s/%s", ns, pod.Name)
+		}
+	}
+	return nil
+}
+
+// getSimulatedProxies returns a simulated proxy for each pod of the synthetic workload,
+// using the credentials the controller at the given bootstrap address issues for the pod.
+func getSimulatedProxies(kubeClient kubernetes.Interface, ns string, proxies int, timeout time.Duration, bootstrapAddress string) ([]*simulatedProxy, error) {
+	var podList *corev1.PodList
+	var err error
+	waitFor(timeout, func() bool {
+		podList, err = kubeClient.CoreV1().Pods(ns).List(context.Background(), metav1.ListOptions{LabelSelector: constants.EnvoyUniqueIDLabelName})
+		return err == nil && len(podList.Items) == proxies
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(podList.Items) != proxies {
+		return nil, errors.Errorf("Found %d of %d pods with an Envoy sidecar in namespace %s", len(podList.Items), proxies, ns)
+	}
+
+	var simulatedProxies []*simulatedProxy
+	for idx := range podList.Items {
+		pod := &podList.Items[idx]
+		certificateYAML, err := getXDSCertificate(kubeClient, pod, bootstrapAddress)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error bootstrapping pod %s/%s", ns, pod.Name)
+		}
+
+		tlsConfig, err := getTLSConfig(certificateYAML, []byte(getBootstrapCABundle(pod)))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error parsing the XDS certificate of pod %s/%s", ns, pod.Name)
+		}
+		simulatedProxies = append(simulatedProxies, newSimulatedProxy(pod.Name, tlsConfig))
+	}
+	return simulatedProxies, nil
+}
+
+// getXDSCertificate exchanges a service account token bound to the given pod for the certificate its proxy connects
+// to XDS with, as the bootstrap init container of the pod would if the pod was scheduled.
+func getXDSCertificate(kubeClient kubernetes.Interface, pod *corev1.Pod, bootstrapAddress string) ([]byte, error) {
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM([]byte(getBootstrapCABundle(pod))) {
+		return nil, errInvalidRootCert
+	}
+
+	expirationSeconds := int64(bootstrapTokenExpirationSeconds)
+	tokenRequest := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         []string{bootstrapTokenAudience},
+			ExpirationSeconds: &expirationSeconds,
+			BoundObjectRef: &authenticationv1.BoundObjectReference{
+				Kind:       "Pod",
+				APIVersion: "v1",
+				Name:       pod.Name,
+				UID:        pod.UID,
+			},
+		},
+	}
+	token, err := kubeClient.CoreV1().ServiceAccounts(pod.Namespace).CreateToken(context.Background(), pod.Spec.ServiceAccountName, tokenRequest, metav1.CreateOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error requesting a token for service account %s/%s", pod.Namespace, pod.Spec.ServiceAccountName)
+	}
+
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				ServerName: fmt.Sprintf("%s.%s.svc", constants.OSMControllerName, osmNamespace),
+				RootCAs:    certPool,
+			},
+		},
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("https://%s%s", bootstrapAddress, bootstrapCertificatePath), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.Status.Token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("Bootstrap request failed with status %s", resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// getBootstrapCABundle returns the root certificate the sidecar injector configured in the bootstrap container of the pod.
+func getBootstrapCABundle(pod *corev1.Pod) string {
+	for _, container := range pod.Spec.InitContainers {
+		for _, env := range container.Env {
+			if env.Name == bootstrapCABundleEnvVar {
+				return env.Value
+			}
+		}
+	}
+	return ""
+}
+
+// getTLSConfig returns the TLS config for the xDS connection of the Envoy with the given SDS certificate file,
+// verifying the xDS server with the given root certificate.
+func getTLSConfig(certificateYAML, rootCert []byte) (*tls.Config, error) {
+	var xdsCert xdsCertificate
+	if err := yaml.Unmarshal(certificateYAML, &xdsCert); err != nil {
+		return nil, err
+	}
+	if len(xdsCert.Resources) == 0 {
+		return nil, errNoCertificate
+	}
+
+	tlsCertificate := xdsCert.Resources[0].TLSCertificate
+	certChain, err := base64.StdEncoding.DecodeString(tlsCertificate.CertificateChain.InlineBytes)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := base64.StdEncoding.DecodeString(tlsCertificate.PrivateKey.InlineBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.X509KeyPair(certChain, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(rootCert) {
+		return nil, errInvalidRootCert
+	}
+
+	return &tls.Config{
+		ServerName:   xdsServerName,
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      certPool,
+	}, nil
+}
+
+// applyTrafficPolicy allows traffic from every service to the first one,
+// which changes the configuration of every proxy of the synthetic workload.
+func applyTrafficPolicy(kubeConfig *rest.Config, ns string, services int) error {
+	specClient, err := smiSpecClient.NewForConfig(kubeConfig)
+	if err != nil {
+		return err
+	}
+	accessClient, err := smiAccessClient.NewForConfig(kubeConfig)
+	if err != nil {
+		return err
+	}
+
+	routeGroup := &spec.HTTPRouteGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: trafficPolicyName},
+		Spec: spec.HTTPRouteGroupSpec{
+			Matches: []spec.HTTPMatch{{
+				Name:      routeMatchName,
+				PathRegex: ".*",
+				Methods:   []string{"*"},
+			}},
+		},
+	}
+	if _, err := specClient.SpecsV1alpha3().HTTPRouteGroups(ns).Create(context.Background(), routeGroup, metav1.CreateOptions{}); err != nil {
+		return errors.Wrapf(err, "Error creating HTTPRouteGroup %s/%s", ns, trafficPolicyName)
+	}
+
+	trafficTarget := &target.TrafficTarget{
+		ObjectMeta: metav1.ObjectMeta{Name: trafficPolicyName},
+		Spec: target.TrafficTargetSpec{
+			Destination: target.IdentityBindingSubject{
+				Kind:      "ServiceAccount",
+				Name:      getServiceName(0),
+				Namespace: ns,
+			},
+			Rules: []target.TrafficTargetRule{{
+				Kind:    "HTTPRouteGroup",
+				Name:    trafficPolicyName,
+				Matches: []string{routeMatchName},
+			}},
+		},
+	}
+	for i := 0; i < services; i++ {
+		trafficTarget.Spec.Sources = append(trafficTarget.Spec.Sources, target.IdentityBindingSubject{
+			Kind:      "ServiceAccount",
+			Name:      getServiceName(i),
+			Namespace: ns,
+		})
+	}
+	if _, err := accessClient.AccessV1alpha2().TrafficTargets(ns).Create(context.Background(), trafficTarget, metav1.CreateOptions{}); err != nil {
+		return errors.Wrapf(err, "Error creating TrafficTarget %s/%s", ns, trafficPolicyName)
+	}
+	return nil
+}
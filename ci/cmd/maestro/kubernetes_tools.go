@@ -6,6 +6,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
 	"os"
 	"sort"
 	"strings"
@@ -14,12 +17,15 @@ import (
 
 	"github.com/Azure/go-autorest/autorest/to"
 	mapset "github.com/deckarep/golang-set"
+	"github.com/pkg/errors"
 	"k8s.io/api/admissionregistration/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
 )
 
 // We are going to wait for the Pod certain amount of time if it is in one of these statuses
@@ -174,8 +180,8 @@ func SearchLogsForSuccess(kubeClient kubernetes.Interface, namespace string, pod
 	}()
 }
 
-// GetKubernetesClient returns a k8s client.
-func GetKubernetesClient() *kubernetes.Clientset {
+// GetKubernetesConfig returns the config of the k8s cluster from KUBECONFIG, or the in-cluster config.
+func GetKubernetesConfig() *rest.Config {
 	var kubeConfig *rest.Config
 	var err error
 	kubeConfigFile := os.Getenv(KubeConfigEnvVar)
@@ -193,8 +199,12 @@ func GetKubernetesClient() *kubernetes.Clientset {
 			os.Exit(1)
 		}
 	}
+	return kubeConfig
+}
 
-	clientset, err := kubernetes.NewForConfig(kubeConfig)
+// GetKubernetesClient returns a k8s client.
+func GetKubernetesClient() *kubernetes.Clientset {
+	clientset, err := kubernetes.NewForConfig(GetKubernetesConfig())
 	if err != nil {
 		fmt.Println("error in getting access to K8S")
 		os.Exit(1)
@@ -202,6 +212,57 @@ func GetKubernetesClient() *kubernetes.Clientset {
 	return clientset
 }
 
+// ForwardPort forwards a random local port to the given port of the pod, until the stop channel is closed.
+// It returns the local port once the tunnel is ready.
+func ForwardPort(kubeConfig *rest.Config, pod *corev1.Pod, podPort int, stop <-chan struct{}) (uint16, error) {
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/portforward", pod.Namespace, pod.Name)
+	hostURL, err := url.Parse(kubeConfig.Host)
+	if err != nil {
+		return 0, err
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(kubeConfig)
+	if err != nil {
+		return 0, err
+	}
+
+	client := &http.Client{Transport: transport}
+	u := &url.URL{Scheme: "https", Path: path, Host: hostURL.Host}
+	ready := make(chan struct{})
+	fw, err := portforward.New(
+		spdy.NewDialer(upgrader, client, http.MethodPost, u),
+		[]string{fmt.Sprintf(":%d", podPort)},
+		stop,
+		ready,
+		ioutil.Discard,
+		ioutil.Discard,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- fw.ForwardPorts()
+	}()
+
+	select {
+	case <-ready:
+	case err := <-errs:
+		return 0, errors.Wrapf(err, "Error forwarding port %d of pod %s/%s", podPort, pod.Namespace, pod.Name)
+	}
+
+	ports, err := fw.GetPorts()
+	if err != nil {
+		return 0, err
+	}
+	if len(ports) == 0 {
+		return 0, errNoForwardedPorts
+	}
+	log.Info().Msgf("Forwarding local port %d to port %d of pod %s/%s", ports[0].Local, podPort, pod.Namespace, pod.Name)
+	return ports[0].Local, nil
+}
+
 // WaitForPodToBeReady waits for a pod by selector to be ready.
 func WaitForPodToBeReady(kubeClient kubernetes.Interface, totalWait time.Duration, namespace, selector string, wg *sync.WaitGroup) {
 	startedWaiting := time.Now()
@@ -57,4 +57,6 @@ var (
 
 	log            = logger.New("ci/maestro")
 	errNoPodsFound = errors.New("no pods found")
+
+	errNoForwardedPorts = errors.New("no forwarded ports")
 )
@@ -74,6 +74,7 @@ type installCmd struct {
 	serviceCertValidityMinutes    int
 	prometheusRetentionTime       string
 	enableDebugServer             bool
+	enableProfiling               bool
 	enablePermissiveTrafficPolicy bool
 	enableEgress                  bool
 	meshName                      string
@@ -117,6 +118,7 @@ func newInstallCmd(config *helm.Configuration, out io.Writer) *cobra.Command {
 	f.IntVar(&inst.serviceCertValidityMinutes, "service-cert-validity-minutes", defaultCertValidityMinutes, "Certificate TTL in minutes")
 	f.StringVar(&inst.prometheusRetentionTime, "prometheus-retention-time", constants.PrometheusDefaultRetentionTime, "Duration for which data will be retained in prometheus")
 	f.BoolVar(&inst.enableDebugServer, "enable-debug-server", false, "Enable the debug HTTP server")
+	f.BoolVar(&inst.enableProfiling, "enable-profiling", false, "Expose the pprof and runtime trace endpoints on the debug HTTP server; requires --enable-debug-server")
 	f.BoolVar(&inst.enablePermissiveTrafficPolicy, "enable-permissive-traffic-policy", false, "Enable permissive traffic policy mode")
 	f.BoolVar(&inst.enableEgress, "enable-egress", false, "Enable egress in the mesh")
 	f.StringSliceVar(&inst.meshCIDRRanges, "mesh-cidr", []string{}, "mesh CIDR range, accepts multiple CIDRs, required if enable-egress option is true")
@@ -159,6 +161,10 @@ func (i *installCmd) run(config *helm.Configuration) error {
 		}
 	}
 
+	if i.enableProfiling && !i.enableDebugServer {
+		return errors.Errorf("Profiling is served by the debug server; please enable it using --enable-debug-server")
+	}
+
 	// Validate CIDR ranges if egress is enabled
 	if i.enableEgress {
 		if err := validateCIDRs(i.meshCIDRRanges); err != nil {
@@ -213,6 +219,7 @@ func (i *installCmd) resolveValues() (map[string]interface{}, error) {
 		fmt.Sprintf("OpenServiceMesh.serviceCertValidityMinutes=%d", i.serviceCertValidityMinutes),
 		fmt.Sprintf("OpenServiceMesh.prometheus.retention.time=%s", i.prometheusRetentionTime),
 		fmt.Sprintf("OpenServiceMesh.enableDebugServer=%t", i.enableDebugServer),
+		fmt.Sprintf("OpenServiceMesh.enableProfiling=%t", i.enableProfiling),
 		fmt.Sprintf("OpenServiceMesh.enablePermissiveTrafficPolicy=%t", i.enablePermissiveTrafficPolicy),
 		fmt.Sprintf("OpenServiceMesh.enableBackpressureExperimental=%t", i.enableBackpressureExperimental),
 		fmt.Sprintf("OpenServiceMesh.enableMetricsStack=%t", i.enableMetricsStack),
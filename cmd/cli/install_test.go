@@ -120,6 +120,7 @@ var _ = Describe("Running the install command", func() {
 								"time": "5d",
 							}},
 						"enableDebugServer":              false,
+						"enableProfiling":                false,
 						"enablePermissiveTrafficPolicy":  false,
 						"enableBackpressureExperimental": false,
 						"enableEgress":                   true,
@@ -224,6 +225,7 @@ var _ = Describe("Running the install command", func() {
 								"time": "5d",
 							}},
 						"enableDebugServer":              false,
+						"enableProfiling":                false,
 						"enablePermissiveTrafficPolicy":  false,
 						"enableBackpressureExperimental": false,
 						"enableEgress":                   true,
@@ -333,6 +335,7 @@ var _ = Describe("Running the install command", func() {
 							},
 						},
 						"enableDebugServer":              false,
+						"enableProfiling":                false,
 						"enablePermissiveTrafficPolicy":  false,
 						"enableBackpressureExperimental": false,
 						"enableEgress":                   true,
@@ -390,6 +393,47 @@ var _ = Describe("Running the install command", func() {
 		})
 	})
 
+	Describe("with profiling enabled and the debug server disabled", func() {
+		var (
+			out    *bytes.Buffer
+			store  *storage.Storage
+			config *helm.Configuration
+			err    error
+		)
+
+		BeforeEach(func() {
+			out = new(bytes.Buffer)
+			store = storage.Init(driver.NewMemory())
+			if mem, ok := store.Driver.(*driver.Memory); ok {
+				mem.SetNamespace(settings.Namespace())
+			}
+
+			config = &helm.Configuration{
+				Releases: store,
+				KubeClient: &kubefake.PrintingKubeClient{
+					Out: ioutil.Discard},
+				Capabilities: chartutil.DefaultCapabilities,
+				Log:          func(format string, v ...interface{}) {},
+			}
+
+			installCmd := &installCmd{
+				out:                     out,
+				chartPath:               "testdata/test-chart",
+				containerRegistry:       testRegistry,
+				containerRegistrySecret: testRegistrySecret,
+				certManager:             "tresor",
+				meshName:                defaultMeshName,
+				enableProfiling:         true,
+			}
+
+			err = installCmd.run(config)
+		})
+
+		It("should error", func() {
+			Expect(err).To(MatchError("Profiling is served by the debug server; please enable it using --enable-debug-server"))
+		})
+	})
+
 	Describe("when a mesh with the given name already exists", func() {
 		var (
 			out     *bytes.Buffer
@@ -559,6 +603,7 @@ var _ = Describe("Resolving values for install command with vault parameters", f
 					},
 				},
 				"enableDebugServer":              false,
+				"enableProfiling":                false,
 				"enablePermissiveTrafficPolicy":  false,
 				"enableBackpressureExperimental": false,
 				"enableEgress":                   true,
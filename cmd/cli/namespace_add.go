@@ -64,8 +64,16 @@ func (a *namespaceAddCmd) run() error {
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
+		namespace, err := a.clientSet.CoreV1().Namespaces().Get(ctx, ns, metav1.GetOptions{})
+		if err != nil {
+			return errors.Errorf("Could not label namespace [%s]: %v", ns, err)
+		}
+		if val, exists := namespace.ObjectMeta.Labels[constants.OSMKubeResourceMonitorAnnotation]; exists && val != a.meshName {
+			return errors.Errorf("Namespace [%s] already belongs to mesh [%s]. Please remove it from mesh [%s] first", ns, val, val)
+		}
+
 		patch := `{"metadata":{"labels":{"` + constants.OSMKubeResourceMonitorAnnotation + `":"` + a.meshName + `"}}}`
-		_, err := a.clientSet.CoreV1().Namespaces().Patch(ctx, ns, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{}, "")
+		_, err = a.clientSet.CoreV1().Namespaces().Patch(ctx, ns, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{}, "")
 		if err != nil {
 			return errors.Errorf("Could not label namespace [%s]: %v", ns, err)
 		}
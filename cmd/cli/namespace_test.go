@@ -121,6 +121,42 @@ var _ = Describe("Running the namespace add command", func() {
 			Expect(err.Error()).To(Equal(fmt.Sprintf("Could not label namespace [%s]: namespaces \"%s\" not found", testNamespace, testNamespace)))
 		})
 	})
+
+	Describe("with pre-existing namespace belonging to another mesh", func() {
+		var (
+			out           *bytes.Buffer
+			fakeClientSet kubernetes.Interface
+			err           error
+		)
+
+		BeforeEach(func() {
+			out = new(bytes.Buffer)
+			fakeClientSet = fake.NewSimpleClientset()
+
+			nsSpec := createNamespaceSpec(testNamespace, incorrectMeshName)
+			fakeClientSet.CoreV1().Namespaces().Create(context.TODO(), nsSpec, metav1.CreateOptions{})
+
+			namespaceAddCmd := &namespaceAddCmd{
+				out:        out,
+				meshName:   testMeshName,
+				namespaces: []string{testNamespace},
+				clientSet:  fakeClientSet,
+			}
+
+			err = namespaceAddCmd.run()
+		})
+
+		It("should error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(fmt.Sprintf("Namespace [%s] already belongs to mesh [%s]. Please remove it from mesh [%s] first", testNamespace, incorrectMeshName, incorrectMeshName)))
+		})
+
+		It("should not relabel the namespace", func() {
+			ns, err := fakeClientSet.CoreV1().Namespaces().Get(context.TODO(), testNamespace, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ns.Labels[constants.OSMKubeResourceMonitorAnnotation]).To(Equal(incorrectMeshName))
+		})
+	})
 })
 
 var _ = Describe("Running the namespace remove command", func() {
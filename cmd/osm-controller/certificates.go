@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 
@@ -60,6 +61,12 @@ func getTresorCertificateManager(kubeClient kubernetes.Interface, enableDebug bo
 	// load the CA from the given k8s secret within the namespace where OSM is install.d
 	// An empty string or nil value would not load or save/load CA.
 	if caBundleSecretName != "" {
+		// With sharding, replicas minting their own CA would not trust the proxies of each other
+		if enableSharding {
+			if err := checkCASecretPrivateKey(kubeClient, osmNamespace, caBundleSecretName); err != nil {
+				return nil, nil, err
+			}
+		}
 		rootCert = getCertFromKubernetes(kubeClient, osmNamespace, caBundleSecretName)
 	}
 
@@ -87,6 +94,24 @@ func getTresorCertificateManager(kubeClient kubernetes.Interface, enableDebug bo
 	return certManager, certManager, nil
 }
 
+// checkCASecretPrivateKey returns an error when the CA bundle secret exists without the CA's private key,
+// such as a secret exported before sharding was enabled, from which the CA cannot be shared by the replicas.
+func checkCASecretPrivateKey(kubeClient kubernetes.Interface, namespace, secretName string) error {
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(context.Background(), secretName, v1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "Error retrieving CA bundle secret %s/%s", namespace, secretName)
+	}
+
+	if _, ok := secret.Data[constants.KubernetesOpaqueSecretRootPrivateKeyKey]; !ok {
+		return errors.Errorf("CA bundle secret %s/%s does not have the CA's private key %q required by sharding; delete the secret for the replicas to share a new CA",
+			namespace, secretName, constants.KubernetesOpaqueSecretRootPrivateKeyKey)
+	}
+	return nil
+}
+
 func getCertFromKubernetes(kubeClient kubernetes.Interface, namespace, secretName string) certificate.Certificater {
 	secrets, err := kubeClient.CoreV1().Secrets(namespace).List(context.Background(), v1.ListOptions{})
 	if err != nil {
@@ -69,6 +69,50 @@ var _ = Describe("Test CMD tools", func() {
 		})
 	})
 
+	Context("Testing checkCASecretPrivateKey", func() {
+		It("accepts a missing secret, and a secret with the private key", func() {
+			kubeClient := testclient.NewSimpleClientset()
+
+			ns := uuid.New().String()
+			secretName := uuid.New().String()
+			Expect(checkCASecretPrivateKey(kubeClient, ns, secretName)).To(Succeed())
+
+			secret := &corev1.Secret{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      secretName,
+					Namespace: ns,
+				},
+				Data: map[string][]byte{
+					constants.KubernetesOpaqueSecretCAKey:             []byte(uuid.New().String()),
+					constants.KubernetesOpaqueSecretRootPrivateKeyKey: []byte(uuid.New().String()),
+				},
+			}
+			_, err := kubeClient.CoreV1().Secrets(ns).Create(context.Background(), secret, v1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(checkCASecretPrivateKey(kubeClient, ns, secretName)).To(Succeed())
+		})
+
+		It("rejects a secret without the private key", func() {
+			kubeClient := testclient.NewSimpleClientset()
+
+			ns := uuid.New().String()
+			secretName := uuid.New().String()
+
+			secret := &corev1.Secret{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      secretName,
+					Namespace: ns,
+				},
+				Data: map[string][]byte{
+					constants.KubernetesOpaqueSecretCAKey: []byte(uuid.New().String()),
+				},
+			}
+			_, err := kubeClient.CoreV1().Secrets(ns).Create(context.Background(), secret, v1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(checkCASecretPrivateKey(kubeClient, ns, secretName)).ToNot(Succeed())
+		})
+	})
+
 	Context("Testing saveSecretToKubernetes", func() {
 		It("saves root cert to k8s", func() {
 			kubeClient := testclient.NewSimpleClientset()
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/injector"
+	"github.com/openservicemesh/osm/pkg/multicluster"
+	"github.com/openservicemesh/osm/pkg/namespace"
+)
+
+// provisionMulticlusterGateway issues the certificate the multicluster gateway connects to XDS with,
+// and stores the gateway's bootstrap config in a secret mounted by the gateway's deployment.
+func provisionMulticlusterGateway(kubeClient kubernetes.Interface, certManager certificate.Manager, cfg configurator.Configurator, osmNamespace string) error {
+	if cfg.GetClusterName() == "" {
+		log.Warn().Msg("The multicluster gateway is enabled, but no cluster name is configured; no services will be exported")
+	}
+
+	cn := multicluster.GetGatewayCommonName(osmNamespace)
+	validityPeriod := constants.XDSCertificateValidityPeriod
+	bootstrapCertificate, err := certManager.IssueCertificate(cn, &validityPeriod)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error issuing bootstrap certificate for multicluster gateway with CN=%s", cn)
+		return err
+	}
+
+	if _, err := injector.CreateEnvoyBootstrapConfig(kubeClient, cfg, multicluster.GatewayBootstrapSecretName, osmNamespace, osmNamespace, bootstrapCertificate); err != nil {
+		log.Error().Err(err).Msg("Failed to create bootstrap config for multicluster gateway")
+		return err
+	}
+	return nil
+}
+
+// startServiceMirror starts mirroring the services exported by the remote cluster to the local cluster.
+func startServiceMirror(kubeClient kubernetes.Interface, namespaceController namespace.Controller, stop <-chan struct{}) error {
+	remoteKubeConfig, err := clientcmd.BuildConfigFromFlags("", remoteClusterKubeConfig)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error creating kube config for remote cluster %s (kubeconfig=%s)", remoteClusterName, remoteClusterKubeConfig)
+		return err
+	}
+	remoteKubeClient, err := kubernetes.NewForConfig(remoteKubeConfig)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error creating kube client for remote cluster %s", remoteClusterName)
+		return err
+	}
+
+	_, err = multicluster.NewMirror(kubeClient, remoteKubeClient, namespaceController, watchedNamespaces, remoteClusterName, remoteClusterOSMNamespace, stop)
+	return err
+}
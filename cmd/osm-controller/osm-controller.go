@@ -4,11 +4,14 @@ import (
 	"context"
 	"flag"
 	"os"
+	"time"
 
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
@@ -23,13 +26,21 @@ import (
 	azureResource "github.com/openservicemesh/osm/pkg/endpoint/providers/azure/kubernetes"
 	"github.com/openservicemesh/osm/pkg/endpoint/providers/kube"
 	"github.com/openservicemesh/osm/pkg/envoy/ads"
+	"github.com/openservicemesh/osm/pkg/envoy/snapshot"
 	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/gateway"
+	"github.com/openservicemesh/osm/pkg/health"
 	"github.com/openservicemesh/osm/pkg/httpserver"
 	"github.com/openservicemesh/osm/pkg/ingress"
 	"github.com/openservicemesh/osm/pkg/injector"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/leader"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/metricsstore"
+	"github.com/openservicemesh/osm/pkg/multicluster"
 	"github.com/openservicemesh/osm/pkg/namespace"
+	"github.com/openservicemesh/osm/pkg/networkpolicy"
+	"github.com/openservicemesh/osm/pkg/sharding"
 	"github.com/openservicemesh/osm/pkg/signals"
 	"github.com/openservicemesh/osm/pkg/smi"
 	"github.com/openservicemesh/osm/pkg/utils"
@@ -40,6 +51,10 @@ const (
 	defaultServiceCertValidityMinutes = 60 // 1 hour
 	caBundleSecretNameCLIParam        = "ca-bundle-secret-name"
 	xdsServerCertificateCommonName    = "ads"
+	ingressClientCertCheckInterval    = 1 * time.Minute
+	networkPolicyResyncInterval       = 10 * time.Minute
+	defaultDrainTimeoutSeconds        = 30
+	defaultMaxConcurrentBootstraps    = 100
 )
 
 var (
@@ -49,10 +64,22 @@ var (
 	kubeConfigFile             string
 	osmNamespace               string
 	webhookName                string
+	validatingWebhookName      string
 	serviceCertValidityMinutes int
 	caBundleSecretName         string
 	enableDebugServer          bool
+	enableProfiling            bool
 	osmConfigMapName           string
+	enableMulticlusterGateway  bool
+	enableSharding             bool
+	remoteClusterName          string
+	remoteClusterKubeConfig    string
+	remoteClusterOSMNamespace  string
+	drainTimeoutSeconds        int
+	snapshotDir                string
+	maxConcurrentBootstraps    int
+	enableNetworkPolicies      bool
+	watchedNamespaces          []string
 
 	injectorConfig injector.Config
 
@@ -85,19 +112,36 @@ func init() {
 	flags.StringVar(&kubeConfigFile, "kubeconfig", "", "Path to Kubernetes config file.")
 	flags.StringVar(&osmNamespace, "osm-namespace", "", "Namespace to which OSM belongs to.")
 	flags.StringVar(&webhookName, "webhook-name", "", "Name of the MutatingWebhookConfiguration to be configured by ADS")
+	flags.StringVar(&validatingWebhookName, "validating-webhook-name", "", "Name of the ValidatingWebhookConfiguration to be configured by ADS")
 	flags.IntVar(&serviceCertValidityMinutes, "service-cert-validity-minutes", defaultServiceCertValidityMinutes, "Certificate validityPeriod duration in minutes")
 	flags.StringVar(&caBundleSecretName, caBundleSecretNameCLIParam, "", "Name of the Kubernetes Secret for the OSM CA bundle")
 	flags.BoolVar(&enableDebugServer, "enable-debug-server", false, "Enable OSM debug HTTP server")
+	flags.BoolVar(&enableProfiling, "enable-profiling", false, "Expose the pprof and runtime trace endpoints on the debug HTTP server; requires --enable-debug-server")
 	flags.StringVar(&osmConfigMapName, "osm-configmap-name", "osm-config", "Name of the OSM ConfigMap")
+	flags.BoolVar(&enableSharding, "enable-sharding", false, "Shard the connected proxies across the osm-controller replicas, and elect a leader for singleton duties")
+	flags.BoolVar(&enableMulticlusterGateway, "enable-multicluster-gateway", false, "Enable the multicluster gateway exporting services to other meshes")
+	flags.StringVar(&remoteClusterName, "remote-cluster-name", "", "Name of the remote cluster to mirror exported services from")
+	flags.StringVar(&remoteClusterKubeConfig, "remote-cluster-kubeconfig", "", "Path to the Kubernetes config file of the remote cluster")
+	flags.StringVar(&remoteClusterOSMNamespace, "remote-cluster-osm-namespace", "osm-system", "Namespace OSM is installed in on the remote cluster")
+	flags.IntVar(&drainTimeoutSeconds, "drain-timeout-seconds", defaultDrainTimeoutSeconds, "Time in seconds given to the connected proxies to move to other replicas on shutdown")
+	flags.IntVar(&maxConcurrentBootstraps, "max-concurrent-bootstraps", defaultMaxConcurrentBootstraps, "Maximum number of proxies concurrently sent their initial configuration; 0 for no maximum")
+	flags.BoolVar(&enableNetworkPolicies, "enable-network-policies", false, "Generate Kubernetes NetworkPolicies allowing the traffic allowed by the SMI traffic policies")
+	flags.StringSliceVar(&watchedNamespaces, "watched-namespaces", nil, "Comma-separated namespaces the mesh is restricted to; the controller watches and monitors all namespaces labeled for the mesh when unset")
+	flags.StringVar(&snapshotDir, "snapshot-dir", "", "Directory in which the configuration of the proxies is persisted, to serve them on restart while caches sync")
 
 	// sidecar injector options
 	flags.BoolVar(&injectorConfig.DefaultInjection, "default-injection", true, "Enable sidecar injection by default")
 	flags.IntVar(&injectorConfig.ListenPort, "webhook-port", constants.InjectorWebhookPort, "Webhook port for sidecar-injector")
 	flags.StringVar(&injectorConfig.InitContainerImage, "init-container-image", "", "InitContainer image")
 	flags.StringVar(&injectorConfig.SidecarImage, "sidecar-image", "", "Sidecar proxy Container image")
+	flags.StringVar(&injectorConfig.InitContainerWindowsImage, "init-container-windows-image", "", "InitContainer image of Windows pods; Windows pods are not injected when unset")
+	flags.StringVar(&injectorConfig.SidecarWindowsImage, "sidecar-windows-image", "", "Sidecar proxy Container image of Windows pods; Windows pods are not injected when unset")
 
 	// feature flags
 	flags.BoolVar(&optionalFeatures.Backpressure, "enable-backpressure-experimental", false, "Enable experimental backpressure feature")
+	flags.BoolVar(&optionalFeatures.GatewayAPI, "enable-gateway-api-experimental", false, "Enable experimental Gateway API feature")
+	flags.BoolVar(&optionalFeatures.TLSOrigination, "enable-egress-tls-origination-experimental", false, "Enable experimental egress TLS origination feature")
+	flags.BoolVar(&optionalFeatures.SidecarScope, "enable-sidecar-scope-experimental", false, "Enable experimental sidecar scope feature")
 }
 
 func main() {
@@ -127,7 +171,10 @@ func main() {
 	}
 	kubeClient := kubernetes.NewForConfigOrDie(kubeConfig)
 
-	stop := signals.RegisterExitHandlers()
+	// The informers and workers are only stopped once the connected proxies are drained on exit,
+	// so that the proxies are served up-to-date configuration until they move to other replicas.
+	exit := signals.RegisterExitHandlers()
+	stop := make(chan struct{})
 
 	// This component will be watching the OSM ConfigMap and will make it
 	// to the rest of the components.
@@ -138,12 +185,6 @@ func main() {
 	}
 	log.Info().Msgf("Initial ConfigMap %s: %s", osmConfigMapName, string(configMap))
 
-	namespaceController := namespace.NewNamespaceController(kubeClient, meshName, stop)
-	meshSpec, err := smi.NewMeshSpecClient(*smiKubeConfig, kubeClient, osmNamespace, namespaceController, stop)
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to create new mesh spec client")
-	}
-
 	// Get the Certificate Manager based on the CLI argument passed to this module.
 	certManager, certDebugger, err := certManagers[certificateManagerKind(*certManagerKind)](kubeClient, enableDebugServer)
 	if err != nil {
@@ -155,12 +196,81 @@ func main() {
 	if caBundleSecretName == "" {
 		log.Info().Msgf("CA bundle will not be exported to a k8s secret (no --%s provided)", caBundleSecretNameCLIParam)
 	} else {
-		if err := createCABundleKubernetesSecret(kubeClient, certManager, osmNamespace, caBundleSecretName); err != nil {
+		if err := createCABundleKubernetesSecret(kubeClient, certManager, osmNamespace, caBundleSecretName, enableSharding); err != nil {
 			log.Error().Err(err).Msgf("Error exporting CA bundle into Kubernetes secret with name %s", caBundleSecretName)
+
+			// All replicas must issue certificates with the same CA: load the one exported by another replica
+			if enableSharding && apierrors.IsAlreadyExists(err) {
+				if certManager, certDebugger, err = certManagers[certificateManagerKind(*certManagerKind)](kubeClient, enableDebugServer); err != nil {
+					log.Fatal().Err(err).Msgf("Failed to get certificate manager with the CA bundle from Kubernetes secret %s", caBundleSecretName)
+				}
+			}
+		}
+	}
+
+	// Restricted to a list of namespaces, the controller requires no permission on cluster-scoped namespace resources
+	var namespaceController namespace.Controller
+	if len(watchedNamespaces) > 0 {
+		namespaceController = namespace.NewStaticNamespaceController(watchedNamespaces)
+	} else {
+		namespaceController = namespace.NewNamespaceController(kubeClient, meshName, osmNamespace, stop)
+	}
+
+	// The webhook server is started before the caches sync below, for the pod to be ready while snapshots are served:
+	// pods are injected meanwhile, and their proxies are issued certificates once the caches are synced.
+	webhook, err := injector.NewWebhook(injectorConfig, kubeClient, certManager, namespaceController, meshName, osmNamespace, osmConfigMapName, webhookName, validatingWebhookName, stop, cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error creating mutating webhook")
+	}
+
+	// The xDS server is started before the caches sync below, to serve the snapshots of the proxies meanwhile
+	var snapshots *snapshot.Store
+	if snapshotDir != "" {
+		if snapshots, err = snapshot.NewStore(snapshotDir, stop); err != nil {
+			log.Fatal().Err(err).Msgf("Error creating snapshot store in %s", snapshotDir)
 		}
 	}
 
-	provider, err := kube.NewProvider(kubeClient, namespaceController, stop, constants.KubeProviderName, cfg)
+	// TODO(draychev): figure out the NS and POD
+	metricsStore := metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName")
+	metricsStore.Start()
+
+	// With sharding, the connected proxies are spread across the replicas. The proxies are assigned before the caches
+	// sync, so that the snapshots are only served by the replica a proxy is assigned to.
+	var sharder sharding.Sharder
+	podName := os.Getenv(constants.EnvVarPodName)
+	if enableSharding {
+		if sharder, err = sharding.NewSharder(kubeClient, osmNamespace, constants.OSMControllerName, podName, stop); err != nil {
+			log.Fatal().Err(err).Msg("Failed to initialize sharding of proxies across replicas")
+		}
+	}
+
+	xdsServer := ads.NewADSServer(ctx, enableDebugServer, osmNamespace, cfg, kubeClient, snapshots, sharder, maxConcurrentBootstraps, metricsStore)
+
+	// TODO(draychev): we need to pass this hard-coded string is a CLI argument (https://github.com/openservicemesh/osm/issues/542)
+	validityPeriod := constants.XDSCertificateValidityPeriod
+	adsCert, err := certManager.IssueCertificate(xdsServerCertificateCommonName, &validityPeriod)
+	if err != nil {
+		log.Fatal().Err(err)
+	}
+
+	grpcServer, lis := utils.NewGrpc(serverType, *port, adsCert.GetCertificateChain(), adsCert.GetPrivateKey(), adsCert.GetIssuingCA())
+	xds_discovery.RegisterAggregatedDiscoveryServiceServer(grpcServer, xdsServer)
+
+	// The connections of the proxies rejected by this replica are closed, for them to reconnect to another replica
+	go utils.GrpcServe(ctx, grpcServer, xdsServer.TrackConnections(lis), cancel, serverType)
+
+	// initialize the http server and start it
+	// The pod is ready once both the xDS and the webhook servers are, as the osm-controller service routes to both
+	httpServer := httpserver.NewHTTPServer(health.Combine(xdsServer, webhook), metricsStore, constants.MetricsServerPort, nil)
+	httpServer.Start()
+
+	meshSpec, err := smi.NewMeshSpecClient(*smiKubeConfig, kubeClient, osmNamespace, namespaceController, watchedNamespaces, stop)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create new mesh spec client")
+	}
+
+	provider, err := kube.NewProvider(kubeClient, namespaceController, watchedNamespaces, stop, constants.KubeProviderName, cfg)
 	if err != nil {
 		log.Fatal().Err(err).Msgf("Failed to get endpoint provider")
 	}
@@ -168,7 +278,7 @@ func main() {
 	endpointsProviders := []endpoint.Provider{provider}
 
 	if azureAuthFile != "" {
-		azureResourceClient, err := azureResource.NewClient(kubeClient, kubeConfig, namespaceController, stop, cfg)
+		azureResourceClient, err := azureResource.NewClient(kubeClient, kubeConfig, namespaceController, watchedNamespaces, stop, cfg)
 		if err != nil {
 			log.Fatal().Err(err).Msg("Failed to initialize azure resource client")
 		}
@@ -179,54 +289,92 @@ func main() {
 		endpointsProviders = append(endpointsProviders, azureProvider)
 	}
 
-	ingressClient, err := ingress.NewIngressClient(kubeClient, namespaceController, stop, cfg)
+	ingressClient, err := ingress.NewIngressClient(kubeClient, namespaceController, watchedNamespaces, stop, cfg)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to initialize ingress client")
 	}
 
+	gatewayClient, err := gateway.NewGatewayClient(dynamic.NewForConfigOrDie(kubeConfig), namespaceController, stop)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize Gateway API client")
+	}
+
+	kubeController, err := k8s.NewKubernetesController(kubeClient, watchedNamespaces, stop)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize Kubernetes controller")
+	}
+
 	meshCatalog := catalog.NewMeshCatalog(
 		namespaceController,
-		kubeClient,
+		kubeController,
 		meshSpec,
 		certManager,
 		ingressClient,
+		gatewayClient,
 		stop,
 		cfg,
 		endpointsProviders...)
 
-	// Create the sidecar-injector webhook
-	if err := injector.NewWebhook(injectorConfig, kubeClient, certManager, meshCatalog, namespaceController, meshName, osmNamespace, webhookName, stop, cfg); err != nil {
-		log.Fatal().Err(err).Msg("Error creating mutating webhook")
+	if enableMulticlusterGateway {
+		meshCatalog.ExpectProxy(multicluster.GetGatewayCommonName(osmNamespace))
 	}
 
-	xdsServer := ads.NewADSServer(ctx, meshCatalog, enableDebugServer, osmNamespace, cfg)
+	// runSingletonDuties performs the duties of which a single replica must be in charge,
+	// until leaderStop is closed.
+	runSingletonDuties := func(leaderStop <-chan struct{}) {
+		// Issue the client certificate configured for the ingress controller and refresh it before it expires
+		ingress.NewClientCertProvisioner(kubeClient, namespaceController, certManager, cfg, getServiceCertValidityPeriod()).Start(ingressClientCertCheckInterval, leaderStop)
+
+		if enableMulticlusterGateway {
+			if err := provisionMulticlusterGateway(kubeClient, certManager, cfg, osmNamespace); err != nil {
+				log.Fatal().Err(err).Msg("Error provisioning multicluster gateway")
+			}
+			if err := multicluster.StartGatewayPortSync(kubeClient, namespaceController, watchedNamespaces, osmNamespace, leaderStop); err != nil {
+				log.Fatal().Err(err).Msg("Error syncing the ports of the multicluster gateway")
+			}
+		}
 
-	// TODO(draychev): we need to pass this hard-coded string is a CLI argument (https://github.com/openservicemesh/osm/issues/542)
-	validityPeriod := constants.XDSCertificateValidityPeriod
-	adsCert, err := certManager.IssueCertificate(xdsServerCertificateCommonName, &validityPeriod)
-	if err != nil {
-		log.Fatal().Err(err)
-	}
+		// No data plane is provisioned for the Gateways managed by OSM, which is reported in their status
+		gatewayClient.ReportStatus(leaderStop)
 
-	grpcServer, lis := utils.NewGrpc(serverType, *port, adsCert.GetCertificateChain(), adsCert.GetPrivateKey(), adsCert.GetIssuingCA())
-	xds_discovery.RegisterAggregatedDiscoveryServiceServer(grpcServer, xdsServer)
+		if enableNetworkPolicies {
+			// Drop at L3/L4 the traffic denied by the SMI traffic policies, and keep the NetworkPolicies in sync with them
+			networkpolicy.NewGenerator(kubeClient, meshCatalog, cfg, meshName, watchedNamespaces).Start(networkPolicyResyncInterval, leaderStop)
+		}
 
-	go utils.GrpcServe(ctx, grpcServer, lis, cancel, serverType)
+		if remoteClusterName != "" {
+			if err := startServiceMirror(kubeClient, namespaceController, leaderStop); err != nil {
+				log.Fatal().Err(err).Msgf("Error mirroring services from remote cluster %s", remoteClusterName)
+			}
+		}
+	}
 
-	// initialize the http server and start it
-	// TODO(draychev): figure out the NS and POD
-	metricsStore := metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName")
+	// With sharding, the singleton duties are performed by the elected leader
+	if enableSharding {
+		leader.Run(kubeClient, osmNamespace, podName, stop, runSingletonDuties)
+	} else {
+		runSingletonDuties(stop)
+	}
+
+	webhook.Start(meshCatalog)
+	xdsServer.Start(meshCatalog)
 
 	// Expose /debug endpoints and data only if the enableDebugServer flag is enabled
-	var debugServer debugger.DebugServer
 	if enableDebugServer {
-		debugServer = debugger.NewDebugServer(certDebugger, xdsServer, meshCatalog, kubeConfig, kubeClient, cfg)
+		debugServer := debugger.NewDebugServer(certDebugger, xdsServer, meshCatalog, kubeConfig, kubeController, cfg, enableProfiling)
+		httpServer.AddHandlers(debugServer.GetHandlers())
 	}
-	httpServer := httpserver.NewHTTPServer(xdsServer, metricsStore, constants.MetricsServerPort, debugServer)
-	httpServer.Start()
 
 	// Wait for exit handler signal
-	<-stop
+	<-exit
+
+	// Hand the connected proxies over to the other replicas before the informers and the gRPC server are stopped
+	xdsServer.Drain(time.Duration(drainTimeoutSeconds) * time.Second)
+	if snapshots != nil {
+		snapshots.Flush()
+	}
+	close(stop)
+	cancel()
 
 	log.Info().Msgf("[%s] Goodbye!", serverType)
 }
@@ -240,7 +388,7 @@ func parseFlags() error {
 	return nil
 }
 
-func createCABundleKubernetesSecret(kubeClient clientset.Interface, certManager certificate.Manager, namespace, caBundleSecretName string) error {
+func createCABundleKubernetesSecret(kubeClient clientset.Interface, certManager certificate.Manager, namespace, caBundleSecretName string, exportPrivateKey bool) error {
 	if caBundleSecretName == "" {
 		log.Info().Msg("No name provided for CA bundle k8s secret. Skip creation of secret")
 		return nil
@@ -252,7 +400,13 @@ func createCABundleKubernetesSecret(kubeClient clientset.Interface, certManager
 		return nil
 	}
 
-	return saveSecretToKubernetes(kubeClient, ca, namespace, caBundleSecretName, nil)
+	// The private key is exported for the other replicas to issue certificates with the same CA
+	var privKey []byte
+	if exportPrivateKey {
+		privKey = ca.GetPrivateKey()
+	}
+
+	return saveSecretToKubernetes(kubeClient, ca, namespace, caBundleSecretName, privKey)
 }
 
 func saveSecretToKubernetes(kubeClient clientset.Interface, ca certificate.Certificater, namespace, caBundleSecretName string, privKey []byte) error {
@@ -24,7 +24,7 @@ var _ = Describe("Test creation of CA bundle k8s secret", func() {
 			namespace := "--namespace--"
 			k8sClient := testclient.NewSimpleClientset()
 
-			err := createCABundleKubernetesSecret(k8sClient, certManager, namespace, secretName)
+			err := createCABundleKubernetesSecret(k8sClient, certManager, namespace, secretName, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			actual, err := k8sClient.CoreV1().Secrets(namespace).Get(context.Background(), secretName, v1.GetOptions{})
@@ -33,6 +33,24 @@ var _ = Describe("Test creation of CA bundle k8s secret", func() {
 			stringPEM := string(actual.Data[constants.KubernetesOpaqueSecretCAKey])[:len(expected)]
 			Expect(stringPEM).To(Equal(expected))
 			Expect(len(actual.Data[constants.KubernetesOpaqueSecretCAKey])).To(Equal(1915))
+			Expect(actual.Data).ToNot(HaveKey(constants.KubernetesOpaqueSecretRootPrivateKeyKey))
+		})
+
+		It("exports the private key of the CA shared by replicas", func() {
+			cache := make(map[certificate.CommonName]certificate.Certificater)
+			certManager := tresor.NewFakeCertManager(&cache, 1*time.Hour)
+			secretName := "--secret--name--"
+			namespace := "--namespace--"
+			k8sClient := testclient.NewSimpleClientset()
+
+			err := createCABundleKubernetesSecret(k8sClient, certManager, namespace, secretName, true)
+			Expect(err).ToNot(HaveOccurred())
+
+			ca, err := certManager.GetRootCertificate()
+			Expect(err).ToNot(HaveOccurred())
+			actual, err := k8sClient.CoreV1().Secrets(namespace).Get(context.Background(), secretName, v1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual.Data[constants.KubernetesOpaqueSecretRootPrivateKeyKey]).To(Equal(ca.GetPrivateKey()))
 		})
 	})
 })
@@ -37,5 +37,27 @@ func validateCLIParams() error {
 	if webhookName == "" {
 		return errors.Errorf("Invalid --webhook-name value: '%s'", webhookName)
 	}
+
+	if maxConcurrentBootstraps < 0 {
+		return errors.Errorf("Invalid --max-concurrent-bootstraps value: %d", maxConcurrentBootstraps)
+	}
+
+	if drainTimeoutSeconds < 0 {
+		return errors.Errorf("Invalid --drain-timeout-seconds value: %d", drainTimeoutSeconds)
+	}
+
+	// Replicas minting their own root certificate would not trust the proxies and webhook clients of each other
+	if enableSharding && caBundleSecretName == "" {
+		return errors.Errorf("Sharding requires the replicas to share the root certificate; please specify its secret using --%s", caBundleSecretNameCLIParam)
+	}
+
+	// GatewayClasses are cluster-scoped, and cannot be watched by a controller restricted to a list of namespaces
+	if len(watchedNamespaces) > 0 && optionalFeatures.GatewayAPI {
+		return errors.Errorf("The experimental Gateway API feature is not supported with --watched-namespaces")
+	}
+
+	if enableProfiling && !enableDebugServer {
+		return errors.Errorf("Profiling is served by the debug server; please enable it using --enable-debug-server")
+	}
 	return nil
 }
@@ -39,6 +39,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Backpressure{},
 		&BackpressureList{},
+		&TLSOrigination{},
+		&TLSOriginationList{},
+		&SidecarScope{},
+		&SidecarScopeList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
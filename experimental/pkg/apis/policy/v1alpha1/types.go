@@ -32,3 +32,71 @@ type BackpressureList struct {
 	// Items is the list of Backpressure
 	Items []Backpressure `json:"items"`
 }
+
+// TLSOrigination is the type used to represent an egress TLS origination policy.
+// Plaintext HTTP requests from the sidecars in the policy's namespace to the given hosts are upgraded to TLS.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TLSOrigination struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TLSOriginationSpec `json:"spec"`
+}
+
+// TLSOriginationSpec is the type used to represent the TLS origination policy specification.
+type TLSOriginationSpec struct {
+	// Hosts is the list of external hosts, requests to which are upgraded to TLS.
+	Hosts []string `json:"hosts"`
+
+	// Port is the port of the external hosts TLS connections are originated to, defaults to 443.
+	Port uint32 `json:"port,omitempty"`
+
+	// SNI is the server name sent in the TLS handshake, defaults to the host of the request.
+	SNI string `json:"sni,omitempty"`
+
+	// CABundle is the PEM encoded CA bundle used to validate the certificates of the external hosts,
+	// defaults to the CA bundle of the sidecar's image.
+	CABundle string `json:"caBundle,omitempty"`
+}
+
+// TLSOriginationList is the type used to represent a list of TLS origination policies.
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TLSOriginationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items is the list of TLSOrigination
+	Items []TLSOrigination `json:"items"`
+}
+
+// SidecarScope is the type used to represent the scope of the sidecars of services in permissive traffic policy mode.
+// The sidecars of the selected services are only configured for the listed egress services, instead of every service in the mesh.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type SidecarScope struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SidecarScopeSpec `json:"spec"`
+}
+
+// SidecarScopeSpec is the type used to represent the sidecar scope specification.
+type SidecarScopeSpec struct {
+	// Services is the list of services in the scope's namespace the scope applies to, defaults to all the services in the namespace.
+	Services []string `json:"services,omitempty"`
+
+	// Egress is the list of services the sidecars of the selected services can reach, in the form <namespace>/<name>.
+	// The namespace "." refers to the scope's namespace, and "*" matches any namespace or name.
+	Egress []string `json:"egress"`
+}
+
+// SidecarScopeList is the type used to represent a list of sidecar scopes.
+//+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type SidecarScopeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items is the list of SidecarScope
+	Items []SidecarScope `json:"items"`
+}
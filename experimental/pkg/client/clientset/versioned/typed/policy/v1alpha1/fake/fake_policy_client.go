@@ -32,6 +32,14 @@ func (c *FakePolicyV1alpha1) Backpressures(namespace string) v1alpha1.Backpressu
 	return &FakeBackpressures{c, namespace}
 }
 
+func (c *FakePolicyV1alpha1) SidecarScopes(namespace string) v1alpha1.SidecarScopeInterface {
+	return &FakeSidecarScopes{c, namespace}
+}
+
+func (c *FakePolicyV1alpha1) TLSOriginations(namespace string) v1alpha1.TLSOriginationInterface {
+	return &FakeTLSOriginations{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakePolicyV1alpha1) RESTClient() rest.Interface {
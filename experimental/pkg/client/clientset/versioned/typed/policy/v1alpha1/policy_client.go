@@ -27,6 +27,8 @@ import (
 type PolicyV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	BackpressuresGetter
+	SidecarScopesGetter
+	TLSOriginationsGetter
 }
 
 // PolicyV1alpha1Client is used to interact with features provided by the policy.openservicemesh.io group.
@@ -38,6 +40,14 @@ func (c *PolicyV1alpha1Client) Backpressures(namespace string) BackpressureInter
 	return newBackpressures(c, namespace)
 }
 
+func (c *PolicyV1alpha1Client) SidecarScopes(namespace string) SidecarScopeInterface {
+	return newSidecarScopes(c, namespace)
+}
+
+func (c *PolicyV1alpha1Client) TLSOriginations(namespace string) TLSOriginationInterface {
+	return newTLSOriginations(c, namespace)
+}
+
 // NewForConfig creates a new PolicyV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*PolicyV1alpha1Client, error) {
 	config := *c
@@ -55,6 +55,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=policy.openservicemesh.io, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithResource("backpressures"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().Backpressures().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("sidecarscopes"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().SidecarScopes().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("tlsoriginations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().TLSOriginations().Informer()}, nil
 
 	}
 
@@ -26,6 +26,10 @@ import (
 type Interface interface {
 	// Backpressures returns a BackpressureInformer.
 	Backpressures() BackpressureInformer
+	// SidecarScopes returns a SidecarScopeInformer.
+	SidecarScopes() SidecarScopeInformer
+	// TLSOriginations returns a TLSOriginationInformer.
+	TLSOriginations() TLSOriginationInformer
 }
 
 type version struct {
@@ -43,3 +47,13 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) Backpressures() BackpressureInformer {
 	return &backpressureInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// SidecarScopes returns a SidecarScopeInformer.
+func (v *version) SidecarScopes() SidecarScopeInformer {
+	return &sidecarScopeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// TLSOriginations returns a TLSOriginationInformer.
+func (v *version) TLSOriginations() TLSOriginationInformer {
+	return &tLSOriginationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -25,3 +25,19 @@ type BackpressureListerExpansion interface{}
 // BackpressureNamespaceListerExpansion allows custom methods to be added to
 // BackpressureNamespaceLister.
 type BackpressureNamespaceListerExpansion interface{}
+
+// SidecarScopeListerExpansion allows custom methods to be added to
+// SidecarScopeLister.
+type SidecarScopeListerExpansion interface{}
+
+// SidecarScopeNamespaceListerExpansion allows custom methods to be added to
+// SidecarScopeNamespaceLister.
+type SidecarScopeNamespaceListerExpansion interface{}
+
+// TLSOriginationListerExpansion allows custom methods to be added to
+// TLSOriginationLister.
+type TLSOriginationListerExpansion interface{}
+
+// TLSOriginationNamespaceListerExpansion allows custom methods to be added to
+// TLSOriginationNamespaceLister.
+type TLSOriginationNamespaceListerExpansion interface{}
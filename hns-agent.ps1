@@ -0,0 +1,130 @@
+# Redirects the traffic of the injected pods of the node to their Proxy. Runs in a host process container of the node,
+# as the HNS endpoints of the pods can only be modified on the host.
+# The init container of an injected pod waits for its openservicemesh.io/hns-redirected annotation, set once its
+# traffic is redirected, so that the application does not start before its traffic is secured by the Proxy.
+# The proxy policy of a pod is computed from its spec: the annotations of a pod can be set by the pod itself, so they
+# are never trusted to skip or configure the redirection.
+
+$ErrorActionPreference = "Stop"
+
+$NodeName = $env:NODE_NAME
+$SyncIntervalSeconds = if ($env:SYNC_INTERVAL_SECONDS) { [int]$env:SYNC_INTERVAL_SECONDS } else { 2 }
+$ProxyIDLabel = "osm-envoy-uid"
+$RedirectedAnnotation = "openservicemesh.io/hns-redirected"
+
+# The traffic of the Proxy, which runs as ContainerUser, is not redirected
+$ProxyUserSID = "S-1-5-93-2-2"
+$ProxyInboundPort = 15003
+$ProxyOutboundPort = 15001
+
+if (-not $NodeName) {
+    throw "NODE_NAME must be set to find the pods of the node"
+}
+
+# The files of the container image and the service account token are mounted in the sandbox of the host process container
+$SandboxPath = $env:CONTAINER_SANDBOX_MOUNT_POINT
+$ServiceAccountPath = Join-Path $SandboxPath "var/run/secrets/kubernetes.io/serviceaccount"
+$APIServer = "https://$($env:KUBERNETES_SERVICE_HOST):$($env:KUBERNETES_SERVICE_PORT)"
+
+# The API server must present a certificate issued by the CA of the cluster
+$CACert = New-Object System.Security.Cryptography.X509Certificates.X509Certificate2 (Join-Path $ServiceAccountPath "ca.crt")
+[System.Net.ServicePointManager]::SecurityProtocol = [System.Net.SecurityProtocolType]::Tls12
+[System.Net.ServicePointManager]::ServerCertificateValidationCallback = {
+    param($sender, $certificate, $chain, $sslPolicyErrors)
+    if ($sslPolicyErrors -band [System.Net.Security.SslPolicyErrors]::RemoteCertificateNameMismatch) {
+        return $false
+    }
+    $clusterChain = New-Object System.Security.Cryptography.X509Certificates.X509Chain
+    $clusterChain.ChainPolicy.RevocationMode = [System.Security.Cryptography.X509Certificates.X509RevocationMode]::NoCheck
+    $clusterChain.ChainPolicy.VerificationFlags = [System.Security.Cryptography.X509Certificates.X509VerificationFlags]::AllowUnknownCertificateAuthority
+    $clusterChain.ChainPolicy.ExtraStore.Add($CACert) | Out-Null
+    if (-not $clusterChain.Build($certificate)) {
+        return $false
+    }
+    $root = $clusterChain.ChainElements[$clusterChain.ChainElements.Count - 1].Certificate
+    return $root.Thumbprint -eq $CACert.Thumbprint
+}
+
+function Get-APIHeaders {
+    # The token is rotated by the kubelet
+    $token = Get-Content -Raw (Join-Path $ServiceAccountPath "token")
+    return @{ Authorization = "Bearer $($token.Trim())" }
+}
+
+# Returns the ports the HTTP and TCP probes of the containers of the pod are sent to, resolving named ports.
+# The health checks of the kubelet are not sent over mTLS, so they are not redirected to the Proxy.
+function Get-ProbePorts($pod) {
+    $ports = @()
+    foreach ($container in $pod.spec.containers) {
+        foreach ($probe in @($container.livenessProbe, $container.readinessProbe, $container.startupProbe)) {
+            if (-not $probe) {
+                continue
+            }
+            $port = if ($probe.httpGet) { $probe.httpGet.port } elseif ($probe.tcpSocket) { $probe.tcpSocket.port } else { $null }
+            if ($port -is [string]) {
+                $port = ($container.ports | Where-Object { $_.name -eq $port } | Select-Object -First 1).containerPort
+            }
+            if ($port) {
+                $ports += "$port"
+            }
+        }
+    }
+    return @($ports | Sort-Object { [int]$_ } -Unique)
+}
+
+# Returns the proxy policy of the pod, passed to init-hns.ps1
+function Get-ProxyPolicy($pod) {
+    return @{
+        userSID = $ProxyUserSID
+        inboundProxyPort = $ProxyInboundPort
+        outboundProxyPort = $ProxyOutboundPort
+        inboundExceptionPorts = @(Get-ProbePorts $pod)
+    } | ConvertTo-Json -Depth 5 -Compress
+}
+
+# The UIDs of the pods whose proxy policy was added or found on their endpoint by this agent.
+# After a restart of the agent, the endpoints of all the pods are checked again.
+$RedirectedPods = @{}
+
+Write-Output "Redirecting the traffic of the injected pods of node $NodeName"
+while ($true) {
+    try {
+        $query = "fieldSelector=spec.nodeName%3D$NodeName&labelSelector=$ProxyIDLabel"
+        $pods = Invoke-RestMethod -Uri "$APIServer/api/v1/pods?$query" -Headers (Get-APIHeaders)
+
+        # Forget the pods deleted from the node
+        $podUIDs = @($pods.items | ForEach-Object { $_.metadata.uid })
+        foreach ($uid in @($RedirectedPods.Keys)) {
+            if ($podUIDs -notcontains $uid) {
+                $RedirectedPods.Remove($uid)
+            }
+        }
+
+        foreach ($pod in $pods.items) {
+            if (-not $pod.status.podIP -or $RedirectedPods.ContainsKey($pod.metadata.uid)) {
+                continue
+            }
+
+            # Each pod is redirected in its own process, so that the failure of one pod does not stop the agent
+            $env:POD_IP = $pod.status.podIP
+            $env:PROXY_POLICY = Get-ProxyPolicy $pod
+            & powershell -NoProfile -ExecutionPolicy Bypass -File (Join-Path $SandboxPath "init-hns.ps1")
+            if ($LASTEXITCODE -ne 0) {
+                Write-Output "Error redirecting the traffic of pod $($pod.metadata.namespace)/$($pod.metadata.name)"
+                continue
+            }
+
+            $annotations = $pod.metadata.annotations
+            if (-not $annotations -or $annotations.$RedirectedAnnotation -ne "true") {
+                $patch = @{ metadata = @{ annotations = @{ $RedirectedAnnotation = "true" } } } | ConvertTo-Json -Depth 5
+                Invoke-RestMethod -Method Patch -Uri "$APIServer/api/v1/namespaces/$($pod.metadata.namespace)/pods/$($pod.metadata.name)" `
+                    -Headers (Get-APIHeaders) -ContentType "application/merge-patch+json" -Body $patch | Out-Null
+            }
+            $RedirectedPods[$pod.metadata.uid] = $true
+            Write-Output "Redirected the traffic of pod $($pod.metadata.namespace)/$($pod.metadata.name)"
+        }
+    } catch {
+        Write-Output "Error syncing the pods of node $NodeName`: $_"
+    }
+    Start-Sleep -Seconds $SyncIntervalSeconds
+}
@@ -0,0 +1,75 @@
+# Exchanges the projected service account token of the pod for the bootstrap config of the Proxy and for the
+# short-lived certificate the Proxy connects to XDS with.
+# With the -Rotate switch, the certificate is instead refreshed for the lifetime of the pod, before it expires.
+# The Proxy reloads the certificate when its file is replaced.
+
+param(
+    [switch]$Rotate
+)
+
+$ErrorActionPreference = "Stop"
+
+$BootstrapTokenFile = if ($env:BOOTSTRAP_TOKEN_FILE) { $env:BOOTSTRAP_TOKEN_FILE } else { "/var/run/secrets/openservicemesh.io/bootstrap/token" }
+$BootstrapConfigFile = if ($env:BOOTSTRAP_CONFIG_FILE) { $env:BOOTSTRAP_CONFIG_FILE } else { "/etc/envoy/bootstrap.yaml" }
+$XDSCertificateFile = if ($env:XDS_CERTIFICATE_FILE) { $env:XDS_CERTIFICATE_FILE } else { "/etc/envoy/xds_certificate.yaml" }
+
+# Time after which a failed certificate refresh is retried
+$RetrySeconds = 10
+
+if (-not $env:BOOTSTRAP_URL -or -not $env:BOOTSTRAP_CERTIFICATE_URL -or -not $env:BOOTSTRAP_CA_BUNDLE) {
+    throw "BOOTSTRAP_URL, BOOTSTRAP_CERTIFICATE_URL and BOOTSTRAP_CA_BUNDLE must be set"
+}
+
+# The webhook server must present a certificate issued by the root certificate of the mesh
+$CACert = New-Object System.Security.Cryptography.X509Certificates.X509Certificate2 (, [System.Text.Encoding]::ASCII.GetBytes($env:BOOTSTRAP_CA_BUNDLE))
+[System.Net.ServicePointManager]::SecurityProtocol = [System.Net.SecurityProtocolType]::Tls12
+[System.Net.ServicePointManager]::ServerCertificateValidationCallback = {
+    param($sender, $certificate, $chain, $sslPolicyErrors)
+    if ($sslPolicyErrors -band [System.Net.Security.SslPolicyErrors]::RemoteCertificateNameMismatch) {
+        return $false
+    }
+    $meshChain = New-Object System.Security.Cryptography.X509Certificates.X509Chain
+    $meshChain.ChainPolicy.RevocationMode = [System.Security.Cryptography.X509Certificates.X509RevocationMode]::NoCheck
+    $meshChain.ChainPolicy.VerificationFlags = [System.Security.Cryptography.X509Certificates.X509VerificationFlags]::AllowUnknownCertificateAuthority
+    $meshChain.ChainPolicy.ExtraStore.Add($CACert) | Out-Null
+    if (-not $meshChain.Build($certificate)) {
+        return $false
+    }
+    $root = $meshChain.ChainElements[$meshChain.ChainElements.Count - 1].Certificate
+    return $root.Thumbprint -eq $CACert.Thumbprint
+}
+
+# Exchanges the token of the pod, which is rotated by the kubelet, with osm-controller
+function Invoke-BootstrapRequest($Uri, $OutFile) {
+    $token = (Get-Content -Raw $BootstrapTokenFile).Trim()
+    return Invoke-WebRequest -UseBasicParsing -Method Post -Uri $Uri -Headers @{ Authorization = "Bearer $token" } -OutFile $OutFile -PassThru
+}
+
+# Replaces the certificate file with a single rename, which the Proxy watches for, and returns the number of seconds
+# after which the certificate must be refreshed
+function Update-XDSCertificate {
+    $response = Invoke-BootstrapRequest $env:BOOTSTRAP_CERTIFICATE_URL "$XDSCertificateFile.tmp"
+    Move-Item -Force "$XDSCertificateFile.tmp" $XDSCertificateFile
+    if ($response.Headers["Cache-Control"] -match "max-age=(\d+)") {
+        return [int]$Matches[1]
+    }
+    return $RetrySeconds
+}
+
+if ($Rotate) {
+    while ($true) {
+        try {
+            $seconds = Update-XDSCertificate
+            Write-Output "Refreshed the certificate of the Proxy, next refresh in $seconds seconds"
+        } catch {
+            Write-Error "Error refreshing the certificate of the Proxy, retrying in $RetrySeconds seconds: $_" -ErrorAction Continue
+            $seconds = $RetrySeconds
+        }
+        Start-Sleep -Seconds $seconds
+    }
+}
+
+Invoke-BootstrapRequest $env:BOOTSTRAP_URL $BootstrapConfigFile | Out-Null
+Update-XDSCertificate | Out-Null
+
+Write-Output "Wrote the bootstrap config of the Proxy to $BootstrapConfigFile and its certificate to $XDSCertificateFile"
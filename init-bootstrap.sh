@@ -0,0 +1,65 @@
+#!/bin/sh
+
+# Exchanges the projected service account token of the pod for the bootstrap config of the Proxy and for the
+# short-lived certificate the Proxy connects to XDS with. Both are written to a memory-backed volume.
+# With the "rotate" argument, the certificate is instead refreshed for the lifetime of the pod, before it expires.
+# The Proxy reloads the certificate when its file is replaced.
+
+set -eu
+
+BOOTSTRAP_TOKEN_FILE=${BOOTSTRAP_TOKEN_FILE:-/var/run/secrets/openservicemesh.io/bootstrap/token}
+BOOTSTRAP_CONFIG_FILE=${BOOTSTRAP_CONFIG_FILE:-/etc/envoy/bootstrap.yaml}
+XDS_CERTIFICATE_FILE=${XDS_CERTIFICATE_FILE:-/etc/envoy/xds_certificate.yaml}
+CA_BUNDLE_FILE="$(dirname "$BOOTSTRAP_CONFIG_FILE")/ca-bundle.pem"
+HEADERS_FILE="$XDS_CERTIFICATE_FILE.headers"
+
+# RETRY_SECONDS is the time after which a failed certificate refresh is retried
+RETRY_SECONDS=10
+
+if [ -z "${BOOTSTRAP_URL:-}" ] || [ -z "${BOOTSTRAP_CERTIFICATE_URL:-}" ] || [ -z "${BOOTSTRAP_CA_BUNDLE:-}" ]; then
+  echo "BOOTSTRAP_URL, BOOTSTRAP_CERTIFICATE_URL and BOOTSTRAP_CA_BUNDLE must be set" >&2
+  exit 1
+fi
+
+printf '%s' "$BOOTSTRAP_CA_BUNDLE" > "$CA_BUNDLE_FILE"
+trap 'rm -f "$CA_BUNDLE_FILE" "$HEADERS_FILE" "$XDS_CERTIFICATE_FILE.tmp"' EXIT
+
+# request <url> <output file> exchanges the token of the pod, which is rotated by the kubelet, with osm-controller
+request() {
+  curl --fail --silent --show-error \
+    --retry 5 --retry-connrefused \
+    --cacert "$CA_BUNDLE_FILE" \
+    --header "Authorization: Bearer $(cat "$BOOTSTRAP_TOKEN_FILE")" \
+    --request POST \
+    --dump-header "$HEADERS_FILE" \
+    --output "$2" \
+    "$1"
+}
+
+# fetch_certificate replaces the certificate file with a single rename, which the Proxy watches for
+fetch_certificate() {
+  request "$BOOTSTRAP_CERTIFICATE_URL" "$XDS_CERTIFICATE_FILE.tmp" && mv -f "$XDS_CERTIFICATE_FILE.tmp" "$XDS_CERTIFICATE_FILE"
+}
+
+# refresh_seconds prints the number of seconds after which the last fetched certificate must be refreshed
+refresh_seconds() {
+  sed -n 's/^[Cc]ache-[Cc]ontrol:.*max-age=\([0-9][0-9]*\).*/\1/p' "$HEADERS_FILE"
+}
+
+if [ "${1:-}" = "rotate" ]; then
+  while true; do
+    seconds=""
+    if fetch_certificate; then
+      seconds=$(refresh_seconds)
+      echo "Refreshed the certificate of the Proxy, next refresh in ${seconds:-$RETRY_SECONDS} seconds"
+    else
+      echo "Error refreshing the certificate of the Proxy, retrying in $RETRY_SECONDS seconds" >&2
+    fi
+    sleep "${seconds:-$RETRY_SECONDS}"
+  done
+fi
+
+request "$BOOTSTRAP_URL" "$BOOTSTRAP_CONFIG_FILE"
+fetch_certificate
+
+echo "Wrote the bootstrap config of the Proxy to $BOOTSTRAP_CONFIG_FILE and its certificate to $XDS_CERTIFICATE_FILE"
@@ -0,0 +1,136 @@
+# Redirects the traffic of a Windows pod to the Proxy by adding a proxy policy to the HNS endpoint of the pod.
+# Windows has no iptables: the Host Networking Service (HNS) redirects the TCP connections of the endpoint instead.
+# The HNS endpoints can only be modified on the host, so this script is run by hns-agent.ps1 in a host process container.
+
+param(
+    # The HNS endpoint of the pod is looked up by its IP
+    [string]$PodIP = $env:POD_IP,
+
+    # The proxy policy of the pod, computed by hns-agent.ps1 from the spec of the pod
+    [string]$ProxyPolicy = $env:PROXY_POLICY
+)
+
+$ErrorActionPreference = "Stop"
+
+if (-not $PodIP -or -not $ProxyPolicy) {
+    throw "The IP and the proxy policy of the pod must be set"
+}
+
+$PodPolicy = $ProxyPolicy | ConvertFrom-Json
+$ProxyUserSID = $PodPolicy.userSID
+$ProxyPort = "$($PodPolicy.outboundProxyPort)"
+$ProxyInboundPort = "$($PodPolicy.inboundProxyPort)"
+$ProxyAdminPort = if ($env:PROXY_ADMIN_PORT) { $env:PROXY_ADMIN_PORT } else { "15000" }
+$ProxyStatsPort = if ($env:PROXY_STATS_PORT) { $env:PROXY_STATS_PORT } else { "15010" }
+$ExcludeInboundPorts = if ($PodPolicy.inboundExceptionPorts) { @($PodPolicy.inboundExceptionPorts) } else { @() }
+
+# The Host Compute Network API of computenetwork.dll manages the HNS endpoints
+Add-Type -TypeDefinition @"
+using System;
+using System.Runtime.InteropServices;
+
+public static class Hcn {
+    [DllImport("computenetwork.dll", CharSet = CharSet.Unicode)]
+    public static extern int HcnEnumerateEndpoints(string query, out string endpoints, out string errorRecord);
+
+    [DllImport("computenetwork.dll", CharSet = CharSet.Unicode)]
+    public static extern int HcnOpenEndpoint(ref Guid id, out IntPtr endpoint, out string errorRecord);
+
+    [DllImport("computenetwork.dll", CharSet = CharSet.Unicode)]
+    public static extern int HcnModifyEndpoint(IntPtr endpoint, string settings, out string errorRecord);
+
+    [DllImport("computenetwork.dll", CharSet = CharSet.Unicode)]
+    public static extern int HcnQueryEndpointProperties(IntPtr endpoint, string query, out string properties, out string errorRecord);
+
+    [DllImport("computenetwork.dll")]
+    public static extern int HcnCloseEndpoint(IntPtr endpoint);
+}
+"@
+
+function Assert-HcnResult($result, $errorRecord, $operation) {
+    if ($result -ne 0) {
+        throw "$operation failed with HRESULT $result`: $errorRecord"
+    }
+}
+
+# Returns whether the given endpoint properties hold a proxy policy
+function Test-ProxyPolicy($endpointProperties) {
+    return [bool]($endpointProperties.Policies | Where-Object { $_.Type -eq "L4WFPPROXY" })
+}
+
+# Find the endpoint of the pod by its IP
+$endpoints = $null
+$errorRecord = $null
+$result = [Hcn]::HcnEnumerateEndpoints('{"SchemaVersion": {"Major": 2, "Minor": 0}}', [ref]$endpoints, [ref]$errorRecord)
+Assert-HcnResult $result $errorRecord "Listing HNS endpoints"
+
+$podEndpoint = $null
+foreach ($endpointID in ($endpoints | ConvertFrom-Json)) {
+    $id = [Guid]$endpointID
+    $handle = [IntPtr]::Zero
+    $result = [Hcn]::HcnOpenEndpoint([ref]$id, [ref]$handle, [ref]$errorRecord)
+    Assert-HcnResult $result $errorRecord "Opening HNS endpoint $endpointID"
+
+    $properties = $null
+    $result = [Hcn]::HcnQueryEndpointProperties($handle, "", [ref]$properties, [ref]$errorRecord)
+    Assert-HcnResult $result $errorRecord "Getting the properties of HNS endpoint $endpointID"
+
+    $endpointProperties = $properties | ConvertFrom-Json
+    if ($endpointProperties.IpConfigurations.IpAddress -contains $PodIP) {
+        $podEndpoint = $handle
+        break
+    }
+    [Hcn]::HcnCloseEndpoint($handle) | Out-Null
+}
+
+if (-not $podEndpoint) {
+    throw "No HNS endpoint found with IP $PodIP"
+}
+
+# The policy may have been added before the agent was restarted
+if (Test-ProxyPolicy $endpointProperties) {
+    [Hcn]::HcnCloseEndpoint($podEndpoint) | Out-Null
+    Write-Output "The traffic of the pod with IP $PodIP is already redirected to the Proxy"
+    return
+}
+
+# Redirect the inbound connections to PROXY_INBOUND_PORT, except for the health checks of the kubelet and the Proxy's ports,
+# and the outbound connections to PROXY_PORT, except for the Proxy's own connections identified by its user
+$policy = @{
+    ResourceType = "Policy"
+    RequestType = "Add"
+    Settings = @{
+        Policies = @(
+            @{
+                Type = "L4WFPPROXY"
+                Settings = @{
+                    InboundProxyPort = $ProxyInboundPort
+                    OutboundProxyPort = $ProxyPort
+                    UserSID = $ProxyUserSID
+                    FilterTuple = @{
+                        Protocols = "6"
+                    }
+                    InboundExceptions = @{
+                        PortExceptions = @($ProxyAdminPort, $ProxyStatsPort) + $ExcludeInboundPorts
+                    }
+                    OutboundExceptions = @{
+                        IpAddressExceptions = @("127.0.0.1")
+                    }
+                }
+            }
+        )
+    }
+}
+
+$result = [Hcn]::HcnModifyEndpoint($podEndpoint, ($policy | ConvertTo-Json -Depth 10), [ref]$errorRecord)
+Assert-HcnResult $result $errorRecord "Adding the proxy policy to the HNS endpoint of the pod"
+
+# The pod is only reported as redirected once the policy is found on its endpoint
+$result = [Hcn]::HcnQueryEndpointProperties($podEndpoint, "", [ref]$properties, [ref]$errorRecord)
+[Hcn]::HcnCloseEndpoint($podEndpoint) | Out-Null
+Assert-HcnResult $result $errorRecord "Getting the properties of the HNS endpoint of the pod"
+if (-not (Test-ProxyPolicy ($properties | ConvertFrom-Json))) {
+    throw "The proxy policy was not added to the HNS endpoint of the pod with IP $PodIP"
+}
+
+Write-Output "Redirected the traffic of the pod with IP $PodIP to the Proxy"
@@ -8,44 +8,78 @@ PROXY_PORT=${PROXY_PORT:-15001}
 PROXY_INBOUND_PORT=${PROXY_INBOUND_PORT:-15003}
 PROXY_UID=${PROXY_UID:-1337}
 SSH_PORT=${SSH_PORT:-22}
+ENABLE_DNS_PROXY=${ENABLE_DNS_PROXY:-false}
+PROXY_DNS_PORT=${PROXY_DNS_PORT:-15053}
+IP_FAMILY=${IP_FAMILY:-ipv4}
 
-# Create a new chain for redirecting outbound traffic to PROXY_PORT
-iptables -t nat -N PROXY_REDIRECT
-iptables -t nat -A PROXY_REDIRECT -p tcp -j REDIRECT --to-port "${PROXY_PORT}"
+# redirect_traffic programs the rules redirecting the traffic of the pod to the Proxy
+# with the given iptables command, skipping the traffic to the given localhost address
+redirect_traffic() {
+  IPTABLES="$1"
+  LOCALHOST="$2"
 
-# Traffic to the Proxy Admin port flows to the Proxy -- not redirected
-iptables -t nat -A PROXY_REDIRECT -p tcp --dport "${PROXY_ADMIN_PORT}" -j ACCEPT
+  # Create a new chain for redirecting outbound traffic to PROXY_PORT
+  "${IPTABLES}" -t nat -N PROXY_REDIRECT
+  "${IPTABLES}" -t nat -A PROXY_REDIRECT -p tcp -j REDIRECT --to-port "${PROXY_PORT}"
 
+  # Traffic to the Proxy Admin port flows to the Proxy -- not redirected
+  "${IPTABLES}" -t nat -A PROXY_REDIRECT -p tcp --dport "${PROXY_ADMIN_PORT}" -j ACCEPT
 
-# Create a new chain for redirecting inbound traffic to PROXY_INBOUND_PORT
-iptables -t nat -N PROXY_IN_REDIRECT
-iptables -t nat -A PROXY_IN_REDIRECT -p tcp -j REDIRECT --to-port "${PROXY_INBOUND_PORT}"
 
-# Create a new chain to redirect inbound traffic to Envoy
-iptables -t nat -N PROXY_INBOUND
-iptables -t nat -A PREROUTING -p tcp -j PROXY_INBOUND
+  # Create a new chain for redirecting inbound traffic to PROXY_INBOUND_PORT
+  "${IPTABLES}" -t nat -N PROXY_IN_REDIRECT
+  "${IPTABLES}" -t nat -A PROXY_IN_REDIRECT -p tcp -j REDIRECT --to-port "${PROXY_INBOUND_PORT}"
 
-# Skip inbound SSH redirection
-iptables -t nat -A PROXY_INBOUND -p tcp --dport "${SSH_PORT}" -j RETURN
-# Skip inbound stats query redirection
-iptables -t nat -A PROXY_INBOUND -p tcp --dport "${PROXY_STATS_PORT}" -j RETURN
-# Redirect remaining inbound traffic to PROXY_INBOUND_PORT
-iptables -t nat -A PROXY_INBOUND -p tcp -j PROXY_IN_REDIRECT
+  # Create a new chain to redirect inbound traffic to Envoy
+  "${IPTABLES}" -t nat -N PROXY_INBOUND
+  "${IPTABLES}" -t nat -A PREROUTING -p tcp -j PROXY_INBOUND
 
+  # Skip inbound SSH redirection
+  "${IPTABLES}" -t nat -A PROXY_INBOUND -p tcp --dport "${SSH_PORT}" -j RETURN
+  # Skip inbound stats query redirection
+  "${IPTABLES}" -t nat -A PROXY_INBOUND -p tcp --dport "${PROXY_STATS_PORT}" -j RETURN
+  # Redirect remaining inbound traffic to PROXY_INBOUND_PORT
+  "${IPTABLES}" -t nat -A PROXY_INBOUND -p tcp -j PROXY_IN_REDIRECT
 
-# Create a new chain to redirect outbound traffic to Envoy
-iptables -t nat -N PROXY_OUTPUT
 
-# For all TCP traffic, jump to PROXY_OUTPUT chain from OUTPUT chain
-iptables -t nat -A OUTPUT -p tcp -j PROXY_OUTPUT
+  # Create a new chain to redirect outbound traffic to Envoy
+  "${IPTABLES}" -t nat -N PROXY_OUTPUT
 
-# TODO(shashank): Redirect app back calls to itself using PROXY_UID
+  # For all TCP traffic, jump to PROXY_OUTPUT chain from OUTPUT chain
+  "${IPTABLES}" -t nat -A OUTPUT -p tcp -j PROXY_OUTPUT
 
-# Don't redirect Envoy traffic back to itself for non-loopback traffic
-iptables -t nat -A PROXY_OUTPUT -m owner --uid-owner "${PROXY_UID}" -j RETURN
+  # TODO(shashank): Redirect app back calls to itself using PROXY_UID
 
-# Skip localhost traffic
-iptables -t nat -A PROXY_OUTPUT -d 127.0.0.1/32 -j RETURN
+  # Don't redirect Envoy traffic back to itself for non-loopback traffic
+  "${IPTABLES}" -t nat -A PROXY_OUTPUT -m owner --uid-owner "${PROXY_UID}" -j RETURN
 
-# Redirect remaining outbound traffic to Envoy
-iptables -t nat -A PROXY_OUTPUT -j PROXY_REDIRECT
+  # Skip localhost traffic
+  "${IPTABLES}" -t nat -A PROXY_OUTPUT -d "${LOCALHOST}" -j RETURN
+
+  # Redirect remaining outbound traffic to Envoy
+  "${IPTABLES}" -t nat -A PROXY_OUTPUT -j PROXY_REDIRECT
+
+
+  # Redirect DNS queries over UDP to the Proxy's DNS listener, except for the Proxy's own queries
+  if [ "${ENABLE_DNS_PROXY}" = "true" ]; then
+    "${IPTABLES}" -t nat -A OUTPUT -p udp --dport 53 -m owner ! --uid-owner "${PROXY_UID}" -j REDIRECT --to-port "${PROXY_DNS_PORT}"
+  fi
+}
+
+# IPv4 rules are programmed with iptables, IPv6 rules with ip6tables, and dual-stack pods get both
+case "${IP_FAMILY}" in
+  ipv4)
+    redirect_traffic iptables 127.0.0.1/32
+    ;;
+  ipv6)
+    redirect_traffic ip6tables ::1/128
+    ;;
+  dual-stack)
+    redirect_traffic iptables 127.0.0.1/32
+    redirect_traffic ip6tables ::1/128
+    ;;
+  *)
+    echo "Invalid IP_FAMILY ${IP_FAMILY}, must be one of ipv4, ipv6 or dual-stack" >&2
+    exit 1
+    ;;
+esac
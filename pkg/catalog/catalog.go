@@ -4,24 +4,26 @@ import (
 	"time"
 
 	set "github.com/deckarep/golang-set"
-	"k8s.io/client-go/kubernetes"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/gateway"
 	"github.com/openservicemesh/osm/pkg/ingress"
+	"github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/namespace"
 	"github.com/openservicemesh/osm/pkg/smi"
 )
 
 // NewMeshCatalog creates a new service catalog
-func NewMeshCatalog(namespaceController namespace.Controller, kubeClient kubernetes.Interface, meshSpec smi.MeshSpec, certManager certificate.Manager, ingressMonitor ingress.Monitor, stop <-chan struct{}, cfg configurator.Configurator, endpointsProviders ...endpoint.Provider) *MeshCatalog {
+func NewMeshCatalog(namespaceController namespace.Controller, kubeController kubernetes.Controller, meshSpec smi.MeshSpec, certManager certificate.Manager, ingressMonitor ingress.Monitor, gatewayMonitor gateway.Monitor, stop <-chan struct{}, cfg configurator.Configurator, endpointsProviders ...endpoint.Provider) *MeshCatalog {
 	log.Info().Msg("Create a new Service MeshCatalog.")
 	sc := MeshCatalog{
 		endpointsProviders: endpointsProviders,
 		meshSpec:           meshSpec,
 		certManager:        certManager,
 		ingressMonitor:     ingressMonitor,
+		gatewayMonitor:     gatewayMonitor,
 		configurator:       cfg,
 
 		expectedProxies:      make(map[certificate.CommonName]expectedProxy),
@@ -30,9 +32,9 @@ func NewMeshCatalog(namespaceController namespace.Controller, kubeClient kuberne
 		announcementChannels: set.NewSet(),
 
 		// Kubernetes needed to determine what Services a pod that connects to XDS belongs to.
-		// In multicluster scenarios this would be a map of cluster ID to Kubernetes client.
-		// The certificate itself would contain the cluster ID making it easy to lookup the client in this map.
-		kubeClient: kubeClient,
+		// In multicluster scenarios this would be a map of cluster ID to Kubernetes controller.
+		// The certificate itself would contain the cluster ID making it easy to lookup the controller in this map.
+		kubeController: kubeController,
 
 		namespaceController: namespaceController,
 	}
@@ -57,6 +59,7 @@ func (mc *MeshCatalog) getAnnouncementChannels() []announcementChannel {
 		{"MeshSpec", mc.meshSpec.GetAnnouncementsChannel()},
 		{"CertManager", mc.certManager.GetAnnouncementsChannel()},
 		{"IngressMonitor", mc.ingressMonitor.GetAnnouncementsChannel()},
+		{"GatewayMonitor", mc.gatewayMonitor.GetAnnouncementsChannel()},
 		{"Ticker", ticking},
 		{"Namespace", mc.namespaceController.GetAnnouncementsChannel()},
 	}
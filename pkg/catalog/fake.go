@@ -10,7 +10,9 @@ import (
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/endpoint/providers/kube"
+	"github.com/openservicemesh/osm/pkg/gateway"
 	"github.com/openservicemesh/osm/pkg/ingress"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/namespace"
 	"github.com/openservicemesh/osm/pkg/smi"
 )
@@ -21,6 +23,7 @@ func NewFakeMeshCatalog(kubeClient kubernetes.Interface) *MeshCatalog {
 	cache := make(map[certificate.CommonName]certificate.Certificater)
 	certManager := tresor.NewFakeCertManager(&cache, 1*time.Hour)
 	ingressMonitor := ingress.NewFakeIngressMonitor()
+	gatewayMonitor := gateway.NewFakeGatewayMonitor()
 	stop := make(<-chan struct{})
 	endpointProviders := []endpoint.Provider{
 		kube.NewFakeProvider(),
@@ -32,5 +35,5 @@ func NewFakeMeshCatalog(kubeClient kubernetes.Interface) *MeshCatalog {
 
 	namespaceController := namespace.NewFakeNamespaceController([]string{osmNamespace})
 
-	return NewMeshCatalog(namespaceController, kubeClient, meshSpec, certManager, ingressMonitor, stop, cfg, endpointProviders...)
+	return NewMeshCatalog(namespaceController, k8s.NewFakeController(kubeClient), meshSpec, certManager, ingressMonitor, gatewayMonitor, stop, cfg, endpointProviders...)
 }
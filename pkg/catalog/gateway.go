@@ -0,0 +1,90 @@
+package catalog
+
+import (
+	"regexp"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/gateway"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// addGatewayRoutesPerHost adds the routes per host defined in Gateway API HTTPRoutes forwarding to the given service
+func (mc *MeshCatalog) addGatewayRoutesPerHost(service service.MeshService, domainRoutesMap map[string][]trafficpolicy.Route) error {
+	httpRoutes, err := mc.gatewayMonitor.GetHTTPRoutes(service)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to get Gateway API HTTPRoutes with backend %s", service)
+		return err
+	}
+
+	for _, httpRoute := range httpRoutes {
+		domains := httpRoute.Spec.Hostnames
+		if len(domains) == 0 {
+			domains = []string{constants.WildcardHTTPMethod}
+		}
+
+		for _, rule := range httpRoute.Spec.Rules {
+			if !gateway.RuleForwardsToService(rule, service) {
+				continue
+			}
+			routes := getGatewayRuleRoutes(rule)
+			for _, domain := range domains {
+				domainRoutesMap[domain] = append(domainRoutesMap[domain], routes...)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getGatewayRuleRoutes translates the matches of an HTTPRoute rule into traffic policy routes
+func getGatewayRuleRoutes(rule gateway.HTTPRouteRule) []trafficpolicy.Route {
+	defaultRoute := trafficpolicy.Route{
+		PathRegex: constants.RegexMatchAll,
+		Methods:   []string{constants.RegexMatchAll},
+	}
+
+	if len(rule.Matches) == 0 {
+		return []trafficpolicy.Route{defaultRoute}
+	}
+
+	var routes []trafficpolicy.Route
+	for _, match := range rule.Matches {
+		route := defaultRoute
+		if match.Path != nil {
+			route.PathRegex = getPathRegex(*match.Path)
+		}
+		if match.Headers != nil && len(match.Headers.Values) > 0 {
+			route.Headers = getHeadersRegex(*match.Headers)
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// getPathRegex returns the path regex equivalent to the given Gateway API path match
+func getPathRegex(pathMatch gateway.HTTPPathMatch) string {
+	switch pathMatch.Type {
+	case gateway.PathMatchExact:
+		return regexp.QuoteMeta(pathMatch.Value)
+	case gateway.PathMatchRegularExpression:
+		return pathMatch.Value
+	default:
+		// Prefix is the default match type
+		return regexp.QuoteMeta(pathMatch.Value) + constants.RegexMatchAll
+	}
+}
+
+// getHeadersRegex returns the header regexes equivalent to the given Gateway API header match
+func getHeadersRegex(headerMatch gateway.HTTPHeaderMatch) map[string]string {
+	headers := make(map[string]string)
+	for name, value := range headerMatch.Values {
+		if headerMatch.Type == gateway.HeaderMatchRegularExpression {
+			headers[name] = value
+			continue
+		}
+		// Exact is the default match type
+		headers[name] = regexp.QuoteMeta(value)
+	}
+	return headers
+}
@@ -0,0 +1,88 @@
+package catalog
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/gateway"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var _ = Describe("Test Gateway API route policies", func() {
+	backend := fakeIngressService
+	other := "other-service"
+
+	Context("Testing addGatewayRoutesPerHost", func() {
+		It("Gets the route policies per domain from HTTPRoutes forwarding to a service", func() {
+			gatewayMonitor := gateway.NewFakeGatewayMonitor()
+			gatewayMonitor.FakeHTTPRoutes = []*gateway.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "route-1",
+						Namespace: fakeIngressNamespace,
+					},
+					Spec: gateway.HTTPRouteSpec{
+						Hostnames: []string{"fake1.com"},
+						Rules: []gateway.HTTPRouteRule{
+							{
+								Matches: []gateway.HTTPRouteMatch{
+									{Path: &gateway.HTTPPathMatch{Type: gateway.PathMatchExact, Value: "/exact"}},
+									{Path: &gateway.HTTPPathMatch{Type: gateway.PathMatchPrefix, Value: "/prefix"}},
+									{
+										Path:    &gateway.HTTPPathMatch{Type: gateway.PathMatchRegularExpression, Value: "/regex/[a-z]+"},
+										Headers: &gateway.HTTPHeaderMatch{Values: map[string]string{"user-agent": "curl/7.1"}},
+									},
+								},
+								ForwardTo: []gateway.HTTPRouteForwardTo{{ServiceName: &backend}},
+							},
+							{
+								Matches: []gateway.HTTPRouteMatch{
+									{Path: &gateway.HTTPPathMatch{Type: gateway.PathMatchExact, Value: "/other"}},
+								},
+								ForwardTo: []gateway.HTTPRouteForwardTo{{ServiceName: &other}},
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "route-2",
+						Namespace: fakeIngressNamespace,
+					},
+					Spec: gateway.HTTPRouteSpec{
+						Rules: []gateway.HTTPRouteRule{
+							{
+								ForwardTo: []gateway.HTTPRouteForwardTo{{ServiceName: &backend}},
+							},
+						},
+					},
+				},
+			}
+			mc := MeshCatalog{gatewayMonitor: gatewayMonitor}
+
+			fakeService := service.MeshService{
+				Namespace: fakeIngressNamespace,
+				Name:      fakeIngressService,
+			}
+			domainRoutesMap := make(map[string][]trafficpolicy.Route)
+			err := mc.addGatewayRoutesPerHost(fakeService, domainRoutesMap)
+			Expect(err).ToNot(HaveOccurred())
+
+			expected := map[string][]trafficpolicy.Route{
+				"fake1.com": {
+					{PathRegex: "/exact", Methods: []string{constants.RegexMatchAll}},
+					{PathRegex: "/prefix.*", Methods: []string{constants.RegexMatchAll}},
+					{PathRegex: "/regex/[a-z]+", Methods: []string{constants.RegexMatchAll}, Headers: map[string]string{"user-agent": `curl/7\.1`}},
+				},
+				constants.WildcardHTTPMethod: {
+					{PathRegex: constants.RegexMatchAll, Methods: []string{constants.RegexMatchAll}},
+				},
+			}
+			Expect(domainRoutesMap).To(Equal(expected))
+		})
+	})
+})
@@ -6,7 +6,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
-// GetIngressRoutesPerHost returns routes per host as defined in observed ingress k8s resources.
+// GetIngressRoutesPerHost returns routes per host as defined in observed ingress k8s resources and Gateway API HTTPRoutes.
 func (mc *MeshCatalog) GetIngressRoutesPerHost(service service.MeshService) (map[string][]trafficpolicy.Route, error) {
 	domainRoutesMap := make(map[string][]trafficpolicy.Route)
 	ingresses, err := mc.ingressMonitor.GetIngressResources(service)
@@ -14,9 +14,6 @@ func (mc *MeshCatalog) GetIngressRoutesPerHost(service service.MeshService) (map
 		log.Error().Err(err).Msgf("Failed to get ingress resources with backend %s", service)
 		return domainRoutesMap, err
 	}
-	if len(ingresses) == 0 {
-		return domainRoutesMap, err
-	}
 
 	defaultRoute := trafficpolicy.Route{
 		PathRegex: constants.RegexMatchAll,
@@ -46,6 +43,10 @@ func (mc *MeshCatalog) GetIngressRoutesPerHost(service service.MeshService) (map
 		}
 	}
 
+	if err := mc.addGatewayRoutesPerHost(service, domainRoutesMap); err != nil {
+		return domainRoutesMap, err
+	}
+
 	log.Trace().Msgf("Created routes per host for service %s: %+v", service, domainRoutesMap)
 
 	return domainRoutesMap, nil
@@ -16,7 +16,9 @@ import (
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/gateway"
 	"github.com/openservicemesh/osm/pkg/ingress"
+	"github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/namespace"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/smi"
@@ -40,6 +42,7 @@ func newFakeMeshCatalog() *MeshCatalog {
 	cache := make(map[certificate.CommonName]certificate.Certificater)
 	certManager := tresor.NewFakeCertManager(&cache, 1*time.Hour)
 	ingressMonitor := ingress.NewFakeIngressMonitor()
+	gatewayMonitor := gateway.NewFakeGatewayMonitor()
 	ingressMonitor.FakeIngresses = getFakeIngresses()
 	stop := make(<-chan struct{})
 	var endpointProviders []endpoint.Provider
@@ -51,7 +54,7 @@ func newFakeMeshCatalog() *MeshCatalog {
 
 	namespaceController := namespace.NewFakeNamespaceController([]string{osmNamespace})
 
-	return NewMeshCatalog(namespaceController, kubeClient, meshSpec, certManager, ingressMonitor, stop, cfg, endpointProviders...)
+	return NewMeshCatalog(namespaceController, kubernetes.NewFakeController(kubeClient), meshSpec, certManager, ingressMonitor, gatewayMonitor, stop, cfg, endpointProviders...)
 }
 
 func getFakeIngresses() []*extensionsV1beta.Ingress {
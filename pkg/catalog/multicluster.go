@@ -0,0 +1,132 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/multicluster"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// ListExportedServices returns the services exported to other meshes through the multicluster gateway.
+// Services are only exported when the mesh is configured with a cluster name.
+func (mc *MeshCatalog) ListExportedServices() ([]service.MeshService, error) {
+	if mc.configurator.GetClusterName() == "" {
+		return nil, nil
+	}
+
+	services, err := mc.meshSpec.ListServices()
+	if err != nil {
+		log.Error().Err(err).Msg("Error listing services to export")
+		return nil, err
+	}
+
+	var exportedServices []service.MeshService
+	for _, svc := range services {
+		if multicluster.IsExported(svc) {
+			exportedServices = append(exportedServices, k8sSvcToMeshSvc(svc))
+		}
+	}
+	return exportedServices, nil
+}
+
+// ListExportedServicePorts returns the TCP ports of the given exported service. Other meshes connect to the
+// multicluster gateway on the ports of the mirrored service, which are the ports of the exported service.
+func (mc *MeshCatalog) ListExportedServicePorts(meshService service.MeshService) ([]uint32, error) {
+	svc, err := mc.meshSpec.GetService(meshService)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error finding exported service %q", meshService)
+		return nil, err
+	}
+
+	var ports []uint32
+	for _, port := range svc.Spec.Ports {
+		if port.Protocol != "" && port.Protocol != corev1.ProtocolTCP {
+			continue
+		}
+		ports = append(ports, uint32(port.Port))
+	}
+	return ports, nil
+}
+
+// listMulticlusterGatewayTrafficPolicies returns the traffic policies allowing the multicluster gateway
+// to forward traffic from other meshes to the exported services, which involve the given service.
+func (mc *MeshCatalog) listMulticlusterGatewayTrafficPolicies(svc service.MeshService) ([]trafficpolicy.TrafficTarget, error) {
+	exportedServices, err := mc.ListExportedServices()
+	if err != nil {
+		return nil, err
+	}
+
+	gatewayService := multicluster.GetGatewayService(mc.configurator.GetOSMNamespace())
+	allowAllRoute := trafficpolicy.Route{
+		PathRegex: constants.RegexMatchAll,
+		Methods:   []string{constants.WildcardHTTPMethod},
+	}
+
+	var trafficTargets []trafficpolicy.TrafficTarget
+	for _, exportedService := range exportedServices {
+		if svc != gatewayService && svc != exportedService {
+			continue
+		}
+		trafficTargets = append(trafficTargets, trafficpolicy.TrafficTarget{
+			Name:        fmt.Sprintf("%s->%s", gatewayService, exportedService),
+			Destination: exportedService,
+			Source:      gatewayService,
+			Route:       allowAllRoute,
+		})
+	}
+	return trafficTargets, nil
+}
+
+// getMirroredServiceHostnames returns the hostnames other meshes address the given exported service with.
+func (mc *MeshCatalog) getMirroredServiceHostnames(meshService service.MeshService) ([]string, error) {
+	clusterName := mc.configurator.GetClusterName()
+	if clusterName == "" {
+		return nil, nil
+	}
+
+	svc, err := mc.meshSpec.GetService(meshService)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error finding service %q", meshService)
+		return nil, err
+	}
+	if !multicluster.IsExported(svc) {
+		return nil, nil
+	}
+
+	mirroredService := svc.DeepCopy()
+	mirroredService.Name = multicluster.GetMirroredServiceName(svc.Name, clusterName)
+	return kubernetes.GetDomainsForService(mirroredService), nil
+}
+
+// ListAllowedOutboundRemoteClusters returns the sorted names of the remote clusters, the mirrored services of which the
+// given service is allowed to connect to.
+func (mc *MeshCatalog) ListAllowedOutboundRemoteClusters(svc service.MeshService) ([]string, error) {
+	allowedServices, err := mc.ListAllowedOutboundServices(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterNames := make(map[string]struct{})
+	for _, allowedService := range allowedServices {
+		k8sService, err := mc.meshSpec.GetService(allowedService)
+		if err != nil || k8sService == nil {
+			continue
+		}
+		if clusterName := k8sService.Labels[multicluster.MirroredFromLabel]; clusterName != "" {
+			clusterNames[clusterName] = struct{}{}
+		}
+	}
+
+	var remoteClusters []string
+	for clusterName := range clusterNames {
+		remoteClusters = append(remoteClusters, clusterName)
+	}
+	sort.Strings(remoteClusters)
+	return remoteClusters, nil
+}
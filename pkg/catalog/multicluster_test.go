@@ -0,0 +1,148 @@
+package catalog
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/multicluster"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// exportingMeshSpec is a fake MeshSpec whose services are exported based on the given set of names
+type exportingMeshSpec struct {
+	smi.MeshSpec
+	services []*corev1.Service
+}
+
+func (s exportingMeshSpec) ListServices() ([]*corev1.Service, error) {
+	return s.services, nil
+}
+
+func (s exportingMeshSpec) GetService(svc service.MeshService) (*corev1.Service, error) {
+	for _, k8sService := range s.services {
+		if k8sService.Name == svc.Name && k8sService.Namespace == svc.Namespace {
+			return k8sService, nil
+		}
+	}
+	return nil, errors.Errorf("Service %s not found", svc)
+}
+
+var _ = Describe("Test multicluster gateway catalog", func() {
+	const osmNamespace = "osm-system"
+
+	exportedService := tests.NewServiceFixture(tests.BookstoreService.Name, tests.BookstoreService.Namespace, nil)
+	exportedService.Annotations = map[string]string{multicluster.ExportAnnotation: "true"}
+	meshSpec := exportingMeshSpec{
+		MeshSpec: smi.NewFakeMeshSpecClient(),
+		services: []*corev1.Service{
+			exportedService,
+			tests.NewServiceFixture(tests.BookbuyerService.Name, tests.BookbuyerService.Namespace, nil),
+		},
+	}
+	gatewayService := multicluster.GetGatewayService(osmNamespace)
+
+	newMeshCatalog := func(clusterName string) *MeshCatalog {
+		return &MeshCatalog{
+			meshSpec: meshSpec,
+			configurator: configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{
+				OSMNamespace:                osmNamespace,
+				PermissiveTrafficPolicyMode: true,
+				ClusterName:                 clusterName,
+			}),
+		}
+	}
+
+	Context("Test ListExportedServices()", func() {
+		It("lists the annotated services", func() {
+			mc := newMeshCatalog("east")
+			actual, err := mc.ListExportedServices()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(Equal([]service.MeshService{tests.BookstoreService}))
+		})
+
+		It("does not export services without a cluster name", func() {
+			mc := newMeshCatalog("")
+			actual, err := mc.ListExportedServices()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(BeEmpty())
+		})
+	})
+
+	Context("Test ListExportedServicePorts()", func() {
+		It("lists the TCP ports of the exported service", func() {
+			mc := newMeshCatalog("east")
+			actual, err := mc.ListExportedServicePorts(tests.BookstoreService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(Equal([]uint32{tests.ServicePort}))
+		})
+	})
+
+	Context("Test listMulticlusterGatewayTrafficPolicies()", func() {
+		expected := trafficpolicy.TrafficTarget{
+			Name:        "osm-system/osm-multicluster-gateway->default/bookstore",
+			Destination: tests.BookstoreService,
+			Source:      gatewayService,
+			Route: trafficpolicy.Route{
+				PathRegex: constants.RegexMatchAll,
+				Methods:   []string{constants.WildcardHTTPMethod},
+			},
+		}
+
+		It("allows the gateway to reach the exported services", func() {
+			mc := newMeshCatalog("east")
+
+			actual, err := mc.listMulticlusterGatewayTrafficPolicies(gatewayService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(Equal([]trafficpolicy.TrafficTarget{expected}))
+
+			actual, err = mc.listMulticlusterGatewayTrafficPolicies(tests.BookstoreService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(Equal([]trafficpolicy.TrafficTarget{expected}))
+
+			actual, err = mc.listMulticlusterGatewayTrafficPolicies(tests.BookbuyerService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(BeEmpty())
+		})
+
+		It("lists the exported services as allowed outbound services of the gateway", func() {
+			mc := newMeshCatalog("east")
+
+			actual, err := mc.ListAllowedOutboundServices(gatewayService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(Equal([]service.MeshService{tests.BookstoreService}))
+		})
+	})
+
+	Context("Test getServiceHostnames()", func() {
+		It("includes the mirrored hostnames of exported services", func() {
+			mc := newMeshCatalog("east")
+
+			actual, err := mc.getServiceHostnames(tests.BookstoreService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).To(ContainElement("bookstore"))
+			Expect(actual).To(ContainElement("bookstore-east.default.svc.cluster.local"))
+
+			actual, err = mc.getServiceHostnames(tests.BookbuyerService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual).ToNot(ContainElement("bookbuyer-east"))
+		})
+	})
+
+	Context("Test GetServiceFromEnvoyCertificate()", func() {
+		It("returns the gateway service for the gateway's certificate", func() {
+			mc := newMeshCatalog("east")
+
+			actual, err := mc.GetServiceFromEnvoyCertificate(multicluster.GetGatewayCommonName(osmNamespace))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*actual).To(Equal(gatewayService))
+		})
+	})
+})
@@ -6,26 +6,97 @@ import (
 )
 
 const (
-	updateAtMostEvery  = 3 * time.Second
+	// maxBroadcastDelay bounds how long a continuous burst of announcements can postpone the broadcast
+	maxBroadcastDelay  = 10 * time.Second
 	updateAtLeastEvery = 1 * time.Minute
 )
 
 // repeater rebroadcasts announcements from SMI, Secrets, Endpoints providers etc. to all connected proxies.
+// Bursts of announcements are coalesced into a single broadcast, sent once no announcement has been
+// received for the configured debounce window, or at the latest maxBroadcastDelay after the burst started.
 func (mc *MeshCatalog) repeater() {
-	lastUpdateAt := time.Now().Add(-1 * updateAtMostEvery)
+	cases, caseNames := mc.getCases()
+
+	// The last case is the debounce timer of the pending broadcast
+	debounce := &debouncer{}
+	debounceIdx := len(cases)
+	cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv})
+
 	for {
-		cases, caseNames := mc.getCases()
-		for {
-			if chosenIdx, message, ok := reflect.Select(cases); ok {
-				log.Info().Msgf("[repeater] Received announcement from %s", caseNames[chosenIdx])
-				delta := time.Since(lastUpdateAt)
-				if delta >= updateAtMostEvery {
-					mc.broadcast(message)
-					lastUpdateAt = time.Now()
-				}
-			}
+		cases[debounceIdx].Chan = debounce.channel()
+		chosenIdx, message, ok := reflect.Select(cases)
+		if chosenIdx == debounceIdx {
+			pending, message := debounce.flush()
+			log.Debug().Msgf("[repeater] Broadcasting %d coalesced announcements", pending)
+			mc.broadcast(message)
+			continue
 		}
+		if ok {
+			log.Info().Msgf("[repeater] Received announcement from %s", caseNames[chosenIdx])
+			debounce.add(message, mc.configurator.GetBroadcastDebounceWindow())
+		}
+	}
+}
+
+// debouncer coalesces the announcements of a burst until its timer fires
+type debouncer struct {
+	timer          *time.Timer
+	burstStartedAt time.Time
+	pending        int
+	lastMessage    interface{}
+}
+
+// add records an announcement and (re)arms the timer for the given debounce window
+func (d *debouncer) add(message interface{}, window time.Duration) {
+	now := time.Now()
+	if d.pending == 0 {
+		d.burstStartedAt = now
+	}
+	d.pending++
+	d.lastMessage = message
+
+	delay := getBroadcastDelay(window, now.Sub(d.burstStartedAt))
+	if d.timer == nil {
+		d.timer = time.NewTimer(delay)
+		return
+	}
+	if !d.timer.Stop() {
+		// Drain the expired timer, which has not been received from yet
+		select {
+		case <-d.timer.C:
+		default:
+		}
+	}
+	d.timer.Reset(delay)
+}
+
+// channel returns the channel of the timer of the pending broadcast, or the zero Value ignored by reflect.Select
+func (d *debouncer) channel() reflect.Value {
+	if d.pending == 0 || d.timer == nil {
+		return reflect.Value{}
+	}
+	return reflect.ValueOf(d.timer.C)
+}
+
+// flush returns the number of coalesced announcements along with the last one, and resets the burst
+func (d *debouncer) flush() (int, interface{}) {
+	pending, message := d.pending, d.lastMessage
+	d.pending = 0
+	d.lastMessage = nil
+	return pending, message
+}
+
+// getBroadcastDelay returns how long to wait for further announcements, given the debounce window
+// and the time elapsed since the burst started
+func getBroadcastDelay(window time.Duration, sinceBurstStarted time.Duration) time.Duration {
+	remaining := maxBroadcastDelay - sinceBurstStarted
+	if remaining < 0 {
+		return 0
+	}
+	if window < remaining {
+		return window
 	}
+	return remaining
 }
 
 func (mc *MeshCatalog) getCases() ([]reflect.SelectCase, []string) {
@@ -39,6 +110,16 @@ func (mc *MeshCatalog) getCases() ([]reflect.SelectCase, []string) {
 	return cases, caseNames
 }
 
+// SubscribeAnnouncements returns a channel receiving the announcements broadcast to the connected proxies.
+// An announcement is dropped when the previous one has not been received yet.
+func (mc *MeshCatalog) SubscribeAnnouncements() <-chan interface{} {
+	subscriber := make(chan interface{}, 1)
+	mc.subscribersLock.Lock()
+	mc.subscribers = append(mc.subscribers, subscriber)
+	mc.subscribersLock.Unlock()
+	return subscriber
+}
+
 func (mc *MeshCatalog) broadcast(message interface{}) {
 	mc.connectedProxiesLock.Lock()
 	for _, connectedEnvoy := range mc.connectedProxies {
@@ -50,4 +131,13 @@ func (mc *MeshCatalog) broadcast(message interface{}) {
 		}
 	}
 	mc.connectedProxiesLock.Unlock()
+
+	mc.subscribersLock.Lock()
+	for _, subscriber := range mc.subscribers {
+		select {
+		case subscriber <- message:
+		default:
+		}
+	}
+	mc.subscribersLock.Unlock()
 }
@@ -0,0 +1,60 @@
+package catalog
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test announcement debouncing", func() {
+	Context("Test debouncer", func() {
+		It("coalesces a burst of announcements into one broadcast", func() {
+			d := &debouncer{}
+			Expect(d.channel().IsValid()).To(BeFalse())
+
+			d.add("first", 10*time.Millisecond)
+			d.add("second", 10*time.Millisecond)
+			d.add("third", 10*time.Millisecond)
+			Expect(d.channel().IsValid()).To(BeTrue())
+
+			Eventually(d.timer.C).Should(Receive())
+			pending, message := d.flush()
+			Expect(pending).To(Equal(3))
+			Expect(message).To(Equal("third"))
+			Expect(d.channel().IsValid()).To(BeFalse())
+		})
+
+		It("re-arms an expired timer which has not been received from", func() {
+			d := &debouncer{}
+			d.add("first", time.Millisecond)
+			time.Sleep(5 * time.Millisecond)
+
+			d.add("second", time.Hour)
+			Consistently(d.timer.C, 20*time.Millisecond).ShouldNot(Receive())
+		})
+	})
+
+	Context("Test getBroadcastDelay()", func() {
+		It("waits for the debounce window", func() {
+			Expect(getBroadcastDelay(time.Second, 0)).To(Equal(time.Second))
+		})
+
+		It("does not postpone the broadcast beyond maxBroadcastDelay", func() {
+			Expect(getBroadcastDelay(time.Second, maxBroadcastDelay-100*time.Millisecond)).To(Equal(100 * time.Millisecond))
+			Expect(getBroadcastDelay(time.Second, maxBroadcastDelay+time.Second)).To(Equal(time.Duration(0)))
+		})
+	})
+
+	Context("Test SubscribeAnnouncements()", func() {
+		It("receives the broadcast announcements without blocking the broadcast", func() {
+			mc := &MeshCatalog{}
+			announcements := mc.SubscribeAnnouncements()
+
+			mc.broadcast("first")
+			mc.broadcast("second")
+			Expect(announcements).To(Receive(Equal("first")))
+			Expect(announcements).ToNot(Receive())
+		})
+	})
+})
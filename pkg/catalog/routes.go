@@ -26,6 +26,22 @@ const (
 func (mc *MeshCatalog) ListTrafficPolicies(service service.MeshService) ([]trafficpolicy.TrafficTarget, error) {
 	log.Info().Msgf("Listing traffic policies for service: %s", service)
 
+	trafficPolicies, err := mc.listMeshTrafficPolicies(service)
+	if err != nil {
+		return nil, err
+	}
+
+	// Allow the multicluster gateway to forward traffic from other meshes to the exported services
+	gatewayTrafficPolicies, err := mc.listMulticlusterGatewayTrafficPolicies(service)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to build multicluster gateway traffic policies for service %s", service)
+		return nil, err
+	}
+	return append(trafficPolicies, gatewayTrafficPolicies...), nil
+}
+
+// listMeshTrafficPolicies returns the traffic policies for a given service derived from service discovery or SMI.
+func (mc *MeshCatalog) listMeshTrafficPolicies(service service.MeshService) ([]trafficpolicy.TrafficTarget, error) {
 	if mc.configurator.IsPermissiveTrafficPolicyMode() {
 		// Build traffic policies from service discovery for allow-all policy
 		trafficPolicies, err := mc.buildAllowAllTrafficPolicies(service)
@@ -173,7 +189,13 @@ func (mc *MeshCatalog) getServiceHostnames(meshService service.MeshService) ([]s
 	}
 
 	hostnames := kubernetes.GetDomainsForService(svc)
-	return hostnames, nil
+
+	// Exported services are also addressed by other meshes with their mirrored names
+	mirroredHostnames, err := mc.getMirroredServiceHostnames(meshService)
+	if err != nil {
+		return nil, err
+	}
+	return append(hostnames, mirroredHostnames...), nil
 }
 
 func (mc *MeshCatalog) getHTTPPathsPerRoute() (map[trafficpolicy.TrafficSpecName]map[trafficpolicy.TrafficSpecMatchName]trafficpolicy.Route, error) {
@@ -303,12 +325,25 @@ func (mc *MeshCatalog) buildAllowAllTrafficPolicies(service service.MeshService)
 		return nil, err
 	}
 
+	scopes := mc.getSidecarScopes()
+
 	var trafficTargets []trafficpolicy.TrafficTarget
 	for _, source := range services {
 		for _, destination := range services {
 			if reflect.DeepEqual(source, destination) {
 				continue
 			}
+
+			// Only the policies the proxies of the given service take part in are relevant to it,
+			// and a source service only reaches the destination services in its sidecar scope.
+			sourceService, destinationService := k8sSvcToMeshSvc(source), k8sSvcToMeshSvc(destination)
+			if !sourceService.Equals(service) && !destinationService.Equals(service) {
+				continue
+			}
+			if !isInSidecarScope(scopes, sourceService, destinationService) {
+				continue
+			}
+
 			allowTrafficTarget := mc.buildAllowPolicyForSourceToDest(source, destination)
 			trafficTargets = append(trafficTargets, allowTrafficTarget)
 		}
@@ -16,7 +16,9 @@ import (
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/endpoint/providers/kube"
+	"github.com/openservicemesh/osm/pkg/gateway"
 	"github.com/openservicemesh/osm/pkg/ingress"
+	"github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/namespace"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/smi"
@@ -37,7 +39,7 @@ var _ = Describe("Catalog tests", func() {
 
 	namespaceController := namespace.NewFakeNamespaceController([]string{osmNamespace})
 
-	meshCatalog := NewMeshCatalog(namespaceController, kubeClient, smi.NewFakeMeshSpecClient(), certManager, ingress.NewFakeIngressMonitor(), make(<-chan struct{}), cfg, endpointProviders...)
+	meshCatalog := NewMeshCatalog(namespaceController, kubernetes.NewFakeController(kubeClient), smi.NewFakeMeshSpecClient(), certManager, ingress.NewFakeIngressMonitor(), gateway.NewFakeGatewayMonitor(), make(<-chan struct{}), cfg, endpointProviders...)
 
 	Context("Test ListTrafficPolicies", func() {
 		It("lists traffic policies", func() {
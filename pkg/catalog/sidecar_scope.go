@@ -0,0 +1,75 @@
+package catalog
+
+import (
+	"strings"
+
+	backpressure "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// sidecarScopeWildcard matches any namespace or name in the egress services of a SidecarScope
+	sidecarScopeWildcard = "*"
+
+	// sidecarScopeLocalNamespace refers to the namespace of the SidecarScope in its egress services
+	sidecarScopeLocalNamespace = "."
+)
+
+// getSidecarScopes returns the sidecar scopes of the mesh.
+func (mc *MeshCatalog) getSidecarScopes() []*backpressure.SidecarScope {
+	if !featureflags.IsSidecarScopeEnabled() {
+		return nil
+	}
+	return mc.meshSpec.ListSidecarScopes()
+}
+
+// isInSidecarScope returns true if the sidecars of the source service are configured for the destination service.
+// The sidecars of a service selected by no SidecarScope are configured for every service, and the sidecars
+// of a service selected by several SidecarScopes for the egress services of all of them.
+func isInSidecarScope(scopes []*backpressure.SidecarScope, source, destination service.MeshService) bool {
+	selected := false
+	for _, scope := range scopes {
+		if !selectsService(scope, source) {
+			continue
+		}
+		selected = true
+		for _, egress := range scope.Spec.Egress {
+			if matchesEgress(egress, scope.Namespace, destination) {
+				return true
+			}
+		}
+	}
+	return !selected
+}
+
+// selectsService returns true if the given SidecarScope applies to the given service.
+func selectsService(scope *backpressure.SidecarScope, svc service.MeshService) bool {
+	if scope.Namespace != svc.Namespace {
+		return false
+	}
+	if len(scope.Spec.Services) == 0 {
+		return true
+	}
+	for _, name := range scope.Spec.Services {
+		if name == svc.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesEgress returns true if the given egress service of a SidecarScope in the given namespace matches the given service.
+// Egress services have the form <namespace>/<name>.
+func matchesEgress(egress, scopeNamespace string, svc service.MeshService) bool {
+	chunks := strings.SplitN(egress, "/", 2)
+	if len(chunks) != 2 {
+		return false
+	}
+
+	namespace, name := chunks[0], chunks[1]
+	if namespace == sidecarScopeLocalNamespace {
+		namespace = scopeNamespace
+	}
+	return (namespace == sidecarScopeWildcard || namespace == svc.Namespace) && (name == sidecarScopeWildcard || name == svc.Name)
+}
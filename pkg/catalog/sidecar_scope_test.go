@@ -0,0 +1,81 @@
+package catalog
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	backpressure "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func newSidecarScope(namespace string, services []string, egress ...string) *backpressure.SidecarScope {
+	return &backpressure.SidecarScope{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sidecar-scope",
+			Namespace: namespace,
+		},
+		Spec: backpressure.SidecarScopeSpec{
+			Services: services,
+			Egress:   egress,
+		},
+	}
+}
+
+var _ = Describe("Test sidecar scopes", func() {
+	otherService := service.MeshService{Namespace: "other", Name: "other"}
+
+	Context("Test isInSidecarScope()", func() {
+		It("allows every destination without sidecar scopes", func() {
+			Expect(isInSidecarScope(nil, tests.BookbuyerService, tests.BookstoreService)).To(BeTrue())
+			Expect(isInSidecarScope(nil, tests.BookbuyerService, otherService)).To(BeTrue())
+		})
+
+		It("allows every destination when no sidecar scope selects the source", func() {
+			scopes := []*backpressure.SidecarScope{
+				newSidecarScope("other", nil, "./other"),
+				newSidecarScope(tests.Namespace, []string{tests.BookstoreServiceName}, "./bookwarehouse"),
+			}
+			Expect(isInSidecarScope(scopes, tests.BookbuyerService, otherService)).To(BeTrue())
+		})
+
+		It("only allows the egress services of the sidecar scopes selecting the source", func() {
+			scopes := []*backpressure.SidecarScope{
+				newSidecarScope(tests.Namespace, []string{tests.BookbuyerServiceName}, "./"+tests.BookstoreServiceName),
+			}
+			Expect(isInSidecarScope(scopes, tests.BookbuyerService, tests.BookstoreService)).To(BeTrue())
+			Expect(isInSidecarScope(scopes, tests.BookbuyerService, otherService)).To(BeFalse())
+		})
+
+		It("merges the egress services of all the sidecar scopes selecting the source", func() {
+			scopes := []*backpressure.SidecarScope{
+				newSidecarScope(tests.Namespace, nil, "./"+tests.BookstoreServiceName),
+				newSidecarScope(tests.Namespace, []string{tests.BookbuyerServiceName}, "other/other"),
+			}
+			Expect(isInSidecarScope(scopes, tests.BookbuyerService, tests.BookstoreService)).To(BeTrue())
+			Expect(isInSidecarScope(scopes, tests.BookbuyerService, otherService)).To(BeTrue())
+		})
+
+		It("matches wildcards in the egress services", func() {
+			scopes := []*backpressure.SidecarScope{
+				newSidecarScope(tests.Namespace, nil, "other/*"),
+			}
+			Expect(isInSidecarScope(scopes, tests.BookbuyerService, otherService)).To(BeTrue())
+			Expect(isInSidecarScope(scopes, tests.BookbuyerService, tests.BookstoreService)).To(BeFalse())
+
+			scopes = []*backpressure.SidecarScope{
+				newSidecarScope(tests.Namespace, nil, "*/"+tests.BookstoreServiceName),
+			}
+			Expect(isInSidecarScope(scopes, tests.BookbuyerService, tests.BookstoreService)).To(BeTrue())
+			Expect(isInSidecarScope(scopes, tests.BookbuyerService, otherService)).To(BeFalse())
+		})
+
+		It("ignores malformed egress services", func() {
+			scopes := []*backpressure.SidecarScope{
+				newSidecarScope(tests.Namespace, nil, tests.BookstoreServiceName),
+			}
+			Expect(isInSidecarScope(scopes, tests.BookbuyerService, tests.BookstoreService)).To(BeFalse())
+		})
+	})
+})
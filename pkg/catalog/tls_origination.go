@@ -0,0 +1,46 @@
+package catalog
+
+import (
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	// defaultTLSOriginationPort is the port TLS connections are originated to when a policy does not specify one
+	defaultTLSOriginationPort = 443
+)
+
+// GetTLSOriginationPolicies returns the egress TLS origination policies for the external hosts the given service
+// makes plaintext HTTP requests to. A TLSOrigination resource applies to all the services in its namespace.
+func (mc *MeshCatalog) GetTLSOriginationPolicies(svc service.MeshService) []trafficpolicy.TLSOrigination {
+	if !featureflags.IsTLSOriginationEnabled() {
+		return nil
+	}
+
+	var policies []trafficpolicy.TLSOrigination
+	for _, tlsOrigination := range mc.meshSpec.ListTLSOriginations() {
+		if tlsOrigination.Namespace != svc.Namespace {
+			continue
+		}
+
+		port := tlsOrigination.Spec.Port
+		if port == 0 {
+			port = defaultTLSOriginationPort
+		}
+
+		for _, host := range tlsOrigination.Spec.Hosts {
+			sni := tlsOrigination.Spec.SNI
+			if sni == "" {
+				sni = host
+			}
+			policies = append(policies, trafficpolicy.TLSOrigination{
+				Host:     host,
+				Port:     port,
+				SNI:      sni,
+				CABundle: tlsOrigination.Spec.CABundle,
+			})
+		}
+	}
+	return policies
+}
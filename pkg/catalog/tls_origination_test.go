@@ -0,0 +1,34 @@
+package catalog
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var _ = Describe("Test egress TLS origination policies", func() {
+	Context("Test GetTLSOriginationPolicies()", func() {
+		featureflags.Initialize(featureflags.OptionalFeatures{TLSOrigination: true})
+		mc := &MeshCatalog{meshSpec: smi.NewFakeMeshSpecClient()}
+
+		It("returns the policies in the namespace of the service with defaults applied", func() {
+			actual := mc.GetTLSOriginationPolicies(tests.BookbuyerService)
+			Expect(actual).To(Equal([]trafficpolicy.TLSOrigination{{
+				Host: "httpbin.org",
+				Port: 443,
+				SNI:  "httpbin.org",
+			}}))
+			Expect(actual[0].GetClusterName()).To(Equal(service.ClusterName("tls-origination|httpbin.org:443")))
+		})
+
+		It("does not return the policies of other namespaces", func() {
+			actual := mc.GetTLSOriginationPolicies(service.MeshService{Namespace: "other", Name: "bookbuyer"})
+			Expect(actual).To(BeEmpty())
+		})
+	})
+})
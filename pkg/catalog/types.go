@@ -9,13 +9,14 @@ import (
 	spec "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha3"
 	split "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/client-go/kubernetes"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/gateway"
 	"github.com/openservicemesh/osm/pkg/ingress"
+	"github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/namespace"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -33,6 +34,7 @@ type MeshCatalog struct {
 	meshSpec           smi.MeshSpec
 	certManager        certificate.Manager
 	ingressMonitor     ingress.Monitor
+	gatewayMonitor     gateway.Monitor
 	configurator       configurator.Configurator
 
 	expectedProxies     map[certificate.CommonName]expectedProxy
@@ -46,9 +48,13 @@ type MeshCatalog struct {
 
 	announcementChannels mapset.Set
 
+	// subscribers receive the announcements broadcast to the connected proxies
+	subscribers     []chan interface{}
+	subscribersLock sync.Mutex
+
 	// Current assumption is that OSM is working with a single Kubernetes cluster.
-	// This here is the client to that cluster.
-	kubeClient kubernetes.Interface
+	// This here is the cache of the resources of that cluster.
+	kubeController kubernetes.Controller
 
 	namespaceController namespace.Controller
 }
@@ -89,6 +95,9 @@ type MeshCataloger interface {
 	// UnregisterProxy unregisters an existing proxy from the service mesh catalog
 	UnregisterProxy(*envoy.Proxy)
 
+	// SubscribeAnnouncements returns a channel receiving the announcements broadcast to the connected proxies
+	SubscribeAnnouncements() <-chan interface{}
+
 	// GetServiceForServiceAccount returns the service corresponding to a service account
 	GetServiceForServiceAccount(service.K8sServiceAccount) (service.MeshService, error)
 
@@ -104,6 +113,18 @@ type MeshCataloger interface {
 
 	// ListMonitoredNamespaces lists namespaces monitored by the control plane
 	ListMonitoredNamespaces() []string
+
+	// ListExportedServices lists the services exported to other meshes through the multicluster gateway
+	ListExportedServices() ([]service.MeshService, error)
+
+	// ListExportedServicePorts lists the TCP ports of an exported service, on which the multicluster gateway listens for it
+	ListExportedServicePorts(service.MeshService) ([]uint32, error)
+
+	// ListAllowedOutboundRemoteClusters lists the remote clusters the mirrored services of which the given service is allowed to connect to
+	ListAllowedOutboundRemoteClusters(service.MeshService) ([]string, error)
+
+	// GetTLSOriginationPolicies returns the egress TLS origination policies for the given service
+	GetTLSOriginationPolicies(service.MeshService) []trafficpolicy.TLSOrigination
 }
 
 type announcementChannel struct {
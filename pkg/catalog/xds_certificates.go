@@ -7,22 +7,30 @@ import (
 
 	mapset "github.com/deckarep/golang-set"
 	v1 "k8s.io/api/core/v1"
-	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sClient "k8s.io/client-go/kubernetes"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/multicluster"
 	"github.com/openservicemesh/osm/pkg/service"
 )
 
 // GetServiceFromEnvoyCertificate returns the single service given Envoy is a member of based on the certificate provided, which is a cert issued to an Envoy for XDS communication (not Envoy-to-Envoy).
 func (mc *MeshCatalog) GetServiceFromEnvoyCertificate(cn certificate.CommonName) (*service.MeshService, error) {
-	pod, err := GetPodFromCertificate(cn, mc.kubeClient)
+	// The multicluster gateway is not a pod with a sidecar, it is identified by its certificate alone
+	if osmNamespace := mc.configurator.GetOSMNamespace(); multicluster.IsGatewayProxy(cn, osmNamespace) {
+		gatewayService := multicluster.GetGatewayService(osmNamespace)
+		return &gatewayService, nil
+	}
+
+	pod, err := GetPodFromCertificate(cn, mc.kubeController)
 	if err != nil {
 		return nil, err
 	}
 
-	services, err := listServicesForPod(pod, mc.kubeClient)
+	services, err := listServicesForPod(pod, mc.kubeController)
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +87,7 @@ func (mc *MeshCatalog) filterTrafficSplitServices(services []v1.Service) []v1.Se
 }
 
 // GetPodFromCertificate returns the Kubernetes Pod object for a given certificate.
-func GetPodFromCertificate(cn certificate.CommonName, kubeClient kubernetes.Interface) (*v1.Pod, error) {
+func GetPodFromCertificate(cn certificate.CommonName, kubeController kubernetes.Controller) (*v1.Pod, error) {
 	cnMeta, err := getCertificateCommonNameMeta(cn)
 	if err != nil {
 		return nil, err
@@ -87,21 +95,50 @@ func GetPodFromCertificate(cn certificate.CommonName, kubeClient kubernetes.Inte
 
 	log.Trace().Msgf("Looking for pod with label %q=%q", constants.EnvoyUniqueIDLabelName, cnMeta.ProxyID)
 
-	podList, err := kubeClient.CoreV1().Pods(cnMeta.Namespace).List(context.Background(), v12.ListOptions{})
+	pods, err := kubeController.ListPodsForProxyID(cnMeta.Namespace, cnMeta.ProxyID)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error listing pods in namespace %s", cnMeta.Namespace)
 		return nil, err
 	}
 
-	var pods []v1.Pod
-	for _, pod := range podList.Items {
-		for labelKey, labelValue := range pod.Labels {
-			if labelKey == constants.EnvoyUniqueIDLabelName && labelValue == cnMeta.ProxyID {
-				pods = append(pods, pod)
-			}
-		}
+	return getPodForCertificate(pods, cnMeta)
+}
+
+// GetLivePodFromCertificate returns the pod the given certificate was issued for, read from the API server rather than
+// from the caches of the controller. A pod which is terminating or has terminated is not returned.
+func GetLivePodFromCertificate(cn certificate.CommonName, kubeClient k8sClient.Interface) (*v1.Pod, error) {
+	cnMeta, err := getCertificateCommonNameMeta(cn)
+	if err != nil {
+		return nil, err
 	}
 
+	podList, err := kubeClient.CoreV1().Pods(cnMeta.Namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", constants.EnvoyUniqueIDLabelName, cnMeta.ProxyID),
+	})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing pods in namespace %s", cnMeta.Namespace)
+		return nil, err
+	}
+
+	var pods []*v1.Pod
+	for idx := range podList.Items {
+		pods = append(pods, &podList.Items[idx])
+	}
+	pod, err := getPodForCertificate(pods, cnMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	if pod.DeletionTimestamp != nil || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+		log.Error().Msgf("Pod %s/%s with label %s = %s is not running", pod.Namespace, pod.Name, constants.EnvoyUniqueIDLabelName, cnMeta.ProxyID)
+		return nil, errDidNotFindPodForCertificate
+	}
+	return pod, nil
+}
+
+// getPodForCertificate returns the single pod of the given pods labeled with the proxy ID of a certificate,
+// ensuring it matches the namespace and service account of the certificate.
+func getPodForCertificate(pods []*v1.Pod, cnMeta *certificateCommonNameMeta) (*v1.Pod, error) {
 	if len(pods) == 0 {
 		log.Error().Msgf("Did not find pod with label %s = %s in namespace %s", constants.EnvoyUniqueIDLabelName, cnMeta.ProxyID, cnMeta.Namespace)
 		return nil, errDidNotFindPodForCertificate
@@ -132,7 +169,7 @@ func GetPodFromCertificate(cn certificate.CommonName, kubeClient kubernetes.Inte
 		return nil, errServiceAccountDoesNotMatchCertificate
 	}
 
-	return &pod, nil
+	return pod, nil
 }
 
 func mapStringStringToSet(m map[string]string) mapset.Set {
@@ -143,20 +180,20 @@ func mapStringStringToSet(m map[string]string) mapset.Set {
 	return stringSet
 }
 
-func listServicesForPod(pod *v1.Pod, kubeClient kubernetes.Interface) ([]v1.Service, error) {
+func listServicesForPod(pod *v1.Pod, kubeController kubernetes.Controller) ([]v1.Service, error) {
 	var serviceList []v1.Service
-	svcList, err := kubeClient.CoreV1().Services(pod.Namespace).List(context.Background(), v12.ListOptions{})
+	services, err := kubeController.ListServices(pod.Namespace)
 	if err != nil {
-		log.Error().Err(err).Msgf("Error listing pods in namespace %s", pod.Namespace)
+		log.Error().Err(err).Msgf("Error listing services in namespace %s", pod.Namespace)
 		return nil, err
 	}
 
 	podLabels := mapStringStringToSet(pod.Labels)
 
-	for _, svc := range svcList.Items {
+	for _, svc := range services {
 		serviceLabelSet := mapStringStringToSet(svc.Spec.Selector)
 		if serviceLabelSet.Intersect(podLabels).Cardinality() > 0 {
-			serviceList = append(serviceList, svc)
+			serviceList = append(serviceList, *svc)
 		}
 	}
 
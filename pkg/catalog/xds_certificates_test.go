@@ -14,6 +14,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
@@ -22,6 +23,7 @@ var _ = Describe("Test XDS certificate tooling", func() {
 
 	kubeClient := testclient.NewSimpleClientset()
 
+	kubeController := kubernetes.NewFakeController(kubeClient)
 	mc := NewFakeMeshCatalog(kubeClient)
 	cn := certificate.CommonName(fmt.Sprintf("%s.%s.%s", tests.EnvoyUID, tests.BookstoreServiceAccountName, tests.Namespace))
 
@@ -124,7 +126,7 @@ var _ = Describe("Test XDS certificate tooling", func() {
 			Expect(len(pods.Items)).To(Equal(3))
 
 			newCN := certificate.CommonName(fmt.Sprintf("%s.%s.%s", envoyUID, tests.BookstoreServiceAccountName, namespace))
-			actualPod, err := GetPodFromCertificate(newCN, kubeClient)
+			actualPod, err := GetPodFromCertificate(newCN, kubeController)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(actualPod.Name).To(Equal(newPod1.Name))
@@ -149,7 +151,7 @@ var _ = Describe("Test XDS certificate tooling", func() {
 
 			// No service account in this CN
 			newCN := certificate.CommonName(fmt.Sprintf("%s.%s", envoyUID, namespace))
-			actualPod, err := GetPodFromCertificate(newCN, kubeClient)
+			actualPod, err := GetPodFromCertificate(newCN, kubeController)
 			Expect(err).To(HaveOccurred())
 			Expect(err).To(Equal(errInvalidCertificateCN))
 			Expect(actualPod).To(BeNil())
@@ -172,7 +174,7 @@ var _ = Describe("Test XDS certificate tooling", func() {
 			}
 
 			newCN := certificate.CommonName(fmt.Sprintf("%s.%s.%s", envoyUID, tests.BookstoreServiceAccountName, namespace))
-			actualPod, err := GetPodFromCertificate(newCN, kubeClient)
+			actualPod, err := GetPodFromCertificate(newCN, kubeController)
 			Expect(err).To(HaveOccurred())
 			Expect(err).To(Equal(errMoreThanOnePodForCertificate))
 			Expect(actualPod).To(BeNil())
@@ -195,7 +197,7 @@ var _ = Describe("Test XDS certificate tooling", func() {
 			Expect(newPod.Spec.ServiceAccountName).To(Equal(tests.BookstoreServiceAccountName))
 
 			newCN := certificate.CommonName(fmt.Sprintf("%s.%s.%s", envoyUID, randomServiceAccount, namespace))
-			actualPod, err := GetPodFromCertificate(newCN, kubeClient)
+			actualPod, err := GetPodFromCertificate(newCN, kubeController)
 			Expect(err).To(HaveOccurred())
 			Expect(err).To(Equal(errServiceAccountDoesNotMatchCertificate))
 			Expect(actualPod).To(BeNil())
@@ -216,7 +218,7 @@ var _ = Describe("Test XDS certificate tooling", func() {
 			Expect(err).ToNot(HaveOccurred())
 
 			newCN := certificate.CommonName(fmt.Sprintf("%s.%s.%s", envoyUID, tests.BookstoreServiceAccountName, someOtherRandomNamespace))
-			actualPod, err := GetPodFromCertificate(newCN, kubeClient)
+			actualPod, err := GetPodFromCertificate(newCN, kubeController)
 			Expect(err).To(HaveOccurred())
 			// Since the namespace on the certificate is different than where the pod is...
 			Expect(err).To(Equal(errDidNotFindPodForCertificate))
@@ -257,12 +259,12 @@ var _ = Describe("Test XDS certificate tooling", func() {
 			}
 
 			pod := tests.NewPodTestFixture(namespace, "pod-name")
-			actualSvcs, err := listServicesForPod(&pod, kubeClient)
+			actualSvcs, err := listServicesForPod(&pod, kubeController)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(len(actualSvcs)).To(Equal(2))
 
 			actualNames := []string{actualSvcs[0].Name, actualSvcs[1].Name}
-			Expect(actualNames).To(Equal(serviceNames))
+			Expect(actualNames).To(ConsistOf(serviceNames))
 		})
 	})
 
@@ -1,15 +1,19 @@
 package configurator
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"reflect"
 	"strconv"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/openservicemesh/osm/pkg/constants"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 )
 
@@ -19,11 +23,25 @@ const (
 	prometheusScrapingKey          = "prometheus_scraping"
 	meshCIDRRangesKey              = "mesh_cidr_ranges"
 	useHTTPSIngressKey             = "use_https_ingress"
+	useMTLSIngressKey              = "use_mtls_ingress"
+	ingressClientCertServiceKey    = "ingress_client_cert_service"
+	ingressClientCertSecretKey     = "ingress_client_cert_secret"
+	clusterNameKey                 = "cluster_name"
+	dnsProxyKey                    = "dns_proxy"
+	broadcastDebounceWindowKey     = "broadcast_debounce_window"
+	proxyUpdateMinIntervalKey      = "proxy_update_min_interval"
+	ipFamilyKey                    = "ip_family"
+	sidecarImageOverridesKey       = "sidecar_image_overrides"
+	initContainerImageOverridesKey = "init_container_image_overrides"
 	zipkinTracingKey               = "zipkin_tracing"
 	zipkinAddressKey               = "zipkin_address"
 	zipkinPortKey                  = "zipkin_port"
 	zipkinEndpointKey              = "zipkin_endpoint"
 	defaultInMeshCIDR              = ""
+
+	// multiclusterTrustedCAsConfigMapName is the optional ConfigMap of the OSM namespace holding the root certificates
+	// of the remote clusters, keyed by remote cluster name
+	multiclusterTrustedCAsConfigMapName = "osm-multicluster-trusted-cas"
 )
 
 // NewConfigurator implements configurator.Configurator and creates the Kubernetes client to manage namespaces.
@@ -41,13 +59,14 @@ func newConfigurator(kubeClient kubernetes.Interface, stop <-chan struct{}, osmN
 		announcements:    make(chan interface{}),
 		osmNamespace:     osmNamespace,
 		osmConfigMapName: osmConfigMapName,
+		detectedIPFamily: detectIPFamily(kubeClient, osmNamespace),
 	}
 
-	// Ensure this exclusively watches only the Namespace where OSM in installed and the particular ConfigMap we need.
+	// Ensure this exclusively watches only the Namespace where OSM in installed and the particular ConfigMaps we need.
 	shouldObserve := func(obj interface{}) bool {
 		ns := reflect.ValueOf(obj).Elem().FieldByName("ObjectMeta").FieldByName("Namespace").String()
 		name := reflect.ValueOf(obj).Elem().FieldByName("ObjectMeta").FieldByName("Name").String()
-		return ns == osmNamespace && name == osmConfigMapName
+		return ns == osmNamespace && (name == osmConfigMapName || name == multiclusterTrustedCAsConfigMapName)
 	}
 
 	informerName := "ConfigMap"
@@ -91,6 +110,56 @@ type osmConfig struct {
 
 	// UseHTTPSIngress is a bool toggle enabling HTTPS protocol between ingress and backend pods
 	UseHTTPSIngress bool `yaml:"use_https_ingress"`
+
+	// UseMTLSIngress is a bool toggle requiring ingress to present a client certificate to backend pods
+	UseMTLSIngress bool `yaml:"use_mtls_ingress"`
+
+	// IngressClientCertService is the namespaced service whose identity is issued to the ingress controller
+	IngressClientCertService string `yaml:"ingress_client_cert_service"`
+
+	// IngressClientCertSecret is the namespaced name of the secret the ingress client certificate is stored in
+	IngressClientCertSecret string `yaml:"ingress_client_cert_secret"`
+
+	// ClusterName is the name of the cluster the mesh runs in, used to identify the mesh to other meshes
+	ClusterName string `yaml:"cluster_name"`
+
+	// DNSProxy is a bool toggle used to enable or disable DNS proxying in the sidecar
+	DNSProxy bool `yaml:"dns_proxy"`
+
+	// BroadcastDebounceWindow is the duration announcements are coalesced for before proxies are updated
+	BroadcastDebounceWindow string `yaml:"broadcast_debounce_window"`
+
+	// ProxyUpdateMinInterval is the minimum duration between two updates pushed to a proxy
+	ProxyUpdateMinInterval string `yaml:"proxy_update_min_interval"`
+
+	// IPFamily is the IP family of the pods in the mesh: ipv4, ipv6 or dual-stack, detected when empty
+	IPFamily string `yaml:"ip_family"`
+
+	// SidecarImageOverrides are the sidecar images of pods scheduled on nodes of given architectures, as comma-separated <arch>=<image>
+	SidecarImageOverrides string `yaml:"sidecar_image_overrides"`
+
+	// InitContainerImageOverrides are the init container images of pods scheduled on nodes of given architectures, as comma-separated <arch>=<image>
+	InitContainerImageOverrides string `yaml:"init_container_image_overrides"`
+}
+
+// detectIPFamily returns the IP family of the pod osm-controller runs in, IPv4 when it cannot be determined.
+func detectIPFamily(kubeClient kubernetes.Interface, osmNamespace string) IPFamily {
+	podName := os.Getenv(constants.EnvVarPodName)
+	if podName == "" {
+		return IPv4
+	}
+
+	pod, err := kubeClient.CoreV1().Pods(osmNamespace).Get(context.Background(), podName, metav1.GetOptions{})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting pod %s/%s to detect the IP family of the mesh; Defaulting to %s", osmNamespace, podName, IPv4)
+		return IPv4
+	}
+
+	podIPs := []string{pod.Status.PodIP}
+	for _, podIP := range pod.Status.PodIPs {
+		podIPs = append(podIPs, podIP.IP)
+	}
+	return getIPFamilyFromIPs(podIPs)
 }
 
 func (c *Client) run(stop <-chan struct{}) {
@@ -132,6 +201,16 @@ func (c *Client) getConfigMap() *osmConfig {
 		PrometheusScraping:          getBoolValueForKey(configMap, prometheusScrapingKey),
 		MeshCIDRRanges:              getEgressCIDR(configMap),
 		UseHTTPSIngress:             getBoolValueForKey(configMap, useHTTPSIngressKey),
+		UseMTLSIngress:              getBoolValueForKey(configMap, useMTLSIngressKey),
+		IngressClientCertService:    getStringValueForKey(configMap, ingressClientCertServiceKey),
+		IngressClientCertSecret:     getStringValueForKey(configMap, ingressClientCertSecretKey),
+		ClusterName:                 getStringValueForKey(configMap, clusterNameKey),
+		DNSProxy:                    getBoolValueForKey(configMap, dnsProxyKey),
+		BroadcastDebounceWindow:     getStringValueForKey(configMap, broadcastDebounceWindowKey),
+		ProxyUpdateMinInterval:      getStringValueForKey(configMap, proxyUpdateMinIntervalKey),
+		IPFamily:                    getStringValueForKey(configMap, ipFamilyKey),
+		SidecarImageOverrides:       getStringValueForKey(configMap, sidecarImageOverridesKey),
+		InitContainerImageOverrides: getStringValueForKey(configMap, initContainerImageOverridesKey),
 
 		ZipkinTracing:  getBoolValueForKey(configMap, zipkinTracingKey),
 		ZipkinAddress:  getStringValueForKey(configMap, zipkinAddressKey),
@@ -107,11 +107,21 @@ var _ = Describe("Test OSM ConfigMap parsing", func() {
 				"ZipkinEndpoint":              zipkinEndpointKey,
 				"MeshCIDRRanges":              meshCIDRRangesKey,
 				"UseHTTPSIngress":             useHTTPSIngressKey,
+				"UseMTLSIngress":              useMTLSIngressKey,
+				"IngressClientCertService":    ingressClientCertServiceKey,
+				"IngressClientCertSecret":     ingressClientCertSecretKey,
+				"ClusterName":                 clusterNameKey,
+				"DNSProxy":                    dnsProxyKey,
+				"BroadcastDebounceWindow":     broadcastDebounceWindowKey,
+				"ProxyUpdateMinInterval":      proxyUpdateMinIntervalKey,
+				"IPFamily":                    ipFamilyKey,
+				"SidecarImageOverrides":       sidecarImageOverridesKey,
+				"InitContainerImageOverrides": initContainerImageOverridesKey,
 			}
 			t := reflect.TypeOf(osmConfig{})
 
 			actualNumberOfFields := t.NumField()
-			expectedNumberOfFields := 9
+			expectedNumberOfFields := 19
 			Expect(actualNumberOfFields).To(
 				Equal(expectedNumberOfFields),
 				fmt.Sprintf("Fields have been added or removed from the osmConfig struct -- expected %d, actual %d; please correct this unit test", expectedNumberOfFields, actualNumberOfFields))
@@ -1,6 +1,8 @@
 package configurator
 
 import (
+	"time"
+
 	"github.com/openservicemesh/osm/pkg/constants"
 )
 
@@ -13,6 +15,17 @@ type FakeConfigurator struct {
 	ZipkinTracing               bool
 	MeshCIDRRanges              []string
 	HTTPSIngress                bool
+	MTLSIngress                 bool
+	IngressClientCertService    string
+	IngressClientCertSecret     string
+	ClusterName                 string
+	MulticlusterTrustedCAs      map[string][]byte
+	DNSProxy                    bool
+	BroadcastDebounceWindow     time.Duration
+	ProxyUpdateMinInterval      time.Duration
+	IPFamily                    IPFamily
+	SidecarImages               map[string]string
+	InitContainerImages         map[string]string
 }
 
 // NewFakeConfigurator create a new fake Configurator
@@ -35,6 +48,17 @@ func NewFakeConfiguratorWithOptions(f FakeConfigurator) Configurator {
 		ZipkinTracing:               f.ZipkinTracing,
 		MeshCIDRRanges:              f.MeshCIDRRanges,
 		HTTPSIngress:                f.HTTPSIngress,
+		MTLSIngress:                 f.MTLSIngress,
+		IngressClientCertService:    f.IngressClientCertService,
+		IngressClientCertSecret:     f.IngressClientCertSecret,
+		ClusterName:                 f.ClusterName,
+		MulticlusterTrustedCAs:      f.MulticlusterTrustedCAs,
+		DNSProxy:                    f.DNSProxy,
+		BroadcastDebounceWindow:     f.BroadcastDebounceWindow,
+		ProxyUpdateMinInterval:      f.ProxyUpdateMinInterval,
+		IPFamily:                    f.IPFamily,
+		SidecarImages:               f.SidecarImages,
+		InitContainerImages:         f.InitContainerImages,
 	}
 }
 
@@ -78,6 +102,31 @@ func (f FakeConfigurator) UseHTTPSIngress() bool {
 	return f.HTTPSIngress
 }
 
+// UseMTLSIngress determines whether ingress must authenticate to backend pods with a client certificate
+func (f FakeConfigurator) UseMTLSIngress() bool {
+	return f.MTLSIngress
+}
+
+// GetIngressClientCertService returns the namespaced service whose identity is issued to the ingress controller
+func (f FakeConfigurator) GetIngressClientCertService() string {
+	return f.IngressClientCertService
+}
+
+// GetIngressClientCertSecret returns the namespaced name of the secret the ingress client certificate is stored in
+func (f FakeConfigurator) GetIngressClientCertSecret() string {
+	return f.IngressClientCertSecret
+}
+
+// GetClusterName returns the name of the cluster the mesh runs in
+func (f FakeConfigurator) GetClusterName() string {
+	return f.ClusterName
+}
+
+// GetMulticlusterTrustedCAs returns the PEM encoded root certificates of the remote clusters, keyed by remote cluster name
+func (f FakeConfigurator) GetMulticlusterTrustedCAs() map[string][]byte {
+	return f.MulticlusterTrustedCAs
+}
+
 // GetAnnouncementsChannel returns a fake announcement channel
 func (f FakeConfigurator) GetAnnouncementsChannel() <-chan interface{} {
 	return make(chan interface{})
@@ -97,3 +146,36 @@ func (f FakeConfigurator) GetZipkinPort() uint32 {
 func (f FakeConfigurator) GetZipkinEndpoint() string {
 	return constants.DefaultZipkinEndpoint
 }
+
+// IsDNSProxyEnabled determines whether DNS queries of applications are answered by their sidecar
+func (f FakeConfigurator) IsDNSProxyEnabled() bool {
+	return f.DNSProxy
+}
+
+// GetBroadcastDebounceWindow returns how long announcements are coalesced for before proxies are updated
+func (f FakeConfigurator) GetBroadcastDebounceWindow() time.Duration {
+	return f.BroadcastDebounceWindow
+}
+
+// GetProxyUpdateMinInterval returns the minimum interval between two updates pushed to a proxy
+func (f FakeConfigurator) GetProxyUpdateMinInterval() time.Duration {
+	return f.ProxyUpdateMinInterval
+}
+
+// GetIPFamily returns the IP family of the pods in the mesh, IPv4 unless set
+func (f FakeConfigurator) GetIPFamily() IPFamily {
+	if f.IPFamily == "" {
+		return IPv4
+	}
+	return f.IPFamily
+}
+
+// GetSidecarImage returns the sidecar image of pods scheduled on nodes of the given architecture, empty when not overridden
+func (f FakeConfigurator) GetSidecarImage(arch string) string {
+	return f.SidecarImages[arch]
+}
+
+// GetInitContainerImage returns the init container image of pods scheduled on nodes of the given architecture, empty when not overridden
+func (f FakeConfigurator) GetInitContainerImage(arch string) string {
+	return f.InitContainerImages[arch]
+}
@@ -2,11 +2,16 @@ package configurator
 
 import (
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
-	"github.com/openservicemesh/osm/pkg/constants"
 	"net"
 	"sort"
 	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
 )
 
 // The functions in this file implement the configurator.Configurator interface
@@ -116,6 +121,157 @@ func (c *Client) UseHTTPSIngress() bool {
 	return c.getConfigMap().UseHTTPSIngress
 }
 
+// UseMTLSIngress determines whether ingress must authenticate to backend pods with a client certificate.
+// The validated client certificate details are forwarded to the backend in the x-forwarded-client-cert header.
+func (c *Client) UseMTLSIngress() bool {
+	return c.getConfigMap().UseMTLSIngress
+}
+
+// GetIngressClientCertService returns the namespaced service (<namespace>/<name>) whose identity is issued
+// to the ingress controller as a client certificate for authenticating to backend pods.
+func (c *Client) GetIngressClientCertService() string {
+	return c.getConfigMap().IngressClientCertService
+}
+
+// GetIngressClientCertSecret returns the namespaced name (<namespace>/<name>) of the secret
+// the ingress client certificate is stored in.
+func (c *Client) GetIngressClientCertSecret() string {
+	return c.getConfigMap().IngressClientCertSecret
+}
+
+// GetClusterName returns the name of the cluster the mesh runs in. Services exported through the
+// multicluster gateway are addressed by other meshes using this name, empty disables the gateway.
+func (c *Client) GetClusterName() string {
+	return c.getConfigMap().ClusterName
+}
+
+// GetMulticlusterTrustedCAs returns the PEM encoded root certificates of the remote clusters, keyed by remote cluster name.
+// They are read from the optional osm-multicluster-trusted-cas ConfigMap of the OSM namespace, and allow meshes with
+// different root certificates to authenticate the multicluster traffic of each other.
+func (c *Client) GetMulticlusterTrustedCAs() map[string][]byte {
+	configMapCacheKey := fmt.Sprintf("%s/%s", c.osmNamespace, multiclusterTrustedCAsConfigMapName)
+	item, exists, err := c.cache.GetByKey(configMapCacheKey)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting ConfigMap from cache with key %s", configMapCacheKey)
+		return nil
+	}
+	if !exists {
+		return nil
+	}
+
+	trustedCAs := make(map[string][]byte)
+	for clusterName, bundle := range item.(*v1.ConfigMap).Data {
+		if block, _ := pem.Decode([]byte(bundle)); block == nil || block.Type != "CERTIFICATE" {
+			log.Error().Msgf("Invalid root certificate of remote cluster %s in ConfigMap %s; Skipping certificate", clusterName, configMapCacheKey)
+			continue
+		}
+		trustedCAs[clusterName] = []byte(bundle)
+	}
+	return trustedCAs
+}
+
+// IsDNSProxyEnabled determines whether DNS queries of applications are intercepted and answered by their sidecar.
+// Names of mesh services are resolved by the sidecar, other names are forwarded to the pod's resolvers.
+func (c *Client) IsDNSProxyEnabled() bool {
+	return c.getConfigMap().DNSProxy
+}
+
+// GetBroadcastDebounceWindow returns how long announcements are coalesced for before proxies are updated.
+// A burst of Kubernetes events results in a single update once no event has been received for this duration.
+func (c *Client) GetBroadcastDebounceWindow() time.Duration {
+	return c.getDurationValue(broadcastDebounceWindowKey, c.getConfigMap().BroadcastDebounceWindow, constants.DefaultBroadcastDebounceWindow)
+}
+
+// GetProxyUpdateMinInterval returns the minimum interval between two updates pushed to a proxy.
+// Announcements received within this interval of the last update are coalesced into the next update.
+func (c *Client) GetProxyUpdateMinInterval() time.Duration {
+	return c.getDurationValue(proxyUpdateMinIntervalKey, c.getConfigMap().ProxyUpdateMinInterval, constants.DefaultProxyUpdateMinInterval)
+}
+
+// GetIPFamily returns the IP family of the pods in the mesh, which determines the addresses proxies listen on
+// and the iptables rules programmed in pods. When the ConfigMap does not set it, the IP family of the controller pod is used.
+func (c *Client) GetIPFamily() IPFamily {
+	switch ipFamily := IPFamily(c.getConfigMap().IPFamily); ipFamily {
+	case IPv4, IPv6, DualStack:
+		return ipFamily
+	case "":
+		return c.detectedIPFamily
+	default:
+		log.Error().Msgf("Invalid IP family %q for ConfigMap %s/%s key %s; Defaulting to %s", ipFamily, c.osmNamespace, c.osmConfigMapName, ipFamilyKey, c.detectedIPFamily)
+		return c.detectedIPFamily
+	}
+}
+
+// getIPFamilyFromIPs returns the IP family of a pod with the given IPs, IPv4 when none is valid.
+func getIPFamilyFromIPs(podIPs []string) IPFamily {
+	var hasIPv4, hasIPv6 bool
+	for _, podIP := range podIPs {
+		ip := net.ParseIP(podIP)
+		switch {
+		case ip == nil:
+			continue
+		case ip.To4() != nil:
+			hasIPv4 = true
+		default:
+			hasIPv6 = true
+		}
+	}
+
+	switch {
+	case hasIPv4 && hasIPv6:
+		return DualStack
+	case hasIPv6:
+		return IPv6
+	default:
+		return IPv4
+	}
+}
+
+// GetSidecarImage returns the sidecar image of pods scheduled on nodes of the given architecture, empty when not overridden.
+func (c *Client) GetSidecarImage(arch string) string {
+	return c.getImageOverride(sidecarImageOverridesKey, c.getConfigMap().SidecarImageOverrides, arch)
+}
+
+// GetInitContainerImage returns the init container image of pods scheduled on nodes of the given architecture, empty when not overridden.
+func (c *Client) GetInitContainerImage(arch string) string {
+	return c.getImageOverride(initContainerImageOverridesKey, c.getConfigMap().InitContainerImageOverrides, arch)
+}
+
+// getImageOverride returns the image of the given architecture in the comma-separated <arch>=<image> overrides of the given ConfigMap key.
+func (c *Client) getImageOverride(key, overrides, arch string) string {
+	if arch == "" {
+		return ""
+	}
+	for _, override := range strings.Split(overrides, ",") {
+		override = strings.TrimSpace(override)
+		if override == "" {
+			continue
+		}
+		archImage := strings.SplitN(override, "=", 2)
+		if len(archImage) != 2 || archImage[0] == "" || archImage[1] == "" {
+			log.Error().Msgf("Invalid image override %q for ConfigMap %s/%s key %s, must be <arch>=<image>; Skipping override", override, c.osmNamespace, c.osmConfigMapName, key)
+			continue
+		}
+		if archImage[0] == arch {
+			return archImage[1]
+		}
+	}
+	return ""
+}
+
+// getDurationValue parses the duration value of the given ConfigMap key, returning the default when unset or invalid.
+func (c *Client) getDurationValue(key, value string, defaultDuration time.Duration) time.Duration {
+	if value == "" {
+		return defaultDuration
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		log.Error().Err(err).Msgf("Invalid duration %q for ConfigMap %s/%s key %s; Defaulting to %s", value, c.osmNamespace, c.osmConfigMapName, key, defaultDuration)
+		return defaultDuration
+	}
+	return duration
+}
+
 // GetAnnouncementsChannel returns a channel, which is used to announce when changes have been made to the OSM ConfigMap.
 func (c *Client) GetAnnouncementsChannel() <-chan interface{} {
 	return c.announcements
@@ -2,6 +2,7 @@ package configurator
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -9,6 +10,8 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testclient "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/constants"
 )
 
 var _ = Describe("Test Envoy configuration creation", func() {
@@ -305,4 +308,142 @@ var _ = Describe("Test Envoy configuration creation", func() {
 			Expect(cfg.GetMeshCIDRRanges()).To(Equal(expectedMeshCIDRRanges))
 		})
 	})
+
+	Context("create OSM config for proxy update rate limiting", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("parses the durations and falls back to the defaults for invalid values", func() {
+			Expect(cfg.GetBroadcastDebounceWindow()).To(Equal(constants.DefaultBroadcastDebounceWindow))
+			Expect(cfg.GetProxyUpdateMinInterval()).To(Equal(constants.DefaultProxyUpdateMinInterval))
+
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					broadcastDebounceWindowKey: "250ms",
+					proxyUpdateMinIntervalKey:  "not-a-duration",
+				},
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetBroadcastDebounceWindow()).To(Equal(250 * time.Millisecond))
+			Expect(cfg.GetProxyUpdateMinInterval()).To(Equal(constants.DefaultProxyUpdateMinInterval))
+		})
+	})
+
+	Context("create OSM config for the IP family of the mesh", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("defaults to the detected IP family and falls back to it for invalid values", func() {
+			Expect(cfg.GetIPFamily()).To(Equal(IPv4))
+
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					ipFamilyKey: "ipv6",
+				},
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			<-cfg.GetAnnouncementsChannel()
+			Expect(cfg.GetIPFamily()).To(Equal(IPv6))
+
+			configMap.Data[ipFamilyKey] = "ipv5"
+			_, err = kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-cfg.GetAnnouncementsChannel()
+			Expect(cfg.GetIPFamily()).To(Equal(IPv4))
+		})
+
+		It("detects the IP family from the pod IPs", func() {
+			Expect(getIPFamilyFromIPs(nil)).To(Equal(IPv4))
+			Expect(getIPFamilyFromIPs([]string{"10.244.0.5"})).To(Equal(IPv4))
+			Expect(getIPFamilyFromIPs([]string{"fd00:10:244::5"})).To(Equal(IPv6))
+			Expect(getIPFamilyFromIPs([]string{"10.244.0.5", "fd00:10:244::5"})).To(Equal(DualStack))
+			Expect(getIPFamilyFromIPs([]string{"", "fd00:10:244::5"})).To(Equal(IPv6))
+		})
+	})
+
+	Context("create OSM config for the images of node architectures", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("returns the image overrides of the given architecture and skips invalid overrides", func() {
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					sidecarImageOverridesKey:       "amd64=envoy:amd64, arm64=envoy:arm64",
+					initContainerImageOverridesKey: "arm64, s390x=init:s390x",
+				},
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetSidecarImage("arm64")).To(Equal("envoy:arm64"))
+			Expect(cfg.GetSidecarImage("amd64")).To(Equal("envoy:amd64"))
+			Expect(cfg.GetSidecarImage("")).To(BeEmpty())
+			Expect(cfg.GetInitContainerImage("arm64")).To(BeEmpty())
+			Expect(cfg.GetInitContainerImage("s390x")).To(Equal("init:s390x"))
+		})
+	})
+
+	Context("create OSM config for the root certificates of remote clusters", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("returns the root certificates of the remote clusters and skips invalid ones", func() {
+			Expect(cfg.GetMulticlusterTrustedCAs()).To(BeEmpty())
+
+			westCA := "-----BEGIN CERTIFICATE-----\nd2VzdA==\n-----END CERTIFICATE-----\n"
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      multiclusterTrustedCAsConfigMapName,
+				},
+				Data: map[string]string{
+					"west":  westCA,
+					"north": "not a certificate",
+				},
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetMulticlusterTrustedCAs()).To(Equal(map[string][]byte{"west": []byte(westCA)}))
+		})
+	})
 })
@@ -1,6 +1,8 @@
 package configurator
 
 import (
+	"time"
+
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/openservicemesh/osm/pkg/logger"
@@ -18,8 +20,25 @@ type Client struct {
 	informer         cache.SharedIndexInformer
 	cache            cache.Store
 	cacheSynced      chan interface{}
+
+	// detectedIPFamily is the IP family of the controller pod, used when the ConfigMap does not set one
+	detectedIPFamily IPFamily
 }
 
+// IPFamily is the IP family of the addresses assigned to the pods in the mesh
+type IPFamily string
+
+const (
+	// IPv4 is the IP family of clusters assigning a single IPv4 address to each pod
+	IPv4 IPFamily = "ipv4"
+
+	// IPv6 is the IP family of clusters assigning a single IPv6 address to each pod
+	IPv6 IPFamily = "ipv6"
+
+	// DualStack is the IP family of clusters assigning both an IPv4 and an IPv6 address to each pod
+	DualStack IPFamily = "dual-stack"
+)
+
 // Configurator is the controller interface for K8s namespaces
 type Configurator interface {
 	// GetOSMNamespace returns the namespace in which OSM controller pod resides
@@ -55,6 +74,39 @@ type Configurator interface {
 	// UseHTTPSIngress determines whether protocol used for traffic from ingress to backend pods should be HTTPS.
 	UseHTTPSIngress() bool
 
+	// UseMTLSIngress determines whether ingress must authenticate to backend pods with a client certificate.
+	UseMTLSIngress() bool
+
+	// GetIngressClientCertService returns the namespaced service whose identity is issued to the ingress controller
+	GetIngressClientCertService() string
+
+	// GetIngressClientCertSecret returns the namespaced name of the secret the ingress client certificate is stored in
+	GetIngressClientCertSecret() string
+
+	// GetClusterName returns the name of the cluster the mesh runs in, empty if the mesh is not part of a multicluster setup
+	GetClusterName() string
+
+	// GetMulticlusterTrustedCAs returns the PEM encoded root certificates of the remote clusters, keyed by remote cluster name
+	GetMulticlusterTrustedCAs() map[string][]byte
+
+	// IsDNSProxyEnabled determines whether DNS queries of applications are answered by their sidecar
+	IsDNSProxyEnabled() bool
+
+	// GetBroadcastDebounceWindow returns how long announcements are coalesced for before proxies are updated
+	GetBroadcastDebounceWindow() time.Duration
+
+	// GetProxyUpdateMinInterval returns the minimum interval between two updates pushed to a proxy
+	GetProxyUpdateMinInterval() time.Duration
+
+	// GetIPFamily returns the IP family of the pods in the mesh, which determines the addresses proxies listen on
+	GetIPFamily() IPFamily
+
+	// GetSidecarImage returns the sidecar image of pods scheduled on nodes of the given architecture, empty when not overridden
+	GetSidecarImage(arch string) string
+
+	// GetInitContainerImage returns the init container image of pods scheduled on nodes of the given architecture, empty when not overridden
+	GetInitContainerImage(arch string) string
+
 	// GetAnnouncementsChannel returns a channel, which is used to announce when changes have been made to the OSM ConfigMap
 	GetAnnouncementsChannel() <-chan interface{}
 }
@@ -0,0 +1,133 @@
+package configurator
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateConfigMap returns the reasons the given data of the OSM ConfigMap is invalid, sorted by key.
+// The controller ignores invalid values, so they are rejected before reaching it.
+func ValidateConfigMap(data map[string]string) []string {
+	var reasons []string
+	addReason := func(key, format string, args ...interface{}) {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	knownKeys := getConfigMapKeys()
+	for key, value := range data {
+		if _, ok := knownKeys[key]; !ok {
+			addReason(key, "unknown key")
+			continue
+		}
+		if err := validateConfigMapValue(key, value); err != nil {
+			addReason(key, "%s, got %q", err, value)
+		}
+	}
+
+	isEnabled := func(key string) bool {
+		enabled, err := strconv.ParseBool(data[key])
+		return err == nil && enabled
+	}
+
+	if isEnabled(egressKey) && strings.TrimSpace(data[meshCIDRRangesKey]) == "" {
+		addReason(meshCIDRRangesKey, "must be set when %s is true", egressKey)
+	}
+	if isEnabled(zipkinTracingKey) {
+		for _, key := range []string{zipkinAddressKey, zipkinEndpointKey} {
+			if data[key] == "" {
+				addReason(key, "must be set when %s is true", zipkinTracingKey)
+			}
+		}
+	}
+	if (data[ingressClientCertServiceKey] == "") != (data[ingressClientCertSecretKey] == "") {
+		addReason(ingressClientCertSecretKey, "%s and %s must be set together", ingressClientCertServiceKey, ingressClientCertSecretKey)
+	}
+
+	sort.Strings(reasons)
+	return reasons
+}
+
+// validateConfigMapValue returns why the value of the given known key is invalid, nil when it is valid.
+func validateConfigMapValue(key, value string) error {
+	switch key {
+	case permissiveTrafficPolicyModeKey, egressKey, prometheusScrapingKey, useHTTPSIngressKey, useMTLSIngressKey, dnsProxyKey, zipkinTracingKey:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return errors.New(`must be "true" or "false"`)
+		}
+
+	case zipkinPortKey:
+		if port, err := strconv.Atoi(value); value != "" && (err != nil || port < 1 || port > 65535) {
+			return errors.New("must be a port number between 1 and 65535")
+		}
+
+	case zipkinEndpointKey:
+		if value != "" && !strings.HasPrefix(value, "/") {
+			return errors.New("must be a path starting with /")
+		}
+
+	case broadcastDebounceWindowKey, proxyUpdateMinIntervalKey:
+		if duration, err := time.ParseDuration(value); value != "" && (err != nil || duration < 0) {
+			return errors.New("must be a non-negative duration such as 500ms or 3s")
+		}
+
+	case ipFamilyKey:
+		switch IPFamily(value) {
+		case "", IPv4, IPv6, DualStack:
+		default:
+			return errors.Errorf("must be one of %s, %s or %s, or empty to detect it", IPv4, IPv6, DualStack)
+		}
+
+	case meshCIDRRangesKey:
+		for _, cidr := range strings.Fields(strings.ReplaceAll(value, ",", " ")) {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.Errorf("must be comma or space separated CIDR ranges, %q is not a CIDR range", cidr)
+			}
+		}
+
+	case ingressClientCertServiceKey, ingressClientCertSecretKey:
+		if parts := strings.Split(value, "/"); value != "" && (len(parts) != 2 || parts[0] == "" || parts[1] == "") {
+			return errors.New("must be of the form <namespace>/<name>")
+		}
+
+	case clusterNameKey:
+		if messages := validation.IsDNS1123Label(value); value != "" && len(messages) > 0 {
+			return errors.Errorf("must be a DNS label, as it is used in the names of mirrored services: %s", strings.Join(messages, ", "))
+		}
+
+	case sidecarImageOverridesKey, initContainerImageOverridesKey:
+		archs := make(map[string]struct{})
+		for _, override := range strings.Split(value, ",") {
+			override = strings.TrimSpace(override)
+			if override == "" {
+				continue
+			}
+			archImage := strings.SplitN(override, "=", 2)
+			if len(archImage) != 2 || archImage[0] == "" || archImage[1] == "" {
+				return errors.Errorf("must be comma-separated <arch>=<image>, %q is not", override)
+			}
+			if _, ok := archs[archImage[0]]; ok {
+				return errors.Errorf("must override the image of architecture %s once", archImage[0])
+			}
+			archs[archImage[0]] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// getConfigMapKeys returns the keys of the OSM ConfigMap, which are the YAML tags of osmConfig
+func getConfigMapKeys() map[string]struct{} {
+	keys := make(map[string]struct{})
+	configType := reflect.TypeOf(osmConfig{})
+	for i := 0; i < configType.NumField(); i++ {
+		keys[configType.Field(i).Tag.Get("yaml")] = struct{}{}
+	}
+	return keys
+}
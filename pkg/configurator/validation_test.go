@@ -0,0 +1,74 @@
+package configurator
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test OSM ConfigMap validation", func() {
+	// The data of the ConfigMap installed by the chart with its default values
+	newChartConfigMap := func() map[string]string {
+		return map[string]string{
+			permissiveTrafficPolicyModeKey: "false",
+			egressKey:                      "false",
+			prometheusScrapingKey:          "true",
+			zipkinTracingKey:               "true",
+			zipkinAddressKey:               "zipkin.osm-system.svc.cluster.local",
+			zipkinPortKey:                  "9411",
+			zipkinEndpointKey:              "/api/v2/spans",
+			useHTTPSIngressKey:             "false",
+			useMTLSIngressKey:              "false",
+			ingressClientCertServiceKey:    "",
+			ingressClientCertSecretKey:     "",
+			clusterNameKey:                 "",
+			dnsProxyKey:                    "false",
+			broadcastDebounceWindowKey:     "1s",
+			proxyUpdateMinIntervalKey:      "3s",
+			ipFamilyKey:                    "",
+			sidecarImageOverridesKey:       "",
+			initContainerImageOverridesKey: "",
+		}
+	}
+
+	Context("Test ValidateConfigMap()", func() {
+		It("accepts the ConfigMap installed by the chart", func() {
+			Expect(ValidateConfigMap(newChartConfigMap())).To(BeEmpty())
+		})
+
+		It("rejects invalid values with the key and the value", func() {
+			data := newChartConfigMap()
+			data[egressKey] = "yes"
+			data[zipkinPortKey] = "94110"
+			data[broadcastDebounceWindowKey] = "-1s"
+			data[ipFamilyKey] = "ipv5"
+			data[sidecarImageOverridesKey] = "arm64=envoy:arm64,arm64=envoy"
+
+			Expect(ValidateConfigMap(data)).To(Equal([]string{
+				`broadcast_debounce_window: must be a non-negative duration such as 500ms or 3s, got "-1s"`,
+				`egress: must be "true" or "false", got "yes"`,
+				`ip_family: must be one of ipv4, ipv6 or dual-stack, or empty to detect it, got "ipv5"`,
+				`sidecar_image_overrides: must override the image of architecture arm64 once, got "arm64=envoy:arm64,arm64=envoy"`,
+				`zipkin_port: must be a port number between 1 and 65535, got "94110"`,
+			}))
+		})
+
+		It("rejects unknown keys", func() {
+			data := newChartConfigMap()
+			data["zipkin_tracing_enabled"] = "true"
+			Expect(ValidateConfigMap(data)).To(Equal([]string{"zipkin_tracing_enabled: unknown key"}))
+		})
+
+		It("rejects inconsistent values", func() {
+			data := newChartConfigMap()
+			data[egressKey] = "true"
+			data[zipkinEndpointKey] = ""
+			data[ingressClientCertServiceKey] = "ingress/nginx"
+
+			Expect(ValidateConfigMap(data)).To(Equal([]string{
+				"ingress_client_cert_secret: ingress_client_cert_service and ingress_client_cert_secret must be set together",
+				"mesh_cidr_ranges: must be set when egress is true",
+				"zipkin_endpoint: must be set when zipkin_tracing is true",
+			}))
+		})
+	})
+})
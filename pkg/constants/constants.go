@@ -15,6 +15,9 @@ const (
 	// WildcardIPAddr is a string constant.
 	WildcardIPAddr = "0.0.0.0"
 
+	// WildcardIPv6Addr is the IPv6 address matching any local address.
+	WildcardIPv6Addr = "::"
+
 	// EnvoyAdminPort is Envoy's admin port
 	EnvoyAdminPort = 15000
 
@@ -36,12 +39,18 @@ const (
 	// EnvoyOutboundListenerPortName is Envoy's outbound listener port name.
 	EnvoyOutboundListenerPortName = "proxy-outbound"
 
+	// EnvoyDNSListenerPort is the port of Envoy's UDP listener DNS queries of the application are redirected to.
+	EnvoyDNSListenerPort = 15053
+
 	// EnvoyUID is the Envoy's User ID
 	EnvoyUID int64 = 1337
 
 	// LocalhostIPAddress is the local host address.
 	LocalhostIPAddress = "127.0.0.1"
 
+	// LocalhostIPv6Address is the IPv6 local host address.
+	LocalhostIPv6Address = "::1"
+
 	// EnvoyMetricsCluster is the cluster name of the Prometheus metrics cluster
 	EnvoyMetricsCluster = "envoy-metrics-cluster"
 
@@ -57,6 +66,12 @@ const (
 	// DefaultZipkinPort is the Zipkin port number.
 	DefaultZipkinPort = uint32(9411)
 
+	// DefaultBroadcastDebounceWindow is how long announcements are coalesced before proxies are updated.
+	DefaultBroadcastDebounceWindow = 1 * time.Second
+
+	// DefaultProxyUpdateMinInterval is the minimum interval between two updates pushed to a proxy.
+	DefaultProxyUpdateMinInterval = 3 * time.Second
+
 	// EnvoyPrometheusInboundListenerPort is Envoy's inbound listener port number for prometheus
 	EnvoyPrometheusInboundListenerPort = 15010
 
@@ -72,6 +87,9 @@ const (
 	// OSMControllerPort is the port on which XDS listens for new connections.
 	OSMControllerPort = 15128
 
+	// MulticlusterGatewayPort is the port on which the multicluster gateway accepts traffic from other meshes.
+	MulticlusterGatewayPort = 15443
+
 	// PrometheusScrapePath is the path for prometheus to scrap envoy metrics from
 	PrometheusScrapePath = "/stats/prometheus"
 
@@ -116,6 +134,9 @@ const (
 	// EnvVarHumanReadableLogMessages is an environment variable, which when set to "true" enables colorful human-readable log messages.
 	EnvVarHumanReadableLogMessages = "OSM_HUMAN_DEBUG_LOG"
 
+	// EnvVarPodName is the name of the env var holding the name of the pod osm-controller runs in
+	EnvVarPodName = "POD_NAME"
+
 	// ClusterWeightAcceptAll is the weight for a cluster that accepts 100 percent of traffic sent to it
 	ClusterWeightAcceptAll = 100
 
@@ -0,0 +1,39 @@
+package debugger
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+)
+
+const (
+	// mutexProfileFraction is the rate at which mutex contention events are sampled: 1 in mutexProfileFraction
+	mutexProfileFraction = 5
+
+	// blockProfileRate is the rate at which blocking events are sampled: 1 per blockProfileRate nanoseconds spent blocked
+	blockProfileRate = int(1e6)
+)
+
+// getProfilingHandlers returns the pprof and runtime trace handlers.
+func getProfilingHandlers() map[string]http.Handler {
+	return map[string]http.Handler{
+		"/debug/pprof/":             http.HandlerFunc(pprof.Index),
+		"/debug/pprof/cmdline":      http.HandlerFunc(pprof.Cmdline),
+		"/debug/pprof/profile":      http.HandlerFunc(pprof.Profile),
+		"/debug/pprof/symbol":       http.HandlerFunc(pprof.Symbol),
+		"/debug/pprof/trace":        http.HandlerFunc(pprof.Trace),
+		"/debug/pprof/allocs":       pprof.Handler("allocs"),
+		"/debug/pprof/block":        pprof.Handler("block"),
+		"/debug/pprof/goroutine":    pprof.Handler("goroutine"),
+		"/debug/pprof/heap":         pprof.Handler("heap"),
+		"/debug/pprof/mutex":        pprof.Handler("mutex"),
+		"/debug/pprof/threadcreate": pprof.Handler("threadcreate"),
+	}
+}
+
+// enableRuntimeProfiling turns on the sampling of mutex contention and blocking events,
+// which the runtime does not collect by default.
+func enableRuntimeProfiling() {
+	runtime.SetMutexProfileFraction(mutexProfileFraction)
+	runtime.SetBlockProfileRate(blockProfileRate)
+}
@@ -3,10 +3,10 @@ package debugger
 import (
 	"net/http"
 
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/kubernetes"
 )
 
 // GetHandlers implements DebugServer interface and returns the rest of URLs and the handling functions.
@@ -20,6 +20,12 @@ func (ds debugServer) GetHandlers() map[string]http.Handler {
 		"/debug/namespaces": ds.getMonitoredNamespacesHandler(),
 	}
 
+	if ds.enableProfiling {
+		for url, handler := range getProfilingHandlers() {
+			handlers[url] = handler
+		}
+	}
+
 	// provides an index of the available /debug endpoints
 	handlers["/debug"] = ds.getDebugIndex(handlers)
 
@@ -27,16 +33,23 @@ func (ds debugServer) GetHandlers() map[string]http.Handler {
 }
 
 // NewDebugServer returns an implementation of DebugServer interface.
-func NewDebugServer(certDebugger CertificateManagerDebugger, xdsDebugger XDSDebugger, meshCatalogDebugger MeshCatalogDebugger, kubeConfig *rest.Config, kubeClient kubernetes.Interface, cfg configurator.Configurator) DebugServer {
+// With enableProfiling, the debug server also serves the pprof and runtime trace endpoints under /debug/pprof/.
+func NewDebugServer(certDebugger CertificateManagerDebugger, xdsDebugger XDSDebugger, meshCatalogDebugger MeshCatalogDebugger, kubeConfig *rest.Config, kubeController kubernetes.Controller, cfg configurator.Configurator, enableProfiling bool) DebugServer {
+	if enableProfiling {
+		enableRuntimeProfiling()
+	}
+
 	return debugServer{
 		certDebugger:        certDebugger,
 		xdsDebugger:         xdsDebugger,
 		meshCatalogDebugger: meshCatalogDebugger,
-		kubeClient:          kubeClient,
+		kubeController:      kubeController,
 
 		// We need the Kubernetes config to be able to establish port forwarding to the Envoy pod we want to debug.
 		kubeConfig: kubeConfig,
 
 		configurator: cfg,
+
+		enableProfiling: enableProfiling,
 	}
 }
@@ -0,0 +1,35 @@
+package debugger
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test debug server handlers", func() {
+	Context("Testing GetHandlers()", func() {
+		It("does not serve the profiling endpoints by default", func() {
+			handlers := debugServer{}.GetHandlers()
+			Expect(handlers).To(HaveKey("/debug/config"))
+			Expect(handlers).ToNot(HaveKey("/debug/pprof/"))
+			Expect(handlers).ToNot(HaveKey("/debug/pprof/trace"))
+		})
+
+		It("serves the profiling endpoints with profiling enabled", func() {
+			handlers := debugServer{enableProfiling: true}.GetHandlers()
+			for _, url := range []string{"/debug/pprof/", "/debug/pprof/profile", "/debug/pprof/trace", "/debug/pprof/heap", "/debug/pprof/goroutine", "/debug/pprof/mutex"} {
+				Expect(handlers).To(HaveKey(url))
+			}
+		})
+
+		It("serves the goroutine profile", func() {
+			handler := debugServer{enableProfiling: true}.GetHandlers()["/debug/pprof/goroutine"]
+			responseRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/debug/pprof/goroutine?debug=1", nil))
+			Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+			Expect(responseRecorder.Body.String()).To(ContainSubstring("goroutine profile"))
+		})
+	})
+})
@@ -8,12 +8,12 @@ import (
 	spec "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha3"
 	split "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/service"
 )
@@ -26,8 +26,9 @@ type debugServer struct {
 	xdsDebugger         XDSDebugger
 	meshCatalogDebugger MeshCatalogDebugger
 	kubeConfig          *rest.Config
-	kubeClient          kubernetes.Interface
+	kubeController      kubernetes.Controller
 	configurator        configurator.Configurator
+	enableProfiling     bool
 }
 
 // CertificateManagerDebugger is an interface with methods for debugging certificate issuance.
@@ -22,10 +22,10 @@ const (
 )
 
 // NewClient creates the Kubernetes client, which retrieves the AzureResource CRD and Services resources.
-func NewClient(kubeClient kubernetes.Interface, azureResourceKubeConfig *rest.Config, namespaceController namespace.Controller, stop chan struct{}, cfg configurator.Configurator) (*Client, error) {
+func NewClient(kubeClient kubernetes.Interface, azureResourceKubeConfig *rest.Config, namespaceController namespace.Controller, watchedNamespaces []string, stop chan struct{}, cfg configurator.Configurator) (*Client, error) {
 	azureResourceClient := osmClient.NewForConfigOrDie(azureResourceKubeConfig)
 
-	k8sClient := newClient(kubeClient, azureResourceClient, namespaceController)
+	k8sClient := newClient(kubeClient, azureResourceClient, namespaceController, watchedNamespaces)
 	if err := k8sClient.Run(stop); err != nil {
 		return nil, errors.Errorf("Failed to start %s client: %+v", kubernetesClientName, err)
 	}
@@ -33,10 +33,12 @@ func NewClient(kubeClient kubernetes.Interface, azureResourceKubeConfig *rest.Co
 }
 
 // newClient creates a provider based on a Kubernetes client instance.
-func newClient(kubeClient kubernetes.Interface, azureResourceClient *osmClient.Clientset, namespaceController namespace.Controller) *Client {
-	azureResourceFactory := osmInformers.NewSharedInformerFactory(azureResourceClient, k8s.DefaultKubeEventResyncInterval)
+// When watchedNamespaces is not empty, only the AzureResources of these namespaces are watched.
+func newClient(kubeClient kubernetes.Interface, azureResourceClient *osmClient.Clientset, namespaceController namespace.Controller, watchedNamespaces []string) *Client {
 	informerCollection := InformerCollection{
-		AzureResource: azureResourceFactory.Osm().V1().AzureResources().Informer(),
+		AzureResource: k8s.NewInformer(watchedNamespaces, func(ns string) cache.SharedIndexInformer {
+			return osmInformers.NewSharedInformerFactoryWithOptions(azureResourceClient, k8s.DefaultKubeEventResyncInterval, osmInformers.WithNamespace(ns)).Osm().V1().AzureResources().Informer()
+		}),
 	}
 
 	cacheCollection := CacheCollection{
@@ -23,18 +23,47 @@ import (
 
 const namespaceSelectorLabel = "app"
 
+// serviceAccountIndex is the name of the index of deployments by the namespaced service account of their pods
+const serviceAccountIndex = "serviceAccount"
+
 // NewProvider implements mesh.EndpointsProvider, which creates a new Kubernetes cluster/compute provider.
-func NewProvider(kubeClient kubernetes.Interface, namespaceController namespace.Controller, stop chan struct{}, providerIdent string, cfg configurator.Configurator) (*Client, error) {
-	informerFactory := informers.NewSharedInformerFactory(kubeClient, k8s.DefaultKubeEventResyncInterval)
+// When watchedNamespaces is not empty, only the resources of these namespaces are watched.
+func NewProvider(kubeClient kubernetes.Interface, namespaceController namespace.Controller, watchedNamespaces []string, stop chan struct{}, providerIdent string, cfg configurator.Configurator) (*Client, error) {
+	newInformerFactory := func(ns string) informers.SharedInformerFactory {
+		return informers.NewSharedInformerFactoryWithOptions(kubeClient, k8s.DefaultKubeEventResyncInterval, informers.WithNamespace(ns))
+	}
 
 	informerCollection := InformerCollection{
-		Endpoints:   informerFactory.Core().V1().Endpoints().Informer(),
-		Deployments: informerFactory.Apps().V1().Deployments().Informer(),
+		Deployments: k8s.NewInformer(watchedNamespaces, func(ns string) cache.SharedIndexInformer {
+			return newInformerFactory(ns).Apps().V1().Deployments().Informer()
+		}),
+	}
+
+	if err := informerCollection.Deployments.AddIndexers(cache.Indexers{serviceAccountIndex: serviceAccountIndexFunc}); err != nil {
+		return nil, errors.Errorf("Failed to index Deployments by service account: %+v", err)
 	}
 
 	cacheCollection := CacheCollection{
-		Endpoints:   informerCollection.Endpoints.GetStore(),
-		Deployments: informerCollection.Deployments.GetStore(),
+		Deployments: informerCollection.Deployments.GetIndexer(),
+	}
+
+	// EndpointSlices split the endpoints of large services across objects, which are smaller to watch and update.
+	// Clusters without the EndpointSlice API are watched for Endpoints instead.
+	if isEndpointSliceSupported(kubeClient.Discovery()) {
+		log.Info().Msgf("[%s] Discovering endpoints with the EndpointSlice API", providerIdent)
+		informerCollection.EndpointSlices = k8s.NewInformer(watchedNamespaces, func(ns string) cache.SharedIndexInformer {
+			return newInformerFactory(ns).Discovery().V1beta1().EndpointSlices().Informer()
+		})
+		if err := informerCollection.EndpointSlices.AddIndexers(cache.Indexers{serviceIndex: serviceIndexFunc}); err != nil {
+			return nil, errors.Errorf("Failed to index EndpointSlices by service: %+v", err)
+		}
+		cacheCollection.EndpointSlices = informerCollection.EndpointSlices.GetIndexer()
+	} else {
+		log.Info().Msgf("[%s] Discovering endpoints with the Endpoints API", providerIdent)
+		informerCollection.Endpoints = k8s.NewInformer(watchedNamespaces, func(ns string) cache.SharedIndexInformer {
+			return newInformerFactory(ns).Core().V1().Endpoints().Informer()
+		})
+		cacheCollection.Endpoints = informerCollection.Endpoints.GetStore()
 	}
 
 	client := Client{
@@ -51,7 +80,11 @@ func NewProvider(kubeClient kubernetes.Interface, namespaceController namespace.
 		ns := reflect.ValueOf(obj).Elem().FieldByName("ObjectMeta").FieldByName("Namespace").String()
 		return namespaceController.IsMonitoredNamespace(ns)
 	}
-	informerCollection.Endpoints.AddEventHandler(k8s.GetKubernetesEventHandlers("Endpoints", "Kubernetes", client.announcements, shouldObserve))
+	if informerCollection.EndpointSlices != nil {
+		informerCollection.EndpointSlices.AddEventHandler(k8s.GetKubernetesEventHandlers("EndpointSlices", "Kubernetes", client.announcements, shouldObserve))
+	} else {
+		informerCollection.Endpoints.AddEventHandler(k8s.GetKubernetesEventHandlers("Endpoints", "Kubernetes", client.announcements, shouldObserve))
+	}
 	informerCollection.Deployments.AddEventHandler(k8s.GetKubernetesEventHandlers("Deployments", "Kubernetes", client.announcements, shouldObserve))
 
 	if err := client.run(stop); err != nil {
@@ -70,6 +103,10 @@ func (c *Client) GetID() string {
 // ListEndpointsForService retrieves the list of IP addresses for the given service
 func (c Client) ListEndpointsForService(svc service.MeshService) []endpoint.Endpoint {
 	log.Info().Msgf("[%s] Getting Endpoints for service %s on Kubernetes", c.providerIdent, svc)
+	if c.caches.EndpointSlices != nil {
+		return c.listEndpointsFromSlices(svc)
+	}
+
 	var endpoints []endpoint.Endpoint
 	endpointsInterface, exist, err := c.caches.Endpoints.GetByKey(svc.String())
 	if err != nil {
@@ -117,7 +154,11 @@ func (c Client) ListEndpointsForService(svc service.MeshService) []endpoint.Endp
 func (c Client) GetServiceForServiceAccount(svcAccount service.K8sServiceAccount) (service.MeshService, error) {
 	log.Info().Msgf("[%s] Getting Services for service account %s on Kubernetes", c.providerIdent, svcAccount)
 	services := mapset.NewSet()
-	deploymentsInterface := c.caches.Deployments.List()
+	deploymentsInterface, err := c.caches.Deployments.ByIndex(serviceAccountIndex, svcAccount.String())
+	if err != nil {
+		log.Error().Err(err).Msgf("[%s] Error fetching Kubernetes Deployments for service account %s from cache", c.providerIdent, svcAccount)
+		return service.MeshService{}, err
+	}
 
 	for _, deployments := range deploymentsInterface {
 		kubernetesDeployments, ok := deployments.(*appsv1.Deployment)
@@ -176,6 +217,19 @@ func (c Client) GetAnnouncementsChannel() <-chan interface{} {
 	return c.announcements
 }
 
+// serviceAccountIndexFunc indexes a deployment by the namespaced service account its pods run as
+func serviceAccountIndexFunc(obj interface{}) ([]string, error) {
+	deployment, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		return nil, nil
+	}
+	svcAccount := service.K8sServiceAccount{
+		Namespace: deployment.Namespace,
+		Name:      deployment.Spec.Template.Spec.ServiceAccountName,
+	}
+	return []string{svcAccount.String()}, nil
+}
+
 func (c *Client) run(stop <-chan struct{}) error {
 	var hasSynced []cache.InformerSynced
 
@@ -184,8 +238,9 @@ func (c *Client) run(stop <-chan struct{}) error {
 	}
 
 	sharedInformers := map[string]cache.SharedInformer{
-		"Endpoints":   c.informers.Endpoints,
-		"Deployments": c.informers.Deployments,
+		"Endpoints":      c.informers.Endpoints,
+		"EndpointSlices": c.informers.EndpointSlices,
+		"Deployments":    c.informers.Deployments,
 	}
 
 	var names []string
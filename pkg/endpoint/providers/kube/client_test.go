@@ -5,6 +5,9 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -32,4 +35,25 @@ var _ = Describe("Test Kubernetes Provider", func() {
 			Expect(actual).To(Equal(expected))
 		})
 	})
+
+	Context("Testing serviceAccountIndexFunc", func() {
+		It("indexes a deployment by the namespaced service account of its pods", func() {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tests.BookstoreServiceName,
+					Namespace: tests.Namespace,
+				},
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							ServiceAccountName: tests.BookstoreServiceAccountName,
+						},
+					},
+				},
+			}
+			keys, err := serviceAccountIndexFunc(deployment)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(Equal([]string{tests.BookstoreServiceAccount.String()}))
+		})
+	})
 })
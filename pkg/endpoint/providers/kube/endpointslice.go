@@ -0,0 +1,126 @@
+package kube
+
+import (
+	"net"
+
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/discovery"
+
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// serviceIndex is the name of the index of endpoint slices by the namespaced name of their service
+	serviceIndex = "service"
+
+	endpointSliceResource = "endpointslices"
+)
+
+// minEndpointSliceVersion is the first Kubernetes version mirroring the Endpoints of services without a selector,
+// such as the services mirrored from remote clusters, to EndpointSlices
+var minEndpointSliceVersion = version.MustParseGeneric("v1.19.0")
+
+// isEndpointSliceSupported returns true if the endpoints of all services can be discovered with the EndpointSlice API
+func isEndpointSliceSupported(discoveryClient discovery.DiscoveryInterface) bool {
+	serverVersion, err := discoveryClient.ServerVersion()
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting Kubernetes server version")
+		return false
+	}
+	v, err := version.ParseGeneric(serverVersion.GitVersion)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error parsing Kubernetes server version %s", serverVersion.GitVersion)
+		return false
+	}
+	if !v.AtLeast(minEndpointSliceVersion) {
+		return false
+	}
+
+	resources, err := discoveryClient.ServerResourcesForGroupVersion(discoveryv1beta1.SchemeGroupVersion.String())
+	if err != nil {
+		log.Debug().Err(err).Msgf("API %s is not served", discoveryv1beta1.SchemeGroupVersion)
+		return false
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == endpointSliceResource {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceIndexFunc indexes an endpoint slice by the namespaced name of its service
+func serviceIndexFunc(obj interface{}) ([]string, error) {
+	endpointSlice, ok := obj.(*discoveryv1beta1.EndpointSlice)
+	if !ok {
+		return nil, nil
+	}
+	name, ok := endpointSlice.Labels[discoveryv1beta1.LabelServiceName]
+	if !ok {
+		return nil, nil
+	}
+	svc := service.MeshService{
+		Namespace: endpointSlice.Namespace,
+		Name:      name,
+	}
+	return []string{svc.String()}, nil
+}
+
+// listEndpointsFromSlices returns the endpoints of the given service from the endpoint slices cache
+func (c Client) listEndpointsFromSlices(svc service.MeshService) []endpoint.Endpoint {
+	var endpoints []endpoint.Endpoint
+	if !c.namespaceController.IsMonitoredNamespace(svc.Namespace) {
+		// Doesn't belong to namespaces we are observing
+		return endpoints
+	}
+
+	endpointSlices, err := c.caches.EndpointSlices.ByIndex(serviceIndex, svc.String())
+	if err != nil {
+		log.Error().Err(err).Msgf("[%s] Error fetching Kubernetes EndpointSlices from cache", c.providerIdent)
+		return endpoints
+	}
+
+	for _, obj := range endpointSlices {
+		endpointSlice, ok := obj.(*discoveryv1beta1.EndpointSlice)
+		if !ok {
+			log.Error().Err(errInvalidObjectType).Msg("Failed type assertion for EndpointSlice in cache")
+			continue
+		}
+		endpoints = append(endpoints, getSliceEndpoints(endpointSlice)...)
+	}
+	return endpoints
+}
+
+// getSliceEndpoints returns the endpoints of the ready addresses of the given endpoint slice
+func getSliceEndpoints(endpointSlice *discoveryv1beta1.EndpointSlice) []endpoint.Endpoint {
+	var endpoints []endpoint.Endpoint
+	if endpointSlice.AddressType == discoveryv1beta1.AddressTypeFQDN {
+		return endpoints
+	}
+
+	for _, sliceEndpoint := range endpointSlice.Endpoints {
+		// An unknown readiness is interpreted as ready
+		if sliceEndpoint.Conditions.Ready != nil && !*sliceEndpoint.Conditions.Ready {
+			continue
+		}
+		for _, address := range sliceEndpoint.Addresses {
+			ip := net.ParseIP(address)
+			if ip == nil {
+				log.Error().Msgf("Error parsing IP address %s", address)
+				continue
+			}
+			for _, port := range endpointSlice.Ports {
+				if port.Port == nil {
+					continue
+				}
+				endpoints = append(endpoints, endpoint.Endpoint{
+					IP:   ip,
+					Port: endpoint.Port(*port.Port),
+				})
+			}
+		}
+	}
+	return endpoints
+}
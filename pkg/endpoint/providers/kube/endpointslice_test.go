@@ -0,0 +1,113 @@
+package kube
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/namespace"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func newEndpointSlice(name string, ready *bool, addresses ...string) *discoveryv1beta1.EndpointSlice {
+	port := int32(8888)
+	return &discoveryv1beta1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: tests.Namespace,
+			Labels:    map[string]string{discoveryv1beta1.LabelServiceName: tests.BookstoreServiceName},
+		},
+		AddressType: discoveryv1beta1.AddressTypeIPv4,
+		Endpoints: []discoveryv1beta1.Endpoint{{
+			Addresses:  addresses,
+			Conditions: discoveryv1beta1.EndpointConditions{Ready: ready},
+		}},
+		Ports: []discoveryv1beta1.EndpointPort{{Port: &port}},
+	}
+}
+
+var _ = Describe("Test Kubernetes Provider with EndpointSlices", func() {
+	ready := true
+	notReady := false
+
+	Context("Testing isEndpointSliceSupported", func() {
+		newDiscovery := func(gitVersion string, served bool) *fakediscovery.FakeDiscovery {
+			discoveryClient := fake.NewSimpleClientset().Discovery().(*fakediscovery.FakeDiscovery)
+			discoveryClient.FakedServerVersion = &version.Info{GitVersion: gitVersion}
+			if served {
+				discoveryClient.Resources = []*metav1.APIResourceList{{
+					GroupVersion: discoveryv1beta1.SchemeGroupVersion.String(),
+					APIResources: []metav1.APIResource{{Name: endpointSliceResource}},
+				}}
+			}
+			return discoveryClient
+		}
+
+		It("uses EndpointSlices when the API is served by a recent cluster", func() {
+			Expect(isEndpointSliceSupported(newDiscovery("v1.19.2", true))).To(BeTrue())
+		})
+
+		It("falls back to Endpoints when the API is not served", func() {
+			Expect(isEndpointSliceSupported(newDiscovery("v1.19.2", false))).To(BeFalse())
+		})
+
+		It("falls back to Endpoints on clusters not mirroring Endpoints to EndpointSlices", func() {
+			Expect(isEndpointSliceSupported(newDiscovery("v1.18.8", true))).To(BeFalse())
+		})
+	})
+
+	Context("Testing serviceIndexFunc", func() {
+		It("indexes an endpoint slice by the namespaced name of its service", func() {
+			keys, err := serviceIndexFunc(newEndpointSlice("bookstore-abc", nil))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(Equal([]string{tests.BookstoreService.String()}))
+		})
+
+		It("does not index an endpoint slice without service", func() {
+			endpointSlice := newEndpointSlice("bookstore-abc", nil)
+			endpointSlice.Labels = nil
+			keys, err := serviceIndexFunc(endpointSlice)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(BeEmpty())
+		})
+	})
+
+	Context("Testing ListEndpointsForService", func() {
+		var indexer cache.Indexer
+
+		BeforeEach(func() {
+			indexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{serviceIndex: serviceIndexFunc})
+			Expect(indexer.Add(newEndpointSlice("bookstore-abc", &ready, "10.0.0.1"))).To(Succeed())
+			Expect(indexer.Add(newEndpointSlice("bookstore-def", nil, "10.0.0.2"))).To(Succeed())
+			Expect(indexer.Add(newEndpointSlice("bookstore-ghi", &notReady, "10.0.0.3"))).To(Succeed())
+		})
+
+		It("returns the ready endpoints of all the endpoint slices of the service", func() {
+			c := Client{
+				caches:              &CacheCollection{EndpointSlices: indexer},
+				namespaceController: namespace.NewFakeNamespaceController([]string{tests.Namespace}),
+			}
+			actual := c.ListEndpointsForService(tests.BookstoreService)
+			Expect(actual).To(ConsistOf(
+				endpoint.Endpoint{IP: net.ParseIP("10.0.0.1"), Port: 8888},
+				endpoint.Endpoint{IP: net.ParseIP("10.0.0.2"), Port: 8888},
+			))
+		})
+
+		It("returns no endpoints for a service in a namespace which is not monitored", func() {
+			c := Client{
+				caches:              &CacheCollection{EndpointSlices: indexer},
+				namespaceController: namespace.NewFakeNamespaceController(nil),
+			}
+			Expect(c.ListEndpointsForService(tests.BookstoreService)).To(BeEmpty())
+		})
+	})
+})
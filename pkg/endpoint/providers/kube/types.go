@@ -14,14 +14,17 @@ var (
 
 // InformerCollection is a struct of the Kubernetes informers used in OSM
 type InformerCollection struct {
-	Endpoints   cache.SharedIndexInformer
-	Deployments cache.SharedIndexInformer
+	Endpoints      cache.SharedIndexInformer
+	EndpointSlices cache.SharedIndexInformer
+	Deployments    cache.SharedIndexInformer
 }
 
 // CacheCollection is a struct of the Kubernetes caches used in OSM
+// Either Endpoints or EndpointSlices is set, depending on the APIs of the cluster.
 type CacheCollection struct {
-	Endpoints   cache.Store
-	Deployments cache.Store
+	Endpoints      cache.Store
+	EndpointSlices cache.Indexer
+	Deployments    cache.Indexer
 }
 
 // Client is a struct for all components necessary to connect to and maintain state of a Kubernetes cluster.
@@ -0,0 +1,141 @@
+package ads
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+)
+
+// bootstrapTimeout is the time after which a proxy which did not request all its configuration is considered bootstrapped
+const bootstrapTimeout = 10 * time.Second
+
+// bootstrapPriority is the priority of a proxy waiting to be sent its initial configuration
+type bootstrapPriority int
+
+const (
+	// unconfiguredPriority is the priority of proxies without configuration, which cannot serve traffic until they bootstrap
+	unconfiguredPriority bootstrapPriority = iota
+
+	// configuredPriority is the priority of proxies which kept the configuration of a previous stream
+	configuredPriority
+)
+
+var bootstrapPriorities = []bootstrapPriority{unconfiguredPriority, configuredPriority}
+
+func (p bootstrapPriority) String() string {
+	if p == unconfiguredPriority {
+		return "unconfigured"
+	}
+	return "configured"
+}
+
+// getBootstrapPriority returns the priority of the proxy which sent the given first request of its stream
+func getBootstrapPriority(request *xds_discovery.DiscoveryRequest) bootstrapPriority {
+	// A proxy reconnecting with a configuration sends the version it last applied
+	if request.VersionInfo != "" {
+		return configuredPriority
+	}
+	return unconfiguredPriority
+}
+
+// admissionController bounds the number of proxies bootstrapping concurrently, so that when many proxies
+// reconnect at once their configuration is not all computed at the same time.
+// Waiting proxies are admitted by priority, then in the order they arrived.
+type admissionController struct {
+	maxConcurrent int
+	metricsStore  metricsstore.MetricStore
+
+	mutex   sync.Mutex
+	active  int
+	waiting map[bootstrapPriority][]chan struct{}
+}
+
+// newAdmissionController returns an admission controller admitting the given number of concurrent bootstraps,
+// or any number of them if it is not positive
+func newAdmissionController(maxConcurrent int, metricsStore metricsstore.MetricStore) *admissionController {
+	return &admissionController{
+		maxConcurrent: maxConcurrent,
+		metricsStore:  metricsStore,
+		waiting:       make(map[bootstrapPriority][]chan struct{}),
+	}
+}
+
+// admit waits until a proxy with the given priority may bootstrap, or the given context is done.
+// An admitted proxy must call release once bootstrapped.
+func (a *admissionController) admit(ctx context.Context, priority bootstrapPriority) error {
+	start := time.Now()
+
+	a.mutex.Lock()
+	if a.maxConcurrent <= 0 || (a.active < a.maxConcurrent && a.getQueueLengthLocked() == 0) {
+		a.active++
+		a.mutex.Unlock()
+		a.metricsStore.ObserveBootstrapQueueWait(priority.String(), 0)
+		return nil
+	}
+
+	admitted := make(chan struct{})
+	a.waiting[priority] = append(a.waiting[priority], admitted)
+	a.setQueueLengthLocked(priority)
+	a.mutex.Unlock()
+
+	select {
+	case <-admitted:
+		a.metricsStore.ObserveBootstrapQueueWait(priority.String(), time.Since(start))
+		return nil
+
+	case <-ctx.Done():
+		a.mutex.Lock()
+		removed := a.removeLocked(priority, admitted)
+		a.mutex.Unlock()
+		if !removed {
+			// The proxy was admitted meanwhile: its slot goes to the next waiting proxy
+			a.release()
+		}
+		return ctx.Err()
+	}
+}
+
+// release ends the bootstrap of an admitted proxy, admitting the next waiting proxy if any
+func (a *admissionController) release() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	for _, priority := range bootstrapPriorities {
+		if len(a.waiting[priority]) == 0 {
+			continue
+		}
+		next := a.waiting[priority][0]
+		a.waiting[priority] = a.waiting[priority][1:]
+		a.setQueueLengthLocked(priority)
+		close(next)
+		return
+	}
+	a.active--
+}
+
+func (a *admissionController) removeLocked(priority bootstrapPriority, admitted chan struct{}) bool {
+	for idx, waiting := range a.waiting[priority] {
+		if waiting == admitted {
+			a.waiting[priority] = append(a.waiting[priority][:idx], a.waiting[priority][idx+1:]...)
+			a.setQueueLengthLocked(priority)
+			return true
+		}
+	}
+	return false
+}
+
+func (a *admissionController) getQueueLengthLocked() int {
+	length := 0
+	for _, waiting := range a.waiting {
+		length += len(waiting)
+	}
+	return length
+}
+
+func (a *admissionController) setQueueLengthLocked(priority bootstrapPriority) {
+	a.metricsStore.SetBootstrapQueueLength(priority.String(), len(a.waiting[priority]))
+}
@@ -0,0 +1,76 @@
+package ads
+
+import (
+	"context"
+	"time"
+
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+)
+
+var _ = Describe("Test ADS server admission control", func() {
+	Context("Test getBootstrapPriority()", func() {
+		It("prioritizes proxies without configuration", func() {
+			Expect(getBootstrapPriority(&xds_discovery.DiscoveryRequest{})).To(Equal(unconfiguredPriority))
+			Expect(getBootstrapPriority(&xds_discovery.DiscoveryRequest{VersionInfo: "3"})).To(Equal(configuredPriority))
+		})
+	})
+
+	Context("Test admissionController", func() {
+		// admitAsync admits a proxy with the given priority in the background, and sends the priority once admitted
+		admitAsync := func(ctx context.Context, a *admissionController, priority bootstrapPriority, admitted chan<- bootstrapPriority) {
+			go func() {
+				if err := a.admit(ctx, priority); err == nil {
+					admitted <- priority
+				}
+			}()
+			Eventually(func() int {
+				a.mutex.Lock()
+				defer a.mutex.Unlock()
+				return len(a.waiting[priority])
+			}).ShouldNot(BeZero())
+		}
+
+		It("admits any number of proxies without a maximum", func() {
+			a := newAdmissionController(0, metricsstore.NewFakeMetricStore())
+			for i := 0; i < 10; i++ {
+				Expect(a.admit(context.Background(), unconfiguredPriority)).To(Succeed())
+			}
+		})
+
+		It("admits the waiting proxies by priority as bootstraps end", func() {
+			a := newAdmissionController(1, metricsstore.NewFakeMetricStore())
+			Expect(a.admit(context.Background(), configuredPriority)).To(Succeed())
+
+			admitted := make(chan bootstrapPriority)
+			admitAsync(context.Background(), a, configuredPriority, admitted)
+			admitAsync(context.Background(), a, unconfiguredPriority, admitted)
+
+			a.release()
+			Eventually(admitted).Should(Receive(Equal(unconfiguredPriority)))
+			Consistently(admitted, 100*time.Millisecond).ShouldNot(Receive())
+
+			a.release()
+			Eventually(admitted).Should(Receive(Equal(configuredPriority)))
+
+			a.release()
+			Expect(a.active).To(BeZero())
+		})
+
+		It("stops waiting when the stream ends", func() {
+			a := newAdmissionController(1, metricsstore.NewFakeMetricStore())
+			Expect(a.admit(context.Background(), unconfiguredPriority)).To(Succeed())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			Expect(a.admit(ctx, unconfiguredPriority)).To(Equal(context.Canceled))
+			Expect(a.getQueueLengthLocked()).To(BeZero())
+
+			a.release()
+			Expect(a.active).To(BeZero())
+		})
+	})
+})
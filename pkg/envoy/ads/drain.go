@@ -0,0 +1,87 @@
+package ads
+
+import (
+	"math/rand"
+	"time"
+)
+
+// drainPollInterval is how often Drain checks whether all streams are closed
+const drainPollInterval = 100 * time.Millisecond
+
+// Drain stops accepting new streams and closes the open ones, waiting for them to finish their in-flight pushes.
+// The streams are closed at random times within the first half of the given timeout, so that their proxies
+// reconnect to the other replicas gradually instead of all at once. Drain returns once all streams are closed,
+// or when the timeout elapses.
+func (s *Server) Drain(timeout time.Duration) {
+	s.drainMutex.Lock()
+	if !s.isDrainingLocked() {
+		s.drainWindow = timeout / 2
+		close(s.draining)
+	}
+	streams := s.streams
+	s.drainMutex.Unlock()
+
+	log.Info().Msgf("Draining %d xDS streams within %s", streams, timeout)
+
+	deadline := time.Now().Add(timeout)
+	for streams > 0 && time.Now().Before(deadline) {
+		time.Sleep(drainPollInterval)
+		streams = s.getStreamCount()
+	}
+
+	if streams > 0 {
+		log.Warn().Msgf("%d xDS streams were still open after %s", streams, timeout)
+		return
+	}
+	log.Info().Msg("Drained all xDS streams")
+}
+
+// isDraining returns true once the server is draining its streams
+func (s *Server) isDraining() bool {
+	s.drainMutex.Lock()
+	defer s.drainMutex.Unlock()
+	return s.isDrainingLocked()
+}
+
+func (s *Server) isDrainingLocked() bool {
+	select {
+	case <-s.draining:
+		return true
+	default:
+		return false
+	}
+}
+
+// addStream registers a new stream; it returns false if the server is draining and the stream must be rejected
+func (s *Server) addStream() bool {
+	s.drainMutex.Lock()
+	defer s.drainMutex.Unlock()
+	if s.isDrainingLocked() {
+		return false
+	}
+	s.streams++
+	return true
+}
+
+// removeStream unregisters a closed stream
+func (s *Server) removeStream() {
+	s.drainMutex.Lock()
+	defer s.drainMutex.Unlock()
+	s.streams--
+}
+
+func (s *Server) getStreamCount() int {
+	s.drainMutex.Lock()
+	defer s.drainMutex.Unlock()
+	return s.streams
+}
+
+// getDrainDelay returns the time a stream stays open once the server started draining
+func (s *Server) getDrainDelay() time.Duration {
+	s.drainMutex.Lock()
+	defer s.drainMutex.Unlock()
+	if s.drainWindow <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(s.drainWindow)))
+}
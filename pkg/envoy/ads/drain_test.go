@@ -0,0 +1,63 @@
+package ads
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+)
+
+var _ = Describe("Test ADS server draining", func() {
+	Context("Test Drain()", func() {
+		It("rejects new streams and is not ready once draining", func() {
+			s := NewADSServer(context.Background(), false, "osm-system", nil, nil, nil, nil, 0, metricsstore.NewFakeMetricStore())
+			s.Start(nil)
+			Expect(s.Readiness()).To(BeTrue())
+			Expect(s.addStream()).To(BeTrue())
+			s.removeStream()
+
+			s.Drain(time.Second)
+			Expect(s.isDraining()).To(BeTrue())
+			Expect(s.Readiness()).To(BeFalse())
+			Expect(s.addStream()).To(BeFalse())
+			Expect(s.getStreamCount()).To(Equal(0))
+		})
+
+		It("waits for the open streams to be closed", func() {
+			s := &Server{draining: make(chan struct{})}
+			Expect(s.addStream()).To(BeTrue())
+
+			go func() {
+				<-s.draining
+				time.Sleep(2 * drainPollInterval)
+				s.removeStream()
+			}()
+
+			start := time.Now()
+			s.Drain(10 * time.Second)
+			Expect(s.getStreamCount()).To(Equal(0))
+			Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+		})
+
+		It("returns once the timeout elapses", func() {
+			s := &Server{draining: make(chan struct{})}
+			Expect(s.addStream()).To(BeTrue())
+
+			s.Drain(2 * drainPollInterval)
+			Expect(s.getStreamCount()).To(Equal(1))
+		})
+
+		It("spreads the closing of the streams over half the timeout", func() {
+			s := &Server{draining: make(chan struct{})}
+			Expect(s.getDrainDelay()).To(Equal(time.Duration(0)))
+
+			s.Drain(time.Second)
+			for i := 0; i < 10; i++ {
+				Expect(s.getDrainDelay()).To(BeNumerically("<", 500*time.Millisecond))
+			}
+		})
+	})
+})
@@ -8,3 +8,5 @@ var errUnknownTypeURL = errors.New("unknown TypeUrl")
 var errCreatingResponse = errors.New("creating response")
 var errEnvoyError = errors.New("Envoy error")
 var errGrpcClosed = errors.New("grpc closed")
+var errNotOwner = errors.New("proxy is served by another osm-controller replica")
+var errDraining = errors.New("osm-controller replica is shutting down")
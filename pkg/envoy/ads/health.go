@@ -6,6 +6,8 @@ func (s *Server) Liveness() bool {
 }
 
 // Readiness is the Kubernetes readiness probe handler.
+// A draining server is not ready, so that new connections go to the other replicas.
+// A server with snapshots is ready before it is started, to serve them while the caches sync.
 func (s *Server) Readiness() bool {
-	return true
+	return !s.isDraining() && (s.isSynced() || s.snapshots != nil)
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/multicluster"
 )
 
 func (s *Server) sendAllResponses(proxy *envoy.Proxy, server *xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer, cfg configurator.Configurator) {
@@ -18,11 +19,20 @@ func (s *Server) sendAllResponses(proxy *envoy.Proxy, server *xds_discovery.Aggr
 	// See: https://github.com/envoyproxy/go-control-plane/issues/59
 	for idx, typeURI := range envoy.XDSResponseOrder {
 		prefix := fmt.Sprintf("[*DS %d/%d]", idx+1, len(envoy.XDSResponseOrder))
+		if _, ok := s.getHandlers(proxy)[typeURI]; !ok {
+			// The multicluster gateway is not served all xDS types
+			continue
+		}
 		log.Trace().Msgf("%s Creating %s response for proxy with CN=%s", prefix, typeURI, proxy.GetCommonName())
 
 		// For SDS we need to add ResourceNames
 		var request *xds_discovery.DiscoveryRequest
-		if typeURI == envoy.TypeSDS {
+		if typeURI == envoy.TypeSDS && multicluster.IsGatewayProxy(proxy.GetCommonName(), s.osmNamespace) {
+			request = makeRequestForMulticlusterGatewaySecrets(s.catalog, cfg)
+			if request == nil {
+				continue
+			}
+		} else if typeURI == envoy.TypeSDS {
 			request = makeRequestForAllSecrets(proxy, s.catalog)
 			if request == nil {
 				continue
@@ -75,9 +85,49 @@ func makeRequestForAllSecrets(proxy *envoy.Proxy, catalog catalog.MeshCataloger)
 	}
 }
 
+// makeRequestForMulticlusterGatewaySecrets constructs an SDS request AS IF the multicluster gateway sent it.
+// The gateway needs the certificates of the mirrored names of the exported services and its own certificate.
+func makeRequestForMulticlusterGatewaySecrets(catalog catalog.MeshCataloger, cfg configurator.Configurator) *xds_discovery.DiscoveryRequest {
+	exportedServices, err := catalog.ListExportedServices()
+	if err != nil {
+		log.Error().Err(err).Msg("Error listing exported services for multicluster gateway")
+		return nil
+	}
+
+	gatewayService := multicluster.GetGatewayService(cfg.GetOSMNamespace())
+	resourceNames := []string{
+		envoy.SDSCert{
+			MeshService: gatewayService,
+			CertType:    envoy.ServiceCertType,
+		}.String(),
+		envoy.SDSCert{
+			MeshService: gatewayService,
+			CertType:    envoy.RootCertTypeForMTLSOutbound,
+		}.String(),
+	}
+	for _, exportedService := range exportedServices {
+		mirroredService := multicluster.GetMirroredService(exportedService, cfg.GetClusterName())
+		resourceNames = append(resourceNames,
+			envoy.SDSCert{
+				MeshService: mirroredService,
+				CertType:    envoy.ServiceCertType,
+			}.String(),
+			envoy.SDSCert{
+				MeshService: mirroredService,
+				CertType:    envoy.RootCertTypeForMTLSInbound,
+			}.String(),
+		)
+	}
+
+	return &xds_discovery.DiscoveryRequest{
+		ResourceNames: resourceNames,
+		TypeUrl:       string(envoy.TypeSDS),
+	}
+}
+
 func (s *Server) newAggregatedDiscoveryResponse(proxy *envoy.Proxy, request *xds_discovery.DiscoveryRequest, cfg configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
 	typeURL := envoy.TypeURI(request.TypeUrl)
-	handler, ok := s.xdsHandlers[typeURL]
+	handler, ok := s.getHandlers(proxy)[typeURL]
 	if !ok {
 		log.Error().Msgf("Responder for TypeUrl %s is not implemented", request.TypeUrl)
 		return nil, errUnknownTypeURL
@@ -99,6 +149,7 @@ func (s *Server) newAggregatedDiscoveryResponse(proxy *envoy.Proxy, request *xds
 
 	response.Nonce = proxy.SetNewNonce(typeURL)
 	response.VersionInfo = strconv.FormatUint(proxy.IncrementLastSentVersion(typeURL), 10)
+	s.saveSnapshot(proxy, response)
 
 	if envoy.TypeURI(request.TypeUrl) == envoy.TypeSDS {
 		log.Trace().Msgf("Constructed %s response: VersionInfo=%s", response.TypeUrl, response.VersionInfo)
@@ -2,9 +2,11 @@ package ads
 
 import (
 	"context"
+	"net"
 	"time"
 
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
@@ -15,17 +17,32 @@ import (
 	"github.com/openservicemesh/osm/pkg/envoy/lds"
 	"github.com/openservicemesh/osm/pkg/envoy/rds"
 	"github.com/openservicemesh/osm/pkg/envoy/sds"
+	"github.com/openservicemesh/osm/pkg/envoy/snapshot"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+	"github.com/openservicemesh/osm/pkg/multicluster"
+	"github.com/openservicemesh/osm/pkg/sharding"
+	"github.com/openservicemesh/osm/pkg/utils"
 )
 
-// NewADSServer creates a new Aggregated Discovery Service server
-func NewADSServer(ctx context.Context, meshCatalog catalog.MeshCataloger, enableDebug bool, osmNamespace string, cfg configurator.Configurator) *Server {
+// NewADSServer creates a new Aggregated Discovery Service server.
+// The proxies are served once Start is called; until then, the proxies of live pods are served the snapshots of the
+// given store, if any. With a non-nil sharder, only the proxies assigned to this replica are served.
+// At most maxConcurrentBootstraps proxies are sent their initial configuration concurrently, unless it is not positive.
+func NewADSServer(ctx context.Context, enableDebug bool, osmNamespace string, cfg configurator.Configurator, kubeClient kubernetes.Interface, snapshots *snapshot.Store, sharder sharding.Sharder, maxConcurrentBootstraps int, metricsStore metricsstore.MetricStore) *Server {
 	server := Server{
-		catalog:      meshCatalog,
 		ctx:          ctx,
 		xdsHandlers:  getHandlers(),
 		enableDebug:  enableDebug,
 		osmNamespace: osmNamespace,
 		cfg:          cfg,
+		kubeClient:   kubeClient,
+		sharder:      sharder,
+		snapshots:    snapshots,
+		admission:    newAdmissionController(maxConcurrentBootstraps, metricsStore),
+
+		multiclusterGatewayHandlers: getMulticlusterGatewayHandlers(),
+		draining:                    make(chan struct{}),
+		synced:                      make(chan struct{}),
 	}
 
 	if enableDebug {
@@ -35,6 +52,12 @@ func NewADSServer(ctx context.Context, meshCatalog catalog.MeshCataloger, enable
 	return &server
 }
 
+// Start serves the proxies with the configuration computed by the given catalog, once its caches are synced.
+func (s *Server) Start(meshCatalog catalog.MeshCataloger) {
+	s.catalog = meshCatalog
+	close(s.synced)
+}
+
 func getHandlers() map[envoy.TypeURI]func(context.Context, catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
 	return map[envoy.TypeURI]func(context.Context, catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator) (*xds_discovery.DiscoveryResponse, error){
 		envoy.TypeEDS: eds.NewResponse,
@@ -45,6 +68,35 @@ func getHandlers() map[envoy.TypeURI]func(context.Context, catalog.MeshCataloger
 	}
 }
 
+func getMulticlusterGatewayHandlers() map[envoy.TypeURI]func(context.Context, catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
+	return map[envoy.TypeURI]func(context.Context, catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator) (*xds_discovery.DiscoveryResponse, error){
+		envoy.TypeEDS: eds.NewResponse,
+		envoy.TypeCDS: cds.NewMulticlusterGatewayResponse,
+		envoy.TypeLDS: lds.NewMulticlusterGatewayResponse,
+		envoy.TypeSDS: sds.NewMulticlusterGatewayResponse,
+	}
+}
+
+// getHandlers returns the xDS handlers for the given proxy
+func (s *Server) getHandlers(proxy *envoy.Proxy) map[envoy.TypeURI]func(context.Context, catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
+	if multicluster.IsGatewayProxy(proxy.GetCommonName(), s.osmNamespace) {
+		return s.multiclusterGatewayHandlers
+	}
+	return s.xdsHandlers
+}
+
+// isOwner returns true if the proxy with the given certificate common name is served by this replica
+func (s *Server) isOwner(cn certificate.CommonName) bool {
+	return s.sharder == nil || s.sharder.IsOwner(cn.String())
+}
+
+// TrackConnections returns the listener the gRPC server must serve, which tracks the connections of the proxies
+// so that rejected proxies are disconnected. It must be called before the listener is served.
+func (s *Server) TrackConnections(lis net.Listener) net.Listener {
+	s.connections = utils.NewConnectionTracker(lis)
+	return s.connections
+}
+
 // DeltaAggregatedResources implements discovery.AggregatedDiscoveryServiceServer
 func (s *Server) DeltaAggregatedResources(xds_discovery.AggregatedDiscoveryService_DeltaAggregatedResourcesServer) error {
 	panic("NotImplemented")
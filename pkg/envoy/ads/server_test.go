@@ -0,0 +1,32 @@
+package ads
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+)
+
+// fakeSharder serves the proxies with the given keys
+type fakeSharder map[string]bool
+
+func (f fakeSharder) IsOwner(key string) bool {
+	return f[key]
+}
+
+var _ = Describe("Test ADS server sharding", func() {
+	cn := certificate.CommonName("proxy-1.bookstore.default")
+
+	Context("Test isOwner()", func() {
+		It("serves all proxies without a sharder", func() {
+			s := Server{}
+			Expect(s.isOwner(cn)).To(BeTrue())
+		})
+
+		It("serves only the proxies assigned by the sharder", func() {
+			s := Server{sharder: fakeSharder{cn.String(): true}}
+			Expect(s.isOwner(cn)).To(BeTrue())
+			Expect(s.isOwner("proxy-2.bookstore.default")).To(BeFalse())
+		})
+	})
+})
@@ -3,14 +3,42 @@ package ads
 import (
 	"context"
 	"strconv"
+	"time"
 
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/utils"
 )
 
+const (
+	// ownershipCheckInterval is how often a sharded replica checks whether it still serves the connected proxies
+	ownershipCheckInterval = 10 * time.Second
+
+	// rejectedConnectionCloseDelay is the time after which the connection of a rejected proxy is closed,
+	// which leaves time for the status of the rejected stream to be sent
+	rejectedConnectionCloseDelay = 100 * time.Millisecond
+)
+
+// reject returns the status closing the stream of a proxy which must be served by another replica, and closes the
+// connection of the proxy shortly after. Envoy retries a closed ADS stream on the same HTTP/2 connection, which
+// would reach this replica again: once the connection is closed, Envoy connects again through the osm-controller
+// service, and may reach another replica.
+func (s *Server) reject(ctx context.Context, err error) error {
+	if s.connections != nil {
+		if clientPeer, ok := peer.FromContext(ctx); ok {
+			time.AfterFunc(rejectedConnectionCloseDelay, func() {
+				s.connections.CloseConnection(clientPeer.Addr)
+			})
+		}
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
+
 // StreamAggregatedResources handles streaming of the clusters to the connected Envoy proxies
 func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer) error {
 	// When a new Envoy proxy connects, ValidateClient would ensure that it has a valid certificate,
@@ -24,6 +52,27 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 
 	ip := utils.GetIPFromContext(server.Context())
 
+	// Until its caches are synced, a restarted controller serves the proxy the configuration it last sent it
+	if !s.isSynced() {
+		if err := s.serveSnapshot(server, cn); err != nil {
+			return err
+		}
+	}
+
+	// A proxy assigned to another replica is rejected, and reconnects through the osm-controller service
+	// until it reaches the replica serving it.
+	if !s.isOwner(cn) {
+		log.Debug().Msgf("Rejecting Envoy %s with CN %s served by another replica", ip, cn)
+		return s.reject(server.Context(), errNotOwner)
+	}
+
+	// A replica shutting down rejects new proxies, which reconnect to another replica
+	if !s.addStream() {
+		log.Debug().Msgf("Rejecting Envoy %s with CN %s while draining", ip, cn)
+		return s.reject(server.Context(), errDraining)
+	}
+	defer s.removeStream()
+
 	namespacedService, err := s.catalog.GetServiceFromEnvoyCertificate(cn)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error fetching service for Envoy %s with CN %s", ip, cn)
@@ -45,6 +94,55 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 	// and any gRPC error states.
 	go receive(requests, &server, proxy, quit)
 
+	// The proxies of a sharded replica move to other replicas as replicas are added
+	var ownershipCheck <-chan time.Time
+	if s.sharder != nil {
+		ticker := time.NewTicker(ownershipCheckInterval)
+		defer ticker.Stop()
+		ownershipCheck = ticker.C
+	}
+
+	// Announcements received within the minimum update interval of the last update
+	// are coalesced into a single update, sent once the interval has elapsed.
+	var lastUpdateAt time.Time
+	var delayedUpdateTimer *time.Timer
+	var delayedUpdate <-chan time.Time
+	defer func() {
+		if delayedUpdateTimer != nil {
+			delayedUpdateTimer.Stop()
+		}
+	}()
+
+	// Once the server starts draining, the stream is closed after a random delay.
+	// Updates keep being sent until then, and a push in progress completes before the stream is closed.
+	draining := s.draining
+	var drainTimer *time.Timer
+	var drained <-chan time.Time
+	defer func() {
+		if drainTimer != nil {
+			drainTimer.Stop()
+		}
+	}()
+
+	// The proxy is sent its initial configuration once it is admitted. Its bootstrap ends once it has been sent
+	// a response of each type it is served, or when bootstrapTimeout elapses.
+	admitted, bootstrapped := false, false
+	pendingTypes := make(map[envoy.TypeURI]bool)
+	var bootstrapTimer *time.Timer
+	var bootstrapDeadline <-chan time.Time
+	endBootstrap := func() {
+		if admitted && !bootstrapped {
+			bootstrapped = true
+			s.admission.release()
+		}
+	}
+	defer func() {
+		if bootstrapTimer != nil {
+			bootstrapTimer.Stop()
+		}
+		endBootstrap()
+	}()
+
 	for {
 
 		select {
@@ -131,6 +229,18 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 			}
 			log.Info().Msgf("Received discovery request <%s> from Envoy <%s> with Nonce=%s", discoveryRequest.TypeUrl, proxy, discoveryRequest.ResponseNonce)
 
+			if !admitted {
+				if err := s.admission.admit(server.Context(), getBootstrapPriority(&discoveryRequest)); err != nil {
+					return err
+				}
+				admitted = true
+				for typeURI := range s.getHandlers(proxy) {
+					pendingTypes[typeURI] = true
+				}
+				bootstrapTimer = time.NewTimer(bootstrapTimeout)
+				bootstrapDeadline = bootstrapTimer.C
+			}
+
 			resp, err := s.newAggregatedDiscoveryResponse(proxy, &discoveryRequest, s.cfg)
 			if err != nil {
 				log.Error().Err(err).Msgf("Error composing a DiscoveryResponse")
@@ -139,11 +249,59 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 
 			if err := server.Send(resp); err != nil {
 				log.Error().Err(err).Msgf("Error sending DiscoveryResponse")
+			} else if !bootstrapped {
+				delete(pendingTypes, typeURL)
+				if len(pendingTypes) == 0 {
+					endBootstrap()
+				}
+			}
+
+		case <-bootstrapDeadline:
+			bootstrapDeadline = nil
+			if !bootstrapped {
+				log.Debug().Msgf("Envoy %s did not request all its configuration within %s; ending its bootstrap", proxy.GetCommonName(), bootstrapTimeout)
+				endBootstrap()
+			}
+
+		case <-draining:
+			draining = nil
+			drainTimer = time.NewTimer(s.getDrainDelay())
+			drained = drainTimer.C
+
+		case <-drained:
+			log.Info().Msgf("Closing stream of Envoy %s to drain osm-controller", proxy.GetCommonName())
+			return s.reject(server.Context(), errDraining)
+
+		case <-ownershipCheck:
+			if !s.isOwner(cn) {
+				log.Info().Msgf("Envoy %s is now served by another replica; closing stream", proxy.GetCommonName())
+				return s.reject(server.Context(), errNotOwner)
 			}
 
 		case <-proxy.GetAnnouncementsChannel():
+			// A proxy is only updated once admitted by its first request
+			if !admitted {
+				continue
+			}
+			if delayedUpdate != nil {
+				log.Debug().Msgf("Change detected - update of Envoy %s already scheduled", proxy.GetCommonName())
+				continue
+			}
+			if wait := s.cfg.GetProxyUpdateMinInterval() - time.Since(lastUpdateAt); wait > 0 {
+				log.Debug().Msgf("Change detected - delaying update of Envoy %s by %s", proxy.GetCommonName(), wait)
+				delayedUpdateTimer = time.NewTimer(wait)
+				delayedUpdate = delayedUpdateTimer.C
+				continue
+			}
 			log.Info().Msgf("Change detected - update all Envoys.")
 			s.sendAllResponses(proxy, &server, s.cfg)
+			lastUpdateAt = time.Now()
+
+		case <-delayedUpdate:
+			delayedUpdate = nil
+			log.Info().Msgf("Sending delayed update to Envoy %s", proxy.GetCommonName())
+			s.sendAllResponses(proxy, &server, s.cfg)
+			lastUpdateAt = time.Now()
 
 		}
 	}
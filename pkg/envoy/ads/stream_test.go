@@ -0,0 +1,69 @@
+package ads
+
+import (
+	"context"
+	"net"
+
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// rejectingServer rejects every stream, like a replica which does not serve the proxy,
+// and records the remote address of the connection of each stream
+type rejectingServer struct {
+	*Server
+	remoteAddrs chan string
+}
+
+func (r rejectingServer) StreamAggregatedResources(server xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer) error {
+	if clientPeer, ok := peer.FromContext(server.Context()); ok {
+		r.remoteAddrs <- clientPeer.Addr.String()
+	}
+	return r.reject(server.Context(), errNotOwner)
+}
+
+var _ = Describe("Test ADS stream rejection", func() {
+	Context("Test reject()", func() {
+		It("closes the connection of a rejected proxy, so that it reconnects through a new one", func() {
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+
+			s := &Server{}
+			remoteAddrs := make(chan string, 2)
+			grpcServer := grpc.NewServer()
+			xds_discovery.RegisterAggregatedDiscoveryServiceServer(grpcServer, rejectingServer{Server: s, remoteAddrs: remoteAddrs})
+			go func() {
+				_ = grpcServer.Serve(s.TrackConnections(lis))
+			}()
+			defer grpcServer.Stop()
+
+			// Like Envoy, the proxy retries its ADS stream over a single client connection
+			conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+			Expect(err).ToNot(HaveOccurred())
+			defer conn.Close()
+			client := xds_discovery.NewAggregatedDiscoveryServiceClient(conn)
+
+			openRejectedStream := func() string {
+				stream, err := client.StreamAggregatedResources(context.Background(), grpc.WaitForReady(true))
+				Expect(err).ToNot(HaveOccurred())
+				_, err = stream.Recv()
+				Expect(status.Code(err)).To(Equal(codes.Unavailable))
+				var remoteAddr string
+				Eventually(remoteAddrs).Should(Receive(&remoteAddr))
+				return remoteAddr
+			}
+
+			firstAddr := openRejectedStream()
+			Eventually(conn.GetState).ShouldNot(Equal(connectivity.Ready))
+
+			secondAddr := openRejectedStream()
+			Expect(secondAddr).ToNot(Equal(firstAddr))
+		})
+	})
+})
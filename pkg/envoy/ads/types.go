@@ -2,15 +2,20 @@ package ads
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/snapshot"
 	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/sharding"
+	"github.com/openservicemesh/osm/pkg/utils"
 )
 
 var (
@@ -26,4 +31,30 @@ type Server struct {
 	enableDebug  bool
 	osmNamespace string
 	cfg          configurator.Configurator
+	sharder      sharding.Sharder
+
+	// kubeClient validates the certificates of the proxies served snapshots, before the caches of the catalog sync
+	kubeClient kubernetes.Interface
+
+	// multiclusterGatewayHandlers are the xDS handlers for the multicluster gateway, which is not a sidecar
+	multiclusterGatewayHandlers map[envoy.TypeURI]func(context.Context, catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator) (*xds_discovery.DiscoveryResponse, error)
+
+	// synced is closed by Start, once the catalog is set
+	synced chan struct{}
+
+	// snapshots persists the responses sent to the proxies, to serve them while the caches of a restarted controller sync
+	snapshots *snapshot.Store
+
+	// connections closes the transport of the proxies rejected by this replica, so that they reconnect through a new
+	// connection instead of retrying on the one to this replica
+	connections *utils.ConnectionTracker
+
+	// admission bounds the number of proxies concurrently sent their initial configuration
+	admission *admissionController
+
+	// draining is closed when the server starts draining its streams ahead of shutting down
+	draining    chan struct{}
+	drainWindow time.Duration
+	streams     int
+	drainMutex  sync.Mutex
 }
@@ -0,0 +1,66 @@
+package ads
+
+import (
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/multicluster"
+)
+
+// isSynced returns true once the server is started
+func (s *Server) isSynced() bool {
+	select {
+	case <-s.synced:
+		return true
+	default:
+		return false
+	}
+}
+
+// serveSnapshot sends the proxy with the given common name its persisted configuration, and waits for the server to start.
+// The snapshot is only sent to a proxy assigned to this replica, whose certificate was issued for a live pod.
+// It returns an error if the stream must be closed before then.
+func (s *Server) serveSnapshot(server xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer, cn certificate.CommonName) error {
+	if s.snapshots != nil && s.isOwner(cn) {
+		responses, err := s.snapshots.Load(cn)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error loading snapshot of Envoy with CN=%s", cn)
+		}
+		// Without synced caches, the certificate of the proxy is validated against the pods of the API server
+		if len(responses) > 0 && !multicluster.IsGatewayProxy(cn, s.osmNamespace) {
+			if _, err := catalog.GetLivePodFromCertificate(cn, s.kubeClient); err != nil {
+				log.Error().Err(err).Msgf("Not sending the snapshot of Envoy with CN=%s, which has no live pod", cn)
+				responses = nil
+			}
+		}
+		for _, response := range responses {
+			if err := server.Send(response); err != nil {
+				return err
+			}
+		}
+		if len(responses) > 0 {
+			log.Info().Msgf("Sent the snapshot of Envoy with CN=%s while caches sync", cn)
+		}
+	}
+
+	select {
+	case <-s.synced:
+		return nil
+	case <-s.draining:
+		return s.reject(server.Context(), errDraining)
+	case <-server.Context().Done():
+		return server.Context().Err()
+	}
+}
+
+// saveSnapshot persists the given response to the given proxy
+func (s *Server) saveSnapshot(proxy *envoy.Proxy, response *xds_discovery.DiscoveryResponse) {
+	if s.snapshots == nil {
+		return
+	}
+	if err := s.snapshots.Save(proxy.GetCommonName(), response); err != nil {
+		log.Error().Err(err).Msgf("Error saving %s snapshot of Envoy with CN=%s", response.TypeUrl, proxy.GetCommonName())
+	}
+}
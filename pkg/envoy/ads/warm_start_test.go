@@ -0,0 +1,124 @@
+package ads
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/snapshot"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+// contextXDSServer is a fake xDS stream with a context
+type contextXDSServer struct {
+	xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer
+	ctx context.Context
+}
+
+func (s contextXDSServer) Context() context.Context {
+	return s.ctx
+}
+
+var _ = Describe("Test ADS server warm start", func() {
+	cn := certificate.CommonName("proxy-1.bookstore.default")
+
+	var dir string
+	var stop chan struct{}
+	var snapshots *snapshot.Store
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "snapshots")
+		Expect(err).ToNot(HaveOccurred())
+		stop = make(chan struct{})
+		snapshots, err = snapshot.NewStore(dir, stop)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		close(stop)
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Context("Test Readiness()", func() {
+		It("is not ready before it is started without snapshots", func() {
+			s := NewADSServer(context.Background(), false, "osm-system", nil, nil, nil, nil, 0, metricsstore.NewFakeMetricStore())
+			Expect(s.Readiness()).To(BeFalse())
+			s.Start(nil)
+			Expect(s.Readiness()).To(BeTrue())
+		})
+
+		It("is ready before it is started with snapshots", func() {
+			s := NewADSServer(context.Background(), false, "osm-system", nil, fake.NewSimpleClientset(), snapshots, nil, 0, metricsstore.NewFakeMetricStore())
+			Expect(s.Readiness()).To(BeTrue())
+		})
+	})
+
+	Context("Test serveSnapshot()", func() {
+		livePod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bookstore-1",
+				Namespace: "default",
+				Labels:    map[string]string{constants.EnvoyUniqueIDLabelName: "proxy-1"},
+			},
+			Spec: corev1.PodSpec{ServiceAccountName: "bookstore"},
+		}
+
+		BeforeEach(func() {
+			cds := &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeCDS.String(), VersionInfo: "3"}
+			Expect(snapshots.Save(cn, cds)).To(Succeed())
+		})
+
+		It("sends the snapshot of the proxy", func() {
+			s := NewADSServer(context.Background(), false, "osm-system", nil, fake.NewSimpleClientset(livePod), snapshots, nil, 0, metricsstore.NewFakeMetricStore())
+			s.Start(nil)
+
+			fakeServer, responses := tests.NewFakeXDSServer(nil, nil, nil)
+			Expect(s.serveSnapshot(contextXDSServer{fakeServer, context.Background()}, cn)).To(Succeed())
+			Expect(*responses).To(HaveLen(1))
+			Expect((*responses)[0].TypeUrl).To(Equal(envoy.TypeCDS.String()))
+			Expect((*responses)[0].VersionInfo).To(Equal("3"))
+		})
+
+		It("does not send the snapshot of a proxy without a live pod", func() {
+			terminatedPod := livePod.DeepCopy()
+			terminatedPod.Status.Phase = corev1.PodSucceeded
+			for _, kubeClient := range []*fake.Clientset{fake.NewSimpleClientset(), fake.NewSimpleClientset(terminatedPod)} {
+				s := NewADSServer(context.Background(), false, "osm-system", nil, kubeClient, snapshots, nil, 0, metricsstore.NewFakeMetricStore())
+				s.Start(nil)
+
+				fakeServer, responses := tests.NewFakeXDSServer(nil, nil, nil)
+				Expect(s.serveSnapshot(contextXDSServer{fakeServer, context.Background()}, cn)).To(Succeed())
+				Expect(*responses).To(BeEmpty())
+			}
+		})
+
+		It("does not send the snapshot of a proxy assigned to another replica", func() {
+			s := NewADSServer(context.Background(), false, "osm-system", nil, fake.NewSimpleClientset(livePod), snapshots, fakeSharder{}, 0, metricsstore.NewFakeMetricStore())
+			s.Start(nil)
+
+			fakeServer, responses := tests.NewFakeXDSServer(nil, nil, nil)
+			Expect(s.serveSnapshot(contextXDSServer{fakeServer, context.Background()}, cn)).To(Succeed())
+			Expect(*responses).To(BeEmpty())
+		})
+
+		It("closes the stream when the server drains before it is started", func() {
+			s := NewADSServer(context.Background(), false, "osm-system", nil, fake.NewSimpleClientset(), snapshots, nil, 0, metricsstore.NewFakeMetricStore())
+			s.Drain(0)
+
+			fakeServer, _ := tests.NewFakeXDSServer(nil, nil, nil)
+			Expect(s.serveSnapshot(contextXDSServer{fakeServer, context.Background()}, cn)).ToNot(Succeed())
+		})
+	})
+})
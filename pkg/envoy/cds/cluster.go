@@ -12,6 +12,7 @@ import (
 	"github.com/golang/protobuf/ptypes/wrappers"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -59,7 +60,7 @@ func getOutboundPassthroughCluster() *xds_cluster.Cluster {
 }
 
 // getLocalServiceCluster returns an Envoy Cluster corresponding to the local service
-func getLocalServiceCluster(catalog catalog.MeshCataloger, proxyServiceName service.MeshService, clusterName string) (*xds_cluster.Cluster, error) {
+func getLocalServiceCluster(catalog catalog.MeshCataloger, proxyServiceName service.MeshService, clusterName string, ipFamily configurator.IPFamily) (*xds_cluster.Cluster, error) {
 	xdsCluster := xds_cluster.Cluster{
 		// The name must match the domain being cURLed in the demo
 		Name:           clusterName,
@@ -70,7 +71,7 @@ func getLocalServiceCluster(catalog catalog.MeshCataloger, proxyServiceName serv
 		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
 			Type: xds_cluster.Cluster_STRICT_DNS,
 		},
-		DnsLookupFamily: xds_cluster.Cluster_V4_ONLY,
+		DnsLookupFamily: getDNSLookupFamily(ipFamily),
 		LoadAssignment: &xds_endpoint.ClusterLoadAssignment{
 			// NOTE: results.MeshService is the top level service that is cURLed.
 			ClusterName: clusterName,
@@ -94,7 +95,7 @@ func getLocalServiceCluster(catalog catalog.MeshCataloger, proxyServiceName serv
 			LbEndpoints: []*xds_endpoint.LbEndpoint{{
 				HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
 					Endpoint: &xds_endpoint.Endpoint{
-						Address: envoy.GetAddress(constants.WildcardIPAddr, uint32(ep.Port)),
+						Address: envoy.GetAddress(envoy.GetWildcardIPAddr(ipFamily), uint32(ep.Port)),
 					},
 				},
 				LoadBalancingWeight: &wrappers.UInt32Value{
@@ -108,8 +109,20 @@ func getLocalServiceCluster(catalog catalog.MeshCataloger, proxyServiceName serv
 	return &xdsCluster, nil
 }
 
+// getDNSLookupFamily returns the family of the addresses Envoy resolves the hostnames of a cluster to
+func getDNSLookupFamily(ipFamily configurator.IPFamily) xds_cluster.Cluster_DnsLookupFamily {
+	switch ipFamily {
+	case configurator.IPv6:
+		return xds_cluster.Cluster_V6_ONLY
+	case configurator.DualStack:
+		return xds_cluster.Cluster_AUTO
+	default:
+		return xds_cluster.Cluster_V4_ONLY
+	}
+}
+
 // getPrometheusCluster returns an Envoy Cluster responsible for scraping metrics by Prometheus
-func getPrometheusCluster() xds_cluster.Cluster {
+func getPrometheusCluster(ipFamily configurator.IPFamily) xds_cluster.Cluster {
 	return xds_cluster.Cluster{
 		// The name must match the domain being cURLed in the demo
 		Name:           constants.EnvoyMetricsCluster,
@@ -127,7 +140,7 @@ func getPrometheusCluster() xds_cluster.Cluster {
 					LbEndpoints: []*xds_endpoint.LbEndpoint{{
 						HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
 							Endpoint: &xds_endpoint.Endpoint{
-								Address: envoy.GetAddress(constants.LocalhostIPAddress, constants.EnvoyAdminPort),
+								Address: envoy.GetAddress(envoy.GetLocalhostIPAddr(ipFamily), constants.EnvoyAdminPort),
 							},
 						},
 						LoadBalancingWeight: &wrappers.UInt32Value{
@@ -0,0 +1,99 @@
+package cds
+
+import (
+	"context"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/multicluster"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// NewMulticlusterGatewayResponse creates a new Cluster Discovery Response for the multicluster gateway.
+// The gateway has a cluster for each port of each exported service, which it connects to with mTLS using its own identity.
+func NewMulticlusterGatewayResponse(_ context.Context, catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_discovery.DiscoveryRequest, cfg configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
+	gatewayService := multicluster.GetGatewayService(cfg.GetOSMNamespace())
+
+	resp := &xds_discovery.DiscoveryResponse{
+		TypeUrl: string(envoy.TypeCDS),
+	}
+
+	exportedServices, err := catalog.ListExportedServices()
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing exported services for multicluster gateway %s", proxy.GetCommonName())
+		return nil, err
+	}
+
+	for _, exportedService := range exportedServices {
+		ports, err := catalog.ListExportedServicePorts(exportedService)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error listing ports of exported service %s", exportedService)
+			continue
+		}
+
+		for _, port := range ports {
+			exportedServiceCluster, err := getExportedServiceCluster(exportedService, port, gatewayService, cfg.GetIPFamily())
+			if err != nil {
+				log.Error().Err(err).Msgf("Failed to construct service cluster for multicluster gateway %s", proxy.GetCommonName())
+				return nil, err
+			}
+
+			marshalledCluster, err := ptypes.MarshalAny(exportedServiceCluster)
+			if err != nil {
+				log.Error().Err(err).Msgf("Failed to marshal cluster for multicluster gateway %s", proxy.GetCommonName())
+				return nil, err
+			}
+			resp.Resources = append(resp.Resources, marshalledCluster)
+		}
+	}
+
+	return resp, nil
+}
+
+// getExportedServiceCluster returns the cluster through which the multicluster gateway connects to the given port
+// of the exported service. The service is addressed by its hostname, as its endpoints do not tell which of their
+// ports is the target of the given service port.
+func getExportedServiceCluster(exportedService service.MeshService, port uint32, gatewayService service.MeshService, ipFamily configurator.IPFamily) (*xds_cluster.Cluster, error) {
+	marshalledUpstreamTLSContext, err := envoy.MessageToAny(
+		envoy.GetUpstreamTLSContext(gatewayService, exportedService.GetCommonName().String()))
+	if err != nil {
+		return nil, err
+	}
+
+	clusterName := multicluster.GetGatewayClusterName(exportedService, port)
+	return &xds_cluster.Cluster{
+		Name:           clusterName,
+		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
+		LbPolicy:       xds_cluster.Cluster_ROUND_ROBIN,
+		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
+			Type: xds_cluster.Cluster_STRICT_DNS,
+		},
+		DnsLookupFamily: getDNSLookupFamily(ipFamily),
+		LoadAssignment: &xds_endpoint.ClusterLoadAssignment{
+			ClusterName: clusterName,
+			Endpoints: []*xds_endpoint.LocalityLbEndpoints{{
+				LbEndpoints: []*xds_endpoint.LbEndpoint{{
+					HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+						Endpoint: &xds_endpoint.Endpoint{
+							Address: envoy.GetAddress(exportedService.GetCommonName().String(), port),
+						},
+					},
+				}},
+			}},
+		},
+		TransportSocket: &xds_core.TransportSocket{
+			Name: wellknown.TransportSocketTls,
+			ConfigType: &xds_core.TransportSocket_TypedConfig{
+				TypedConfig: marshalledUpstreamTLSContext,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,36 @@
+package cds
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/golang/protobuf/ptypes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/multicluster"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("Construct multicluster gateway clusters", func() {
+	Context("Test getExportedServiceCluster()", func() {
+		It("connects to the given port of the exported service with mTLS", func() {
+			gatewayService := multicluster.GetGatewayService("osm-system")
+			cluster, err := getExportedServiceCluster(tests.BookstoreService, tests.ServicePort, gatewayService, configurator.IPv4)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(cluster.Name).To(Equal("default/bookstore:8888"))
+			Expect(cluster.GetType()).To(Equal(xds_cluster.Cluster_STRICT_DNS))
+			Expect(cluster.LoadAssignment.Endpoints).To(HaveLen(1))
+			Expect(cluster.LoadAssignment.Endpoints[0].LbEndpoints).To(HaveLen(1))
+			Expect(cluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address).To(Equal(envoy.GetAddress("bookstore.default.svc.cluster.local", tests.ServicePort)))
+
+			upstreamTLSContext := &xds_auth.UpstreamTlsContext{}
+			err = ptypes.UnmarshalAny(cluster.TransportSocket.GetTypedConfig(), upstreamTLSContext)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(upstreamTLSContext.Sni).To(Equal("bookstore.default.svc.cluster.local"))
+		})
+	})
+})
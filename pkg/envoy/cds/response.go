@@ -59,7 +59,7 @@ func NewResponse(_ context.Context, catalog catalog.MeshCataloger, proxy *envoy.
 	// Create a local cluster for the service.
 	// The local cluster will be used for incoming traffic.
 	localClusterName := getLocalClusterName(proxyServiceName)
-	localCluster, err := getLocalServiceCluster(catalog, proxyServiceName, localClusterName)
+	localCluster, err := getLocalServiceCluster(catalog, proxyServiceName, localClusterName, cfg.GetIPFamily())
 	if err != nil {
 		log.Error().Err(err).Msgf("Failed to get local cluster config for proxy %s", proxyServiceName)
 		return nil, err
@@ -72,6 +72,16 @@ func NewResponse(_ context.Context, catalog catalog.MeshCataloger, proxy *envoy.
 		clusterFactories[passthroughCluster.Name] = passthroughCluster
 	}
 
+	// Add clusters originating TLS connections to external hosts
+	for _, tlsOrigination := range catalog.GetTLSOriginationPolicies(proxyServiceName) {
+		tlsOriginationCluster, err := getTLSOriginationCluster(tlsOrigination, cfg.GetIPFamily())
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to construct TLS origination cluster for host %s for proxy %s", tlsOrigination.Host, proxyServiceName)
+			return nil, err
+		}
+		clusterFactories[tlsOriginationCluster.Name] = tlsOriginationCluster
+	}
+
 	for _, cluster := range clusterFactories {
 		log.Debug().Msgf("Proxy service %s constructed ClusterConfiguration: %+v ", proxyServiceName, cluster)
 		marshalledClusters, err := ptypes.MarshalAny(cluster)
@@ -83,7 +93,7 @@ func NewResponse(_ context.Context, catalog catalog.MeshCataloger, proxy *envoy.
 	}
 
 	if cfg.IsPrometheusScrapingEnabled() {
-		prometheusCluster := getPrometheusCluster()
+		prometheusCluster := getPrometheusCluster(cfg.GetIPFamily())
 		marshalledCluster, err := ptypes.MarshalAny(&prometheusCluster)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error marshaling Prometheus cluster for proxy with CN=%s", proxy.GetCommonName())
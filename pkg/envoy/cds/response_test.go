@@ -84,7 +84,7 @@ var _ = Describe("CDS Response", func() {
 
 	Context("Test cds clusters", func() {
 		It("Returns a local cluster object", func() {
-			remoteCluster, err := getLocalServiceCluster(catalog, proxyService, getLocalClusterName(proxyService))
+			remoteCluster, err := getLocalServiceCluster(catalog, proxyService, getLocalClusterName(proxyService), configurator.IPv4)
 			Expect(err).ToNot(HaveOccurred())
 
 			expectedClusterLoadAssignment := &xds_endpoint.ClusterLoadAssignment{
@@ -248,7 +248,7 @@ var _ = Describe("CDS Response", func() {
 		})
 
 		It("Returns a Prometheus cluster object", func() {
-			remoteCluster := getPrometheusCluster()
+			remoteCluster := getPrometheusCluster(configurator.IPv4)
 
 			expectedClusterLoadAssignment := &xds_endpoint.ClusterLoadAssignment{
 				ClusterName: constants.EnvoyMetricsCluster,
@@ -294,5 +294,21 @@ var _ = Describe("CDS Response", func() {
 			Expect(remoteCluster.LoadAssignment).To(Equal(expectedClusterLoadAssignment))
 			Expect(&remoteCluster).To(Equal(expectedCluster))
 		})
+
+		It("Resolves the local addresses of dual-stack pods to either family", func() {
+			localCluster, err := getLocalServiceCluster(catalog, proxyService, getLocalClusterName(proxyService), configurator.DualStack)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(localCluster.DnsLookupFamily).To(Equal(xds_cluster.Cluster_AUTO))
+		})
+
+		It("Returns clusters reaching the local addresses of IPv6 pods", func() {
+			localCluster, err := getLocalServiceCluster(catalog, proxyService, getLocalClusterName(proxyService), configurator.IPv6)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(localCluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address.GetSocketAddress().Address).To(Equal("::"))
+			Expect(localCluster.DnsLookupFamily).To(Equal(xds_cluster.Cluster_V6_ONLY))
+
+			prometheusCluster := getPrometheusCluster(configurator.IPv6)
+			Expect(prometheusCluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address.GetSocketAddress().Address).To(Equal("::1"))
+		})
 	})
 })
@@ -0,0 +1,104 @@
+package cds
+
+import (
+	"strings"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	// defaultCABundlePath is the path of the CA bundle in the sidecar's image, used when a policy does not specify one
+	defaultCABundlePath = "/etc/ssl/certs/ca-certificates.crt"
+)
+
+// getTLSOriginationCluster returns an Envoy cluster originating TLS connections to the external host of the given policy
+func getTLSOriginationCluster(tlsOrigination trafficpolicy.TLSOrigination, ipFamily configurator.IPFamily) (*xds_cluster.Cluster, error) {
+	trustedCA := &xds_core.DataSource{
+		Specifier: &xds_core.DataSource_Filename{
+			Filename: defaultCABundlePath,
+		},
+	}
+	if tlsOrigination.CABundle != "" {
+		trustedCA = &xds_core.DataSource{
+			Specifier: &xds_core.DataSource_InlineString{
+				InlineString: tlsOrigination.CABundle,
+			},
+		}
+	}
+
+	upstreamTLSContext := &xds_auth.UpstreamTlsContext{
+		CommonTlsContext: &xds_auth.CommonTlsContext{
+			TlsParams: envoy.GetTLSParams(),
+			ValidationContextType: &xds_auth.CommonTlsContext_ValidationContext{
+				ValidationContext: &xds_auth.CertificateValidationContext{
+					TrustedCa:            trustedCA,
+					MatchSubjectAltNames: getSubjectAltNameMatchers(tlsOrigination.SNI),
+				},
+			},
+		},
+		Sni: tlsOrigination.SNI,
+	}
+	marshalledUpstreamTLSContext, err := envoy.MessageToAny(upstreamTLSContext)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterName := string(tlsOrigination.GetClusterName())
+	return &xds_cluster.Cluster{
+		Name:                 clusterName,
+		ConnectTimeout:       ptypes.DurationProto(clusterConnectTimeout),
+		LbPolicy:             xds_cluster.Cluster_ROUND_ROBIN,
+		ClusterDiscoveryType: &xds_cluster.Cluster_Type{Type: xds_cluster.Cluster_LOGICAL_DNS},
+		DnsLookupFamily:      getDNSLookupFamily(ipFamily),
+		LoadAssignment: &xds_endpoint.ClusterLoadAssignment{
+			ClusterName: clusterName,
+			Endpoints: []*xds_endpoint.LocalityLbEndpoints{{
+				LbEndpoints: []*xds_endpoint.LbEndpoint{{
+					HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+						Endpoint: &xds_endpoint.Endpoint{
+							Address: envoy.GetAddress(tlsOrigination.Host, tlsOrigination.Port),
+						},
+					},
+				}},
+			}},
+		},
+		TransportSocket: &xds_core.TransportSocket{
+			Name: wellknown.TransportSocketTls,
+			ConfigType: &xds_core.TransportSocket_TypedConfig{
+				TypedConfig: marshalledUpstreamTLSContext,
+			},
+		},
+	}, nil
+}
+
+// getSubjectAltNameMatchers returns the matchers of the DNS Subject Alternative Names of a certificate valid for the given
+// server name. As the matchers compare the SAN of the certificate literally, a wildcard certificate is matched by its
+// wildcard SAN, which covers the leftmost label of the server name only, and never a top-level domain alone.
+func getSubjectAltNameMatchers(serverName string) []*xds_matcher.StringMatcher {
+	matchers := []*xds_matcher.StringMatcher{{
+		MatchPattern: &xds_matcher.StringMatcher_Exact{
+			Exact: serverName,
+		},
+	}}
+
+	labels := strings.SplitN(serverName, ".", 2)
+	if len(labels) < 2 || labels[0] == "*" || !strings.Contains(labels[1], ".") {
+		return matchers
+	}
+	return append(matchers, &xds_matcher.StringMatcher{
+		MatchPattern: &xds_matcher.StringMatcher_Exact{
+			Exact: "*." + labels[1],
+		},
+	})
+}
@@ -0,0 +1,88 @@
+package cds
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/golang/protobuf/ptypes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var _ = Describe("Test TLS origination clusters", func() {
+	Context("Test getTLSOriginationCluster()", func() {
+		tlsOrigination := trafficpolicy.TLSOrigination{
+			Host: "httpbin.org",
+			Port: 443,
+			SNI:  "sni.httpbin.org",
+		}
+
+		getUpstreamTLSContext := func(cluster *xds_cluster.Cluster) *xds_auth.UpstreamTlsContext {
+			upstreamTLSContext := &xds_auth.UpstreamTlsContext{}
+			err := ptypes.UnmarshalAny(cluster.TransportSocket.GetTypedConfig(), upstreamTLSContext)
+			Expect(err).ToNot(HaveOccurred())
+			return upstreamTLSContext
+		}
+
+		It("originates TLS to the external host validated with the default CA bundle", func() {
+			cluster, err := getTLSOriginationCluster(tlsOrigination, configurator.IPv4)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(cluster.Name).To(Equal("tls-origination|httpbin.org:443"))
+			Expect(cluster.GetType()).To(Equal(xds_cluster.Cluster_LOGICAL_DNS))
+			Expect(cluster.DnsLookupFamily).To(Equal(xds_cluster.Cluster_V4_ONLY))
+			socketAddress := cluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address.GetSocketAddress()
+			Expect(socketAddress.Address).To(Equal("httpbin.org"))
+			Expect(socketAddress.GetPortValue()).To(Equal(uint32(443)))
+
+			upstreamTLSContext := getUpstreamTLSContext(cluster)
+			Expect(upstreamTLSContext.Sni).To(Equal("sni.httpbin.org"))
+			validationContext := upstreamTLSContext.CommonTlsContext.GetValidationContext()
+			Expect(validationContext.TrustedCa.GetFilename()).To(Equal(defaultCABundlePath))
+			Expect(validationContext.MatchSubjectAltNames).To(HaveLen(2))
+			Expect(validationContext.MatchSubjectAltNames[0].GetExact()).To(Equal("sni.httpbin.org"))
+			Expect(validationContext.MatchSubjectAltNames[1].GetExact()).To(Equal("*.httpbin.org"))
+		})
+
+		It("validates the external host with the CA bundle of the policy", func() {
+			tlsOrigination.CABundle = "-----BEGIN CERTIFICATE-----"
+			cluster, err := getTLSOriginationCluster(tlsOrigination, configurator.IPv4)
+			Expect(err).ToNot(HaveOccurred())
+
+			validationContext := getUpstreamTLSContext(cluster).CommonTlsContext.GetValidationContext()
+			Expect(validationContext.TrustedCa.GetInlineString()).To(Equal("-----BEGIN CERTIFICATE-----"))
+		})
+
+		It("resolves the external host to addresses of the IP family of the mesh", func() {
+			cluster, err := getTLSOriginationCluster(tlsOrigination, configurator.IPv6)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cluster.DnsLookupFamily).To(Equal(xds_cluster.Cluster_V6_ONLY))
+		})
+	})
+
+	Context("Test getSubjectAltNameMatchers()", func() {
+		getExactMatches := func(serverName string) []string {
+			var exactMatches []string
+			for _, matcher := range getSubjectAltNameMatchers(serverName) {
+				exactMatches = append(exactMatches, matcher.GetExact())
+			}
+			return exactMatches
+		}
+
+		It("matches the server name and the wildcard SAN covering its leftmost label", func() {
+			Expect(getExactMatches("api.httpbin.org")).To(Equal([]string{"api.httpbin.org", "*.httpbin.org"}))
+		})
+
+		It("does not match a wildcard SAN covering a top-level domain", func() {
+			Expect(getExactMatches("httpbin.org")).To(Equal([]string{"httpbin.org"}))
+			Expect(getExactMatches("localhost")).To(Equal([]string{"localhost"}))
+		})
+
+		It("does not match another wildcard SAN for a wildcard server name", func() {
+			Expect(getExactMatches("*.httpbin.org")).To(Equal([]string{"*.httpbin.org"}))
+		})
+	})
+})
@@ -50,6 +50,17 @@ func getHTTPConnectionManager(routeName string, cfg configurator.Configurator) *
 	return connManager
 }
 
+// setForwardClientCertDetails configures the connection manager to replace any x-forwarded-client-cert (XFCC)
+// header sent by the client with the details of the validated client certificate of the connection.
+func setForwardClientCertDetails(connManager *xds_hcm.HttpConnectionManager) {
+	connManager.ForwardClientCertDetails = xds_hcm.HttpConnectionManager_SANITIZE_SET
+	connManager.SetCurrentClientCertDetails = &xds_hcm.HttpConnectionManager_SetCurrentClientCertDetails{
+		Subject: &wrappers.BoolValue{Value: true},
+		Uri:     true,
+		Dns:     true,
+	}
+}
+
 func getPrometheusConnectionManager(listenerName string, routeName string, clusterName string) *xds_hcm.HttpConnectionManager {
 	return &xds_hcm.HttpConnectionManager{
 		StatPrefix: listenerName,
@@ -0,0 +1,149 @@
+package lds
+
+import (
+	"time"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_dns_table "github.com/envoyproxy/go-control-plane/envoy/data/dns/v3"
+	xds_dns_filter "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/udp/dns_filter/v3alpha"
+	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/golang/protobuf/ptypes"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	dnsListenerName = "dns_listener"
+
+	// dnsFilterName is the name of Envoy's UDP DNS filter
+	dnsFilterName = "envoy.filters.udp.dns_filter"
+
+	dnsStatPrefix      = "dns"
+	dnsAnswerTTL       = 30 * time.Second
+	dnsResolverTimeout = 5 * time.Second
+
+	// externalNameRegex matches the names outside of the cluster domain, whose top-level domain is not "local"
+	externalNameRegex = `^(.*\.)?([^.]{0,4}|[^.]{6,}|[^l.][^.]{4}|l[^o.][^.]{3}|lo[^c.][^.]{2}|loc[^a.][^.]|loca[^l.])$`
+)
+
+// newDNSListener returns the UDP listener the DNS queries of the application are redirected to.
+// Names of the mesh services and external hosts the given service is allowed to reach are answered by the sidecar
+// from an inline table. Other names in the cluster domain are forwarded to the resolvers configured in the pod,
+// as are external names when egress is enabled; external names are otherwise answered with NXDOMAIN.
+func newDNSListener(meshCatalog catalog.MeshCataloger, svc service.MeshService, cfg configurator.Configurator) (*xds_listener.Listener, error) {
+	dnsTable, err := getDNSTable(meshCatalog, svc, cfg, externalHostResolver)
+	if err != nil {
+		return nil, err
+	}
+
+	dnsFilter := &xds_dns_filter.DnsFilterConfig{
+		StatPrefix: dnsStatPrefix,
+		ServerConfig: &xds_dns_filter.DnsFilterConfig_ServerContextConfig{
+			ConfigSource: &xds_dns_filter.DnsFilterConfig_ServerContextConfig_InlineDnsTable{
+				InlineDnsTable: dnsTable,
+			},
+		},
+		ClientConfig: &xds_dns_filter.DnsFilterConfig_ClientContextConfig{
+			ResolverTimeout: ptypes.DurationProto(dnsResolverTimeout),
+		},
+	}
+	marshalledDNSFilter, err := ptypes.MarshalAny(dnsFilter)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling DNS filter config for proxy of service %s", svc)
+		return nil, err
+	}
+
+	address := envoy.GetListenerAddress(cfg.GetIPFamily(), constants.EnvoyDNSListenerPort)
+	address.GetSocketAddress().Protocol = xds_core.SocketAddress_UDP
+
+	return &xds_listener.Listener{
+		Name:             dnsListenerName,
+		Address:          address,
+		TrafficDirection: xds_core.TrafficDirection_OUTBOUND,
+		ListenerFilters: []*xds_listener.ListenerFilter{
+			{
+				Name: dnsFilterName,
+				ConfigType: &xds_listener.ListenerFilter_TypedConfig{
+					TypedConfig: marshalledDNSFilter,
+				},
+			},
+		},
+	}, nil
+}
+
+// getDNSTable returns a DNS table resolving the fully qualified names of the mesh services
+// the given service is allowed to reach to their cluster IPs, and the external hosts it is allowed to reach
+// to their addresses resolved by the controller.
+func getDNSTable(meshCatalog catalog.MeshCataloger, svc service.MeshService, cfg configurator.Configurator, resolver *hostResolver) (*xds_dns_table.DnsTable, error) {
+	allowedServices, err := meshCatalog.ListAllowedOutboundServices(svc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing allowed outbound services for service %s", svc)
+		return nil, err
+	}
+
+	dnsTable := &xds_dns_table.DnsTable{}
+	for _, allowedService := range allowedServices {
+		k8sService, err := meshCatalog.GetSMISpec().GetService(allowedService)
+		if err != nil || k8sService == nil {
+			log.Error().Err(err).Msgf("Error finding Kubernetes service for %s", allowedService)
+			continue
+		}
+		// Headless services are resolved to their pod IPs by the cluster DNS
+		if k8sService.Spec.ClusterIP == "" || k8sService.Spec.ClusterIP == corev1.ClusterIPNone {
+			continue
+		}
+
+		dnsTable.VirtualDomains = append(dnsTable.VirtualDomains, newDNSVirtualDomain(kubernetes.GetFQDNForService(k8sService), []string{k8sService.Spec.ClusterIP}))
+	}
+
+	hosts := make(map[string]struct{})
+	for _, tlsOrigination := range meshCatalog.GetTLSOriginationPolicies(svc) {
+		if _, ok := hosts[tlsOrigination.Host]; ok {
+			continue
+		}
+		hosts[tlsOrigination.Host] = struct{}{}
+
+		addresses := resolver.resolve(tlsOrigination.Host, cfg.GetIPFamily())
+		if len(addresses) == 0 {
+			// Unresolved hosts are forwarded to the resolvers of the pod when egress is enabled
+			continue
+		}
+		dnsTable.VirtualDomains = append(dnsTable.VirtualDomains, newDNSVirtualDomain(tlsOrigination.Host, addresses))
+	}
+
+	if !cfg.IsEgressEnabled() {
+		// Without egress, external names are only resolved for the external hosts the policies allow
+		dnsTable.KnownSuffixes = []*xds_matcher.StringMatcher{
+			{
+				MatchPattern: &xds_matcher.StringMatcher_SafeRegex{
+					SafeRegex: &xds_matcher.RegexMatcher{
+						EngineType: &xds_matcher.RegexMatcher_GoogleRe2{GoogleRe2: &xds_matcher.RegexMatcher_GoogleRE2{}},
+						Regex:      externalNameRegex,
+					},
+				},
+			},
+		}
+	}
+	return dnsTable, nil
+}
+
+func newDNSVirtualDomain(name string, addresses []string) *xds_dns_table.DnsTable_DnsVirtualDomain {
+	return &xds_dns_table.DnsTable_DnsVirtualDomain{
+		Name: name,
+		Endpoint: &xds_dns_table.DnsTable_DnsEndpoint{
+			EndpointConfig: &xds_dns_table.DnsTable_DnsEndpoint_AddressList{
+				AddressList: &xds_dns_table.DnsTable_AddressList{
+					Address: addresses,
+				},
+			},
+		},
+		AnswerTtl: ptypes.DurationProto(dnsAnswerTTL),
+	}
+}
@@ -0,0 +1,79 @@
+package lds
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+// externalHostResolver resolves the external hosts answered by the DNS listeners of the proxies.
+// Their addresses are shared by the proxies, so they are resolved once per answer TTL rather than on each proxy update.
+var externalHostResolver = newHostResolver(net.DefaultResolver.LookupIPAddr, dnsAnswerTTL)
+
+// hostResolver is a cache of the addresses of external hosts
+type hostResolver struct {
+	lookupIPAddr func(ctx context.Context, host string) ([]net.IPAddr, error)
+	ttl          time.Duration
+
+	mu    sync.Mutex
+	hosts map[string]resolvedHost
+}
+
+type resolvedHost struct {
+	addresses []net.IP
+	expiresAt time.Time
+}
+
+func newHostResolver(lookupIPAddr func(ctx context.Context, host string) ([]net.IPAddr, error), ttl time.Duration) *hostResolver {
+	return &hostResolver{
+		lookupIPAddr: lookupIPAddr,
+		ttl:          ttl,
+		hosts:        make(map[string]resolvedHost),
+	}
+}
+
+// resolve returns the addresses of the given IP family of the host, from the cache unless they expired.
+// A host which cannot be resolved has no addresses.
+func (r *hostResolver) resolve(host string, ipFamily configurator.IPFamily) []string {
+	r.mu.Lock()
+	resolved, ok := r.hosts[host]
+	r.mu.Unlock()
+
+	if !ok || time.Now().After(resolved.expiresAt) {
+		ctx, cancel := context.WithTimeout(context.Background(), dnsResolverTimeout)
+		defer cancel()
+		ipAddrs, err := r.lookupIPAddr(ctx, host)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error resolving external host %s", host)
+			// Addresses resolved before are served until the host resolves again
+			if ok {
+				return filterIPFamily(resolved.addresses, ipFamily)
+			}
+			return nil
+		}
+
+		resolved = resolvedHost{expiresAt: time.Now().Add(r.ttl)}
+		for _, ipAddr := range ipAddrs {
+			resolved.addresses = append(resolved.addresses, ipAddr.IP)
+		}
+		r.mu.Lock()
+		r.hosts[host] = resolved
+		r.mu.Unlock()
+	}
+	return filterIPFamily(resolved.addresses, ipFamily)
+}
+
+func filterIPFamily(ips []net.IP, ipFamily configurator.IPFamily) []string {
+	var addresses []string
+	for _, ip := range ips {
+		isIPv4 := ip.To4() != nil
+		if (ipFamily == configurator.IPv4 && !isIPv4) || (ipFamily == configurator.IPv6 && isIPv4) {
+			continue
+		}
+		addresses = append(addresses, ip.String())
+	}
+	return addresses
+}
@@ -0,0 +1,178 @@
+package lds
+
+import (
+	"context"
+	"net"
+	"regexp"
+	"time"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_dns_table "github.com/envoyproxy/go-control-plane/envoy/data/dns/v3"
+	xds_dns_filter "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/udp/dns_filter/v3alpha"
+	"github.com/golang/protobuf/ptypes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// dnsMeshSpec assigns cluster IPs to the services of the fake mesh spec
+type dnsMeshSpec struct {
+	smi.MeshSpec
+	clusterIPs map[service.MeshService]string
+}
+
+func (s dnsMeshSpec) GetService(svc service.MeshService) (*corev1.Service, error) {
+	k8sService := tests.NewServiceFixture(svc.Name, svc.Namespace, nil)
+	k8sService.Spec.ClusterIP = s.clusterIPs[svc]
+	return k8sService, nil
+}
+
+// dnsMeshCatalog allows outbound traffic to a fixed set of services
+type dnsMeshCatalog struct {
+	catalog.MeshCataloger
+	meshSpec        smi.MeshSpec
+	allowedServices []service.MeshService
+}
+
+func (c dnsMeshCatalog) GetSMISpec() smi.MeshSpec {
+	return c.meshSpec
+}
+
+func (c dnsMeshCatalog) ListAllowedOutboundServices(service.MeshService) ([]service.MeshService, error) {
+	return c.allowedServices, nil
+}
+
+func (c dnsMeshCatalog) GetTLSOriginationPolicies(service.MeshService) []trafficpolicy.TLSOrigination {
+	return []trafficpolicy.TLSOrigination{{Host: "httpbin.org", Port: 443}}
+}
+
+var _ = Describe("Construct DNS listener", func() {
+	headlessService := service.MeshService{
+		Namespace: tests.Namespace,
+		Name:      "headless",
+	}
+	meshCatalog := dnsMeshCatalog{
+		MeshCataloger: catalog.NewFakeMeshCatalog(fake.NewSimpleClientset()),
+		meshSpec: dnsMeshSpec{
+			clusterIPs: map[service.MeshService]string{
+				tests.BookstoreService: "10.0.0.10",
+				headlessService:        corev1.ClusterIPNone,
+			},
+		},
+		allowedServices: []service.MeshService{tests.BookstoreService, headlessService},
+	}
+
+	Context("Test newDNSListener()", func() {
+		var defaultResolver *hostResolver
+
+		BeforeEach(func() {
+			defaultResolver = externalHostResolver
+			externalHostResolver = newHostResolver(func(context.Context, string) ([]net.IPAddr, error) {
+				return []net.IPAddr{{IP: net.ParseIP("3.3.3.3")}}, nil
+			}, time.Hour)
+		})
+
+		AfterEach(func() {
+			externalHostResolver = defaultResolver
+		})
+
+		It("listens on the DNS port over UDP", func() {
+			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{IPFamily: configurator.DualStack})
+			listener, err := newDNSListener(meshCatalog, tests.BookbuyerService, cfg)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(listener.Name).To(Equal(dnsListenerName))
+			socketAddress := listener.Address.GetSocketAddress()
+			Expect(socketAddress.Protocol).To(Equal(xds_core.SocketAddress_UDP))
+			Expect(socketAddress.Address).To(Equal(constants.WildcardIPv6Addr))
+			Expect(socketAddress.Ipv4Compat).To(BeTrue())
+			Expect(socketAddress.GetPortValue()).To(Equal(uint32(constants.EnvoyDNSListenerPort)))
+
+			Expect(len(listener.ListenerFilters)).To(Equal(1))
+			Expect(listener.ListenerFilters[0].Name).To(Equal(dnsFilterName))
+
+			dnsFilter := &xds_dns_filter.DnsFilterConfig{}
+			err = ptypes.UnmarshalAny(listener.ListenerFilters[0].GetTypedConfig(), dnsFilter)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dnsFilter.ClientConfig.UpstreamResolvers).To(BeEmpty())
+			Expect(len(dnsFilter.ServerConfig.GetInlineDnsTable().VirtualDomains)).To(Equal(2))
+		})
+	})
+
+	Context("Test getDNSTable()", func() {
+		var lookups []string
+		var resolver *hostResolver
+
+		BeforeEach(func() {
+			lookups = nil
+			resolver = newHostResolver(func(_ context.Context, host string) ([]net.IPAddr, error) {
+				lookups = append(lookups, host)
+				return []net.IPAddr{{IP: net.ParseIP("3.3.3.3")}, {IP: net.ParseIP("2001:db8::3")}}, nil
+			}, time.Hour)
+		})
+
+		It("resolves the allowed outbound services to their cluster IPs", func() {
+			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{IPFamily: configurator.IPv4})
+			dnsTable, err := getDNSTable(meshCatalog, tests.BookbuyerService, cfg, resolver)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(len(dnsTable.VirtualDomains)).To(Equal(2))
+			Expect(dnsTable.VirtualDomains[0]).To(Equal(&xds_dns_table.DnsTable_DnsVirtualDomain{
+				Name: "bookstore.default.svc.cluster.local",
+				Endpoint: &xds_dns_table.DnsTable_DnsEndpoint{
+					EndpointConfig: &xds_dns_table.DnsTable_DnsEndpoint_AddressList{
+						AddressList: &xds_dns_table.DnsTable_AddressList{
+							Address: []string{"10.0.0.10"},
+						},
+					},
+				},
+				AnswerTtl: ptypes.DurationProto(30 * time.Second),
+			}))
+		})
+
+		It("only resolves the TLS origination hosts without egress", func() {
+			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{IPFamily: configurator.IPv4})
+			dnsTable, err := getDNSTable(meshCatalog, tests.BookbuyerService, cfg, resolver)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lookups).To(Equal([]string{"httpbin.org"}))
+
+			Expect(len(dnsTable.VirtualDomains)).To(Equal(2))
+			Expect(dnsTable.VirtualDomains[1].Name).To(Equal("httpbin.org"))
+			Expect(dnsTable.VirtualDomains[1].Endpoint.GetAddressList().Address).To(Equal([]string{"3.3.3.3"}))
+
+			// External names match the known suffixes, which are answered with NXDOMAIN when not in the table
+			Expect(len(dnsTable.KnownSuffixes)).To(Equal(1))
+			externalName := regexp.MustCompile(dnsTable.KnownSuffixes[0].GetSafeRegex().Regex)
+			Expect(externalName.MatchString("httpbin.org")).To(BeTrue())
+			Expect(externalName.MatchString("evil.example.com")).To(BeTrue())
+			Expect(externalName.MatchString("bookstore.default.svc.cluster.local")).To(BeFalse())
+			Expect(externalName.MatchString("headless.default.svc.cluster.local")).To(BeFalse())
+		})
+
+		It("resolves the external hosts allowed by the egress policies", func() {
+			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{Egress: true, IPFamily: configurator.IPv4})
+			dnsTable, err := getDNSTable(meshCatalog, tests.BookbuyerService, cfg, resolver)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dnsTable.KnownSuffixes).To(BeEmpty())
+
+			Expect(len(dnsTable.VirtualDomains)).To(Equal(2))
+			Expect(dnsTable.VirtualDomains[1].Name).To(Equal("httpbin.org"))
+			Expect(dnsTable.VirtualDomains[1].Endpoint.GetAddressList().Address).To(Equal([]string{"3.3.3.3"}))
+
+			// Resolved addresses are cached
+			_, err = getDNSTable(meshCatalog, tests.BookbuyerService, cfg, resolver)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lookups).To(Equal([]string{"httpbin.org"}))
+		})
+	})
+})
@@ -14,21 +14,31 @@ import (
 	"github.com/openservicemesh/osm/pkg/service"
 )
 
+// isIngressTLS returns true if the traffic from ingress to backend pods is encrypted
+func isIngressTLS(cfg configurator.Configurator) bool {
+	return cfg.UseHTTPSIngress() || cfg.UseMTLSIngress()
+}
+
 func getIngressTransportProtocol(cfg configurator.Configurator) string {
-	if cfg.UseHTTPSIngress() {
+	if isIngressTLS(cfg) {
 		return envoy.TransportProtocolTLS
 	}
 	return ""
 }
 
 func newIngressFilterChain(cfg configurator.Configurator, svc service.MeshService) *xds_listener.FilterChain {
-	marshalledDownstreamTLSContext, err := envoy.MessageToAny(envoy.GetDownstreamTLSContext(svc, false /* TLS */))
+	marshalledDownstreamTLSContext, err := envoy.MessageToAny(envoy.GetDownstreamTLSContext(svc, cfg.UseMTLSIngress()))
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling DownstreamTLSContext object for proxy %s", svc)
 		return nil
 	}
 
 	inboundConnManager := getHTTPConnectionManager(route.InboundRouteConfigName, cfg)
+	if cfg.UseMTLSIngress() {
+		// With mTLS ingress the client certificate presented by the ingress has been validated
+		// against the allowed inbound SANs; forward its details to the backend in the XFCC header.
+		setForwardClientCertDetails(inboundConnManager)
+	}
 	marshalledInboundConnManager, err := ptypes.MarshalAny(inboundConnManager)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling inbound HttpConnectionManager object for proxy %s", svc)
@@ -55,7 +65,7 @@ func newIngressFilterChain(cfg configurator.Configurator, svc service.MeshServic
 func getIngressFilterChains(svc service.MeshService, cfg configurator.Configurator) []*xds_listener.FilterChain {
 	var ingressFilterChains []*xds_listener.FilterChain
 
-	if cfg.UseHTTPSIngress() {
+	if isIngressTLS(cfg) {
 		// Filter chain with SNI matching enabled for HTTPS clients that set the SNI
 		ingressFilterChainWithSNI := newIngressFilterChain(cfg, svc)
 		ingressFilterChainWithSNI.FilterChainMatch.ServerNames = []string{svc.GetCommonName().String()}
@@ -70,7 +80,7 @@ func getIngressFilterChains(svc service.MeshService, cfg configurator.Configurat
 }
 
 func getIngressTransportSocket(cfg configurator.Configurator, marshalledDownstreamTLSContext *any.Any) *xds_core.TransportSocket {
-	if cfg.UseHTTPSIngress() {
+	if isIngressTLS(cfg) {
 		return &xds_core.TransportSocket{
 			Name: wellknown.TransportSocketTls,
 			ConfigType: &xds_core.TransportSocket_TypedConfig{
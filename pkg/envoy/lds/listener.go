@@ -23,8 +23,16 @@ import (
 const (
 	outboundMeshFilterChainName   = "outbound-mesh-filter-chain"
 	outboundEgressFilterChainName = "outbound-egress-filter-chain"
+
+	outboundTLSOriginationFilterChainName = "outbound-tls-origination-filter-chain"
+
+	// transportProtocolRawBuffer is the transport protocol of plaintext connections detected by the TlsInspector ListenerFilter
+	transportProtocolRawBuffer = "raw_buffer"
 )
 
+// plaintextHTTPProtocols are the application protocols of plaintext HTTP connections detected by the HttpInspector ListenerFilter
+var plaintextHTTPProtocols = []string{"http/1.0", "http/1.1", "h2c"}
+
 func newOutboundListener(cfg configurator.Configurator) (*xds_listener.Listener, error) {
 	connManager := getHTTPConnectionManager(route.OutboundRouteConfigName, cfg)
 
@@ -36,7 +44,7 @@ func newOutboundListener(cfg configurator.Configurator) (*xds_listener.Listener,
 
 	outboundListener := &xds_listener.Listener{
 		Name:             outboundListenerName,
-		Address:          envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyOutboundListenerPort),
+		Address:          envoy.GetListenerAddress(cfg.GetIPFamily(), constants.EnvoyOutboundListenerPort),
 		TrafficDirection: xds_core.TrafficDirection_OUTBOUND,
 		FilterChains: []*xds_listener.FilterChain{
 			{
@@ -101,10 +109,44 @@ func updateOutboundListenerForEgress(outboundListener *xds_listener.Listener, cf
 	return nil
 }
 
-func newInboundListener() *xds_listener.Listener {
+// updateOutboundListenerForTLSOrigination routes plaintext HTTP egress traffic with the outbound route configuration,
+// so requests to the external hosts of egress TLS origination policies are upgraded to TLS.
+// This is only needed with egress, since otherwise all outbound HTTP traffic matches the in-mesh filter chain.
+func updateOutboundListenerForTLSOrigination(outboundListener *xds_listener.Listener, cfg configurator.Configurator) error {
+	connManager := getHTTPConnectionManager(route.OutboundRouteConfigName, cfg)
+	marshalledConnManager, err := ptypes.MarshalAny(connManager)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling HttpConnectionManager object for TLS origination filter chain")
+		return err
+	}
+
+	outboundListener.FilterChains = append(outboundListener.FilterChains, &xds_listener.FilterChain{
+		Name: outboundTLSOriginationFilterChainName,
+		FilterChainMatch: &xds_listener.FilterChainMatch{
+			TransportProtocol:    transportProtocolRawBuffer,
+			ApplicationProtocols: plaintextHTTPProtocols,
+		},
+		Filters: []*xds_listener.Filter{
+			{
+				Name: wellknown.HTTPConnectionManager,
+				ConfigType: &xds_listener.Filter_TypedConfig{
+					TypedConfig: marshalledConnManager,
+				},
+			},
+		},
+	})
+	outboundListener.ListenerFilters = append(outboundListener.ListenerFilters, &xds_listener.ListenerFilter{
+		// The HttpInspector ListenerFilter is used to detect plaintext HTTP traffic
+		Name: wellknown.HttpInspector,
+	})
+
+	return nil
+}
+
+func newInboundListener(ipFamily configurator.IPFamily) *xds_listener.Listener {
 	return &xds_listener.Listener{
 		Name:             inboundListenerName,
-		Address:          envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyInboundListenerPort),
+		Address:          envoy.GetListenerAddress(ipFamily, constants.EnvoyInboundListenerPort),
 		TrafficDirection: xds_core.TrafficDirection_INBOUND,
 		FilterChains:     []*xds_listener.FilterChain{},
 		ListenerFilters: []*xds_listener.ListenerFilter{
@@ -115,7 +157,7 @@ func newInboundListener() *xds_listener.Listener {
 	}
 }
 
-func buildPrometheusListener(connManager *xds_hcm.HttpConnectionManager) (*xds_listener.Listener, error) {
+func buildPrometheusListener(connManager *xds_hcm.HttpConnectionManager, ipFamily configurator.IPFamily) (*xds_listener.Listener, error) {
 	marshalledConnManager, err := ptypes.MarshalAny(connManager)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling HttpConnectionManager object")
@@ -125,7 +167,7 @@ func buildPrometheusListener(connManager *xds_hcm.HttpConnectionManager) (*xds_l
 	return &xds_listener.Listener{
 		Name:             prometheusListenerName,
 		TrafficDirection: xds_core.TrafficDirection_INBOUND,
-		Address:          envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyPrometheusInboundListenerPort),
+		Address:          envoy.GetListenerAddress(ipFamily, constants.EnvoyPrometheusInboundListenerPort),
 		FilterChains: []*xds_listener.FilterChain{
 			{
 				Filters: []*xds_listener.Filter{
@@ -111,12 +111,21 @@ var _ = Describe("Construct inbound and outbound listeners", func() {
 
 	Context("Test creation of inbound listener", func() {
 		It("Tests the inbound listener config", func() {
-			listener := newInboundListener()
+			listener := newInboundListener(configurator.IPv4)
 			Expect(listener.Address).To(Equal(envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyInboundListenerPort)))
 			Expect(len(listener.ListenerFilters)).To(Equal(1)) // tls-inpsector listener filter
 			Expect(listener.ListenerFilters[0].Name).To(Equal(wellknown.TlsInspector))
 			Expect(listener.TrafficDirection).To(Equal(xds_core.TrafficDirection_INBOUND))
 		})
+
+		It("Listens on the addresses of the IP family of the pod", func() {
+			listener := newInboundListener(configurator.IPv6)
+			Expect(listener.Address).To(Equal(envoy.GetAddress(constants.WildcardIPv6Addr, constants.EnvoyInboundListenerPort)))
+
+			listener = newInboundListener(configurator.DualStack)
+			Expect(listener.Address.GetSocketAddress().Address).To(Equal(constants.WildcardIPv6Addr))
+			Expect(listener.Address.GetSocketAddress().Ipv4Compat).To(BeTrue())
+		})
 	})
 
 	Context("Test creation of HTTP connection manager", func() {
@@ -138,7 +147,7 @@ var _ = Describe("Construct inbound and outbound listeners", func() {
 	Context("Test creation of Prometheus listener", func() {
 		It("Tests the Prometheus listener config", func() {
 			connManager := getPrometheusConnectionManager("fake-prometheus", constants.PrometheusScrapePath, constants.EnvoyMetricsCluster)
-			listener, _ := buildPrometheusListener(connManager)
+			listener, _ := buildPrometheusListener(connManager, configurator.IPv4)
 			Expect(listener.Address).To(Equal(envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyPrometheusInboundListenerPort)))
 			Expect(len(listener.ListenerFilters)).To(Equal(0)) //  no listener filters
 			Expect(listener.TrafficDirection).To(Equal(xds_core.TrafficDirection_INBOUND))
@@ -0,0 +1,143 @@
+package lds
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/multicluster"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	multiclusterGatewayListenerName = "multicluster_gateway_listener"
+)
+
+// NewMulticlusterGatewayResponse creates a new Listener Discovery Response for the multicluster gateway.
+// The gateway listens on each port of the exported services, and terminates mTLS from other meshes, with a
+// filter chain per exported service matched by the SNI of its mirrored name. The traffic is then proxied to
+// the same port of the exported service.
+func NewMulticlusterGatewayResponse(_ context.Context, catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_discovery.DiscoveryRequest, cfg configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
+	resp := &xds_discovery.DiscoveryResponse{
+		TypeUrl: string(envoy.TypeLDS),
+	}
+
+	exportedServices, err := catalog.ListExportedServices()
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing exported services for multicluster gateway %s", proxy.GetCommonName())
+		return nil, err
+	}
+
+	gatewayListeners := make(map[uint32]*xds_listener.Listener)
+	for _, exportedService := range exportedServices {
+		ports, err := catalog.ListExportedServicePorts(exportedService)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error listing ports of exported service %s", exportedService)
+			continue
+		}
+		for _, port := range ports {
+			filterChain, err := getMulticlusterGatewayFilterChain(exportedService, port, cfg.GetClusterName())
+			if err != nil {
+				log.Error().Err(err).Msgf("Error making multicluster gateway filter chain for service %s", exportedService)
+				continue
+			}
+			if _, ok := gatewayListeners[port]; !ok {
+				gatewayListeners[port] = newMulticlusterGatewayListener(cfg.GetIPFamily(), port)
+			}
+			gatewayListeners[port].FilterChains = append(gatewayListeners[port].FilterChains, filterChain)
+		}
+	}
+
+	// Configuring a listener without a filter chain is an error
+	if len(gatewayListeners) == 0 {
+		log.Debug().Msgf("No services are exported through multicluster gateway %s", proxy.GetCommonName())
+		return resp, nil
+	}
+
+	var ports []uint32
+	for port := range gatewayListeners {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+
+	for _, port := range ports {
+		marshalledListener, err := ptypes.MarshalAny(gatewayListeners[port])
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling multicluster gateway listener config for proxy %s", proxy.GetCommonName())
+			return nil, err
+		}
+		resp.Resources = append(resp.Resources, marshalledListener)
+	}
+
+	return resp, nil
+}
+
+func newMulticlusterGatewayListener(ipFamily configurator.IPFamily, port uint32) *xds_listener.Listener {
+	return &xds_listener.Listener{
+		Name:             fmt.Sprintf("%s_%d", multiclusterGatewayListenerName, port),
+		Address:          envoy.GetListenerAddress(ipFamily, port),
+		TrafficDirection: xds_core.TrafficDirection_INBOUND,
+		FilterChains:     []*xds_listener.FilterChain{},
+		ListenerFilters: []*xds_listener.ListenerFilter{
+			{
+				Name: wellknown.TlsInspector,
+			},
+		},
+	}
+}
+
+func getMulticlusterGatewayFilterChain(exportedService service.MeshService, port uint32, clusterName string) (*xds_listener.FilterChain, error) {
+	// Other meshes address the exported service by its mirrored name. The gateway presents the certificate
+	// for this name and requires clients to present a certificate issued by the shared root certificate.
+	mirroredService := multicluster.GetMirroredService(exportedService, clusterName)
+	marshalledDownstreamTLSContext, err := envoy.MessageToAny(envoy.GetDownstreamTLSContext(mirroredService, true /* mTLS */))
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling DownstreamTLSContext object for mirrored service %s", mirroredService)
+		return nil, err
+	}
+
+	gatewayClusterName := multicluster.GetGatewayClusterName(exportedService, port)
+	tcpProxy := &xds_tcp_proxy.TcpProxy{
+		StatPrefix:       gatewayClusterName,
+		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: gatewayClusterName},
+	}
+	marshalledTCPProxy, err := envoy.MessageToAny(tcpProxy)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling TcpProxy object for exported service %s", exportedService)
+		return nil, err
+	}
+
+	return &xds_listener.FilterChain{
+		Name: mirroredService.String(),
+		Filters: []*xds_listener.Filter{
+			{
+				Name:       wellknown.TCPProxy,
+				ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledTCPProxy},
+			},
+		},
+
+		// Route the traffic to the exported service based on the SNI of its mirrored name
+		FilterChainMatch: &xds_listener.FilterChainMatch{
+			ServerNames:       []string{mirroredService.GetCommonName().String()},
+			TransportProtocol: envoy.TransportProtocolTLS,
+		},
+
+		TransportSocket: &xds_core.TransportSocket{
+			Name: wellknown.TransportSocketTls,
+			ConfigType: &xds_core.TransportSocket_TypedConfig{
+				TypedConfig: marshalledDownstreamTLSContext,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,54 @@
+package lds
+
+import (
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("Construct multicluster gateway listener", func() {
+	Context("Test newMulticlusterGatewayListener()", func() {
+		It("listens on the given port of the exported services and inspects TLS", func() {
+			listener := newMulticlusterGatewayListener(configurator.IPv4, tests.ServicePort)
+
+			Expect(listener.Name).To(Equal("multicluster_gateway_listener_8888"))
+			Expect(listener.Address).To(Equal(envoy.GetAddress(constants.WildcardIPAddr, tests.ServicePort)))
+			Expect(listener.TrafficDirection).To(Equal(xds_core.TrafficDirection_INBOUND))
+			Expect(len(listener.ListenerFilters)).To(Equal(1))
+			Expect(listener.ListenerFilters[0].Name).To(Equal(wellknown.TlsInspector))
+		})
+	})
+
+	Context("Test getMulticlusterGatewayFilterChain()", func() {
+		It("routes the mirrored name of the exported service to the cluster of its port", func() {
+			filterChain, err := getMulticlusterGatewayFilterChain(tests.BookstoreService, tests.ServicePort, "east")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(filterChain.FilterChainMatch.ServerNames).To(Equal([]string{"bookstore-east.default.svc.cluster.local"}))
+			Expect(filterChain.FilterChainMatch.TransportProtocol).To(Equal(envoy.TransportProtocolTLS))
+
+			Expect(len(filterChain.Filters)).To(Equal(1))
+			Expect(filterChain.Filters[0].Name).To(Equal(wellknown.TCPProxy))
+			tcpProxy := &xds_tcp_proxy.TcpProxy{}
+			err = ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), tcpProxy)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tcpProxy.GetCluster()).To(Equal("default/bookstore:8888"))
+
+			downstreamTLSContext := &xds_auth.DownstreamTlsContext{}
+			err = ptypes.UnmarshalAny(filterChain.TransportSocket.GetTypedConfig(), downstreamTLSContext)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(downstreamTLSContext.RequireClientCertificate.Value).To(BeTrue())
+			Expect(downstreamTLSContext.CommonTlsContext.TlsCertificateSdsSecretConfigs[0].Name).To(Equal("service-cert:default/bookstore-east"))
+		})
+	})
+})
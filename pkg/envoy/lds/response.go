@@ -24,6 +24,7 @@ const (
 // 1. Inbound listener to handle incoming traffic
 // 2. Outbound listener to handle outgoing traffic
 // 3. Prometheus listener for metrics
+// A DNS listener is added when DNS proxying is enabled.
 func NewResponse(ctx context.Context, catalog catalog.MeshCataloger, proxy *envoy.Proxy, request *xds_discovery.DiscoveryRequest, cfg configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
 	svc, err := catalog.GetServiceFromEnvoyCertificate(proxy.GetCommonName())
 	if err != nil {
@@ -40,6 +41,12 @@ func NewResponse(ctx context.Context, catalog catalog.MeshCataloger, proxy *envo
 	if outboundListener, err := newOutboundListener(cfg); err != nil {
 		log.Error().Err(err).Msgf("Error making outbound listener config for proxy %s", proxyServiceName)
 	} else {
+		if cfg.IsEgressEnabled() && len(catalog.GetTLSOriginationPolicies(proxyServiceName)) > 0 {
+			if err := updateOutboundListenerForTLSOrigination(outboundListener, cfg); err != nil {
+				// An error in TLS origination config should not disrupt other traffic, so only log an error
+				log.Error().Err(err).Msgf("Error building TLS origination config for outbound listener for proxy %s", proxyServiceName)
+			}
+		}
 		if marshalledOutbound, err := ptypes.MarshalAny(outboundListener); err != nil {
 			log.Error().Err(err).Msgf("Failed to marshal outbound listener config for proxy %s", proxyServiceName)
 		} else {
@@ -47,8 +54,19 @@ func NewResponse(ctx context.Context, catalog catalog.MeshCataloger, proxy *envo
 		}
 	}
 
+	// --- DNS -------------------
+	if cfg.IsDNSProxyEnabled() {
+		if dnsListener, err := newDNSListener(catalog, proxyServiceName, cfg); err != nil {
+			log.Error().Err(err).Msgf("Error making DNS listener config for proxy %s", proxyServiceName)
+		} else if marshalledDNS, err := ptypes.MarshalAny(dnsListener); err != nil {
+			log.Error().Err(err).Msgf("Failed to marshal DNS listener config for proxy %s", proxyServiceName)
+		} else {
+			resp.Resources = append(resp.Resources, marshalledDNS)
+		}
+	}
+
 	// --- INBOUND -------------------
-	inboundListener := newInboundListener()
+	inboundListener := newInboundListener(cfg.GetIPFamily())
 	if meshFilterChain, err := getInboundInMeshFilterChain(proxyServiceName, cfg); err != nil {
 		log.Error().Err(err).Msgf("Error making in-mesh filter chain for proxy %s", proxy.GetCommonName())
 	} else if meshFilterChain != nil {
@@ -86,7 +104,7 @@ func NewResponse(ctx context.Context, catalog catalog.MeshCataloger, proxy *envo
 	if cfg.IsPrometheusScrapingEnabled() {
 		// Build Prometheus listener config
 		prometheusConnManager := getPrometheusConnectionManager(prometheusListenerName, constants.PrometheusScrapePath, constants.EnvoyMetricsCluster)
-		if prometheusListener, err := buildPrometheusListener(prometheusConnManager); err != nil {
+		if prometheusListener, err := buildPrometheusListener(prometheusConnManager, cfg.GetIPFamily()); err != nil {
 			log.Error().Err(err).Msgf("Error building Prometheus listener config for proxy %s", proxyServiceName)
 		} else {
 			if marshalledPrometheus, err := ptypes.MarshalAny(prometheusListener); err != nil {
@@ -1,7 +1,10 @@
 package lds
 
 import (
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/openservicemesh/osm/pkg/configurator"
@@ -45,6 +48,37 @@ var _ = Describe("Test LDS response", func() {
 			Expect(len(filterChains[0].FilterChainMatch.ServerNames)).To(Equal(0)) // filter chain without SNI matching
 		})
 
+		It("constructs filter chain used for mTLS ingress", func() {
+			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{
+				MTLSIngress: true, // mTLS
+			})
+			filterChains := getIngressFilterChains(tests.BookstoreService, cfg)
+			Expect(len(filterChains)).To(Equal(2))
+			for _, filterChain := range filterChains {
+				Expect(filterChain.FilterChainMatch.TransportProtocol).To(Equal(envoy.TransportProtocolTLS))
+
+				// The ingress client certificate is required and validated against the allowed inbound SANs
+				tlsContext := &xds_auth.DownstreamTlsContext{}
+				err := ptypes.UnmarshalAny(filterChain.TransportSocket.GetTypedConfig(), tlsContext)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(tlsContext.RequireClientCertificate.GetValue()).To(BeTrue())
+				expectedValidationContext := envoy.SDSCert{
+					MeshService: tests.BookstoreService,
+					CertType:    envoy.RootCertTypeForMTLSInbound,
+				}.String()
+				Expect(tlsContext.CommonTlsContext.GetValidationContextSdsSecretConfig().Name).To(Equal(expectedValidationContext))
+
+				// The validated client certificate details are forwarded to the backend
+				Expect(len(filterChain.Filters)).To(Equal(1))
+				connManager := &xds_hcm.HttpConnectionManager{}
+				err = ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), connManager)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(connManager.ForwardClientCertDetails).To(Equal(xds_hcm.HttpConnectionManager_SANITIZE_SET))
+				Expect(connManager.SetCurrentClientCertDetails.Subject.GetValue()).To(BeTrue())
+				Expect(connManager.SetCurrentClientCertDetails.Uri).To(BeTrue())
+			}
+		})
+
 		It("constructs in-mesh filter chain", func() {
 			cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{})
 			filterChain, err := getInboundInMeshFilterChain(tests.BookstoreService, cfg)
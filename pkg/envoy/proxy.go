@@ -106,7 +106,8 @@ func NewProxy(cn certificate.CommonName, ip net.Addr) *Proxy {
 
 		connectedAt: time.Now(),
 
-		announcements:      make(chan interface{}),
+		// A single pending announcement is retained while the proxy is being updated; further ones coalesce into it
+		announcements:      make(chan interface{}, 1),
 		lastNonce:          make(map[TypeURI]string),
 		lastSentVersion:    make(map[TypeURI]uint64),
 		lastAppliedVersion: make(map[TypeURI]uint64),
@@ -71,6 +71,8 @@ func NewResponse(ctx context.Context, catalog catalog.MeshCataloger, proxy *envo
 		return nil, err
 	}
 
+	updateRoutesForTLSOrigination(proxyServiceName, catalog, cfg, sourceAggregatedRoutesByHostnames)
+
 	route.UpdateRouteConfiguration(sourceAggregatedRoutesByHostnames, sourceRouteConfig, true, false)
 	route.UpdateRouteConfiguration(destinationAggregatedRoutesByHostnames, destinationRouteConfig, false, true)
 	routeConfiguration = append(routeConfiguration, sourceRouteConfig)
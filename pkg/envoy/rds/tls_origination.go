@@ -0,0 +1,55 @@
+package rds
+
+import (
+	"fmt"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	// plaintextHTTPPort is the port of the plaintext HTTP requests upgraded to TLS
+	plaintextHTTPPort = 80
+
+	// wildcardHost matches the requests to hosts without a more specific virtual host
+	wildcardHost = "*"
+)
+
+// updateRoutesForTLSOrigination routes the plaintext HTTP requests to the external hosts of the egress TLS origination
+// policies to the clusters originating TLS connections to them
+func updateRoutesForTLSOrigination(svc service.MeshService, catalog catalog.MeshCataloger, cfg configurator.Configurator, routesPerHost map[string]map[string]trafficpolicy.RouteWeightedClusters) {
+	tlsOriginations := catalog.GetTLSOriginationPolicies(svc)
+	if len(tlsOriginations) == 0 {
+		return
+	}
+
+	allowAllRoute := trafficpolicy.Route{
+		PathRegex: constants.RegexMatchAll,
+		Methods:   []string{constants.WildcardHTTPMethod},
+	}
+
+	for _, tlsOrigination := range tlsOriginations {
+		weightedCluster := service.WeightedCluster{
+			ClusterName: tlsOrigination.GetClusterName(),
+			Weight:      constants.ClusterWeightAcceptAll,
+		}
+		hosts := fmt.Sprintf("%s,%s:%d", tlsOrigination.Host, tlsOrigination.Host, plaintextHTTPPort)
+		aggregateRoutesByHost(routesPerHost, allowAllRoute, weightedCluster, hosts)
+	}
+
+	// With egress, plaintext HTTP requests to external hosts are routed by the outbound route configuration.
+	// The requests to the hosts without a TLS origination policy are passed through to their original destination.
+	if cfg.IsEgressEnabled() {
+		passthroughCluster := service.WeightedCluster{
+			ClusterName: service.ClusterName(envoy.OutboundPassthroughCluster),
+			Weight:      constants.ClusterWeightAcceptAll,
+		}
+		aggregateRoutesByHost(routesPerHost, allowAllRoute, passthroughCluster, wildcardHost)
+	}
+
+	log.Trace().Msgf("TLS origination routes for service %s: %+v", svc, routesPerHost)
+}
@@ -0,0 +1,149 @@
+package sds
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/multicluster"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// NewMulticlusterGatewayResponse creates a new Secrets Discovery Response for the multicluster gateway.
+// The gateway is served the certificates of the mirrored names of the exported services, which it presents
+// to other meshes, and its own certificate, which it presents to the exported services.
+func NewMulticlusterGatewayResponse(_ context.Context, catalog catalog.MeshCataloger, proxy *envoy.Proxy, request *xds_discovery.DiscoveryRequest, cfg configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
+	log.Info().Msgf("Composing SDS Discovery Response for multicluster gateway: %s", proxy.GetCommonName())
+
+	gatewayService := multicluster.GetGatewayService(cfg.GetOSMNamespace())
+	mirroredServices, err := getMirroredServices(catalog, cfg.GetClusterName())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing mirrored services for multicluster gateway %s", proxy.GetCommonName())
+		return nil, err
+	}
+
+	var resources []*any.Any
+	for _, requestedCertificate := range request.ResourceNames {
+		sdsCert, err := envoy.UnmarshalSDSCert(requestedCertificate)
+		if err != nil {
+			log.Error().Err(err).Msgf("Invalid resource kind requested: %q", requestedCertificate)
+			continue
+		}
+
+		isGatewayCert := sdsCert.MeshService == gatewayService
+		if _, isMirroredCert := mirroredServices[sdsCert.MeshService]; !isGatewayCert && !isMirroredCert {
+			log.Error().Msgf("Multicluster gateway %s requested certificate %s; this is not allowed", proxy.GetCommonName(), requestedCertificate)
+			continue
+		}
+
+		cert, err := catalog.GetCertificateForService(sdsCert.MeshService)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error obtaining a certificate for service %s", sdsCert.MeshService)
+			continue
+		}
+
+		var envoySecret *xds_auth.Secret
+		switch {
+		case sdsCert.CertType == envoy.ServiceCertType:
+			envoySecret, err = getServiceCertSecret(cert, requestedCertificate)
+
+		case sdsCert.CertType == envoy.RootCertTypeForMTLSOutbound && isGatewayCert:
+			// Connections to the exported services verify the SANs of the certificates they present
+			envoySecret, err = getRootCert(cert, *sdsCert, gatewayService, catalog)
+
+		case sdsCert.CertType == envoy.RootCertTypeForMTLSInbound && !isGatewayCert:
+			// Connections from other meshes are verified against the root certificates of this mesh and of the
+			// remote clusters alone, the services of other meshes are not known to this mesh.
+			trustedCAs := cfg.GetMulticlusterTrustedCAs()
+			envoySecret = getTrustedCASecret(requestedCertificate, getTrustedCABundle(cert.GetIssuingCA(), trustedCAs, getRemoteClusterNames(trustedCAs)))
+
+		default:
+			log.Debug().Msgf("Multicluster gateway %s does not use certificate %s", proxy.GetCommonName(), requestedCertificate)
+			continue
+		}
+		if err != nil {
+			log.Error().Err(err).Msgf("Error creating cert %s for multicluster gateway %s", requestedCertificate, proxy.GetCommonName())
+			continue
+		}
+
+		marshalledSecret, err := ptypes.MarshalAny(envoySecret)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshaling Envoy secret %s for multicluster gateway %s", envoySecret.Name, proxy.GetCommonName())
+			continue
+		}
+		resources = append(resources, marshalledSecret)
+	}
+
+	return &xds_discovery.DiscoveryResponse{
+		TypeUrl:   string(envoy.TypeSDS),
+		Resources: resources,
+	}, nil
+}
+
+// getMirroredServices returns the set of names other meshes address the exported services by
+func getMirroredServices(catalog catalog.MeshCataloger, clusterName string) (map[service.MeshService]interface{}, error) {
+	exportedServices, err := catalog.ListExportedServices()
+	if err != nil {
+		return nil, err
+	}
+
+	mirroredServices := make(map[service.MeshService]interface{})
+	for _, exportedService := range exportedServices {
+		mirroredServices[multicluster.GetMirroredService(exportedService, clusterName)] = nil
+	}
+	return mirroredServices, nil
+}
+
+// getTrustedCASecret creates the validation context trusting the given CA bundle.
+func getTrustedCASecret(name string, caBundle []byte) *xds_auth.Secret {
+	return &xds_auth.Secret{
+		Name: name,
+		Type: &xds_auth.Secret_ValidationContext{
+			ValidationContext: &xds_auth.CertificateValidationContext{
+				TrustedCa: &xds_core.DataSource{
+					Specifier: &xds_core.DataSource_InlineBytes{
+						InlineBytes: caBundle,
+					},
+				},
+			},
+		},
+	}
+}
+
+// getRemoteClusterNames returns the sorted names of the remote clusters with the given root certificates
+func getRemoteClusterNames(trustedCAs map[string][]byte) []string {
+	var clusterNames []string
+	for clusterName := range trustedCAs {
+		clusterNames = append(clusterNames, clusterName)
+	}
+	sort.Strings(clusterNames)
+	return clusterNames
+}
+
+// getTrustedCABundle returns the given issuing CA followed by the root certificates of the given remote clusters.
+func getTrustedCABundle(issuingCA []byte, trustedCAs map[string][]byte, clusterNames []string) []byte {
+	var bundle bytes.Buffer
+	bundle.Write(issuingCA)
+	for _, clusterName := range clusterNames {
+		remoteCA, ok := trustedCAs[clusterName]
+		if !ok {
+			continue
+		}
+		if bundle.Len() > 0 && !bytes.HasSuffix(bundle.Bytes(), []byte("\n")) {
+			bundle.WriteByte('\n')
+		}
+		bundle.Write(remoteCA)
+	}
+	return bundle.Bytes()
+}
@@ -48,7 +48,7 @@ func NewResponse(_ context.Context, catalog catalog.MeshCataloger, proxy *envoy.
 	log.Trace().Msgf("Received SDS request for ResourceNames (certificates) %+v", requestedCerts)
 
 	// request.ResourceNames is expected to be a list of either "service-cert:namespace/service" or "root-cert:namespace/service"
-	for _, envoyProto := range getEnvoySDSSecrets(cert, proxy, requestedCerts, catalog) {
+	for _, envoyProto := range getEnvoySDSSecrets(cert, proxy, requestedCerts, catalog, cfg) {
 		marshalledSecret, err := ptypes.MarshalAny(envoyProto)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error marshaling Envoy secret %s for proxy %s for service %s", envoyProto.Name, proxy.GetCommonName(), serviceForProxy.String())
@@ -63,7 +63,7 @@ func NewResponse(_ context.Context, catalog catalog.MeshCataloger, proxy *envoy.
 	}, nil
 }
 
-func getEnvoySDSSecrets(cert certificate.Certificater, proxy *envoy.Proxy, requestedCerts []string, catalog catalog.MeshCataloger) []*xds_auth.Secret {
+func getEnvoySDSSecrets(cert certificate.Certificater, proxy *envoy.Proxy, requestedCerts []string, catalog catalog.MeshCataloger, cfg configurator.Configurator) []*xds_auth.Secret {
 	// requestedCerts is expected to be a list of either "service-cert:namespace/service" or "root-cert:namespace/service"
 
 	var envoySecrets []*xds_auth.Secret
@@ -110,12 +110,34 @@ func getEnvoySDSSecrets(cert certificate.Certificater, proxy *envoy.Proxy, reque
 				log.Error().Err(err).Msgf("Error creating cert %s for proxy %s for service %s", requestedCertificate, proxy.GetCommonName(), serviceForProxy.String())
 				continue
 			}
+			if sdsCert.CertType == envoy.RootCertTypeForMTLSOutbound {
+				addRemoteClusterCAs(envoySecret, serviceForProxy, catalog, cfg)
+			}
 			envoySecrets = append(envoySecrets, envoySecret)
 		}
 	}
 	return envoySecrets
 }
 
+// addRemoteClusterCAs adds the root certificates of the remote clusters, the mirrored services of which the given
+// service is allowed to connect to, to the given outbound validation context. The gateways of the remote clusters
+// present certificates issued by their own root certificate for the mirrored services.
+func addRemoteClusterCAs(secret *xds_auth.Secret, svc service.MeshService, catalog catalog.MeshCataloger, cfg configurator.Configurator) {
+	trustedCAs := cfg.GetMulticlusterTrustedCAs()
+	if len(trustedCAs) == 0 {
+		return
+	}
+	remoteClusters, err := catalog.ListAllowedOutboundRemoteClusters(svc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing the remote clusters service %s is allowed to connect to", svc)
+		return
+	}
+	trustedCA := secret.GetValidationContext().GetTrustedCa()
+	trustedCA.Specifier = &xds_core.DataSource_InlineBytes{
+		InlineBytes: getTrustedCABundle(trustedCA.GetInlineBytes(), trustedCAs, remoteClusters),
+	}
+}
+
 // getServiceCertSecret creates the struct with certificates for the service, which the
 // connected Envoy proxy belongs to.
 func getServiceCertSecret(cert certificate.Certificater, name string) (*xds_auth.Secret, error) {
@@ -19,6 +19,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -131,7 +132,7 @@ var _ = Describe("Test SDS response functions", func() {
 			resourceNames := []string{sdsc.String()}
 			cert, proxy, mc := prep(resourceNames, namespace, serviceName)
 
-			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc)
+			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc, configurator.NewFakeConfigurator())
 
 			Expect(len(actual)).To(Equal(1))
 			Expect(actual[0].Name).To(Equal(sdsc.String()))
@@ -146,7 +147,7 @@ var _ = Describe("Test SDS response functions", func() {
 			resourceNames := []string{fmt.Sprintf("root-cert-https:%s/%s", namespace, serviceName)}
 			cert, proxy, mc := prep(resourceNames, namespace, serviceName)
 
-			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc)
+			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc, configurator.NewFakeConfigurator())
 
 			Expect(len(actual)).To(Equal(1))
 			Expect(actual[0].Name).To(Equal(fmt.Sprintf("root-cert-https:%s/%s", namespace, serviceName)))
@@ -161,7 +162,7 @@ var _ = Describe("Test SDS response functions", func() {
 			resourceNames := []string{fmt.Sprintf("service-cert:%s/%s", namespace, serviceName)}
 			cert, proxy, mc := prep(resourceNames, namespace, serviceName)
 
-			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc)
+			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc, configurator.NewFakeConfigurator())
 
 			Expect(len(actual)).To(Equal(1))
 			Expect(actual[0].Name).To(Equal(fmt.Sprintf("service-cert:%s/%s", namespace, serviceName)))
@@ -176,10 +177,27 @@ var _ = Describe("Test SDS response functions", func() {
 			resourceNames := []string{"service-cert:SomeOtherNamespace/SomeOtherService"}
 			cert, proxy, mc := prep(resourceNames, namespace, serviceName)
 
-			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc)
+			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc, configurator.NewFakeConfigurator())
 
 			Expect(len(actual)).To(Equal(0))
 		})
 	})
 
+	Context("Test getTrustedCABundle()", func() {
+		trustedCAs := map[string][]byte{
+			"west":  []byte("west-ca\n"),
+			"north": []byte("north-ca\n"),
+		}
+
+		It("appends the root certificates of the given remote clusters to the issuing CA", func() {
+			Expect(string(getTrustedCABundle([]byte("mesh-ca"), trustedCAs, []string{"west", "east"}))).To(Equal("mesh-ca\nwest-ca\n"))
+			Expect(string(getTrustedCABundle([]byte("mesh-ca\n"), trustedCAs, nil))).To(Equal("mesh-ca\n"))
+		})
+
+		It("trusts every remote cluster in the validation context of the multicluster gateway", func() {
+			bundle := getTrustedCABundle([]byte("mesh-ca\n"), trustedCAs, getRemoteClusterNames(trustedCAs))
+			secret := getTrustedCASecret("root-cert-for-mtls-inbound:ns/svc", bundle)
+			Expect(string(secret.GetValidationContext().GetTrustedCa().GetInlineBytes())).To(Equal("mesh-ca\nnorth-ca\nwest-ca\n"))
+		})
+	})
 })
@@ -0,0 +1,7 @@
+package snapshot
+
+import "github.com/pkg/errors"
+
+var (
+	errInvalidCommonName = errors.New("Invalid proxy certificate common name for a snapshot file")
+)
@@ -0,0 +1,274 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+const (
+	snapshotFileExtension = ".json"
+
+	// maxSnapshotAge is the age after which the snapshot of a proxy is considered gone with its pod
+	maxSnapshotAge = 24 * time.Hour
+
+	// persistInterval is how often the updated snapshots are written. The responses sent to a proxy
+	// meanwhile are coalesced into a single write of its snapshot file.
+	persistInterval = 5 * time.Second
+
+	// pruneInterval is how often the stale snapshots are removed
+	pruneInterval = time.Hour
+)
+
+// NewStore creates a Store persisting the snapshots in the given directory, and removes the snapshots
+// which have not been updated for a day, at startup and every hour. The updated snapshots are written in the
+// background until the given stop channel is closed.
+func NewStore(dir string, stop <-chan struct{}) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	s := &Store{
+		dir:     dir,
+		entries: make(map[certificate.CommonName]*entry),
+	}
+	s.prune(maxSnapshotAge)
+	go s.run(stop)
+	return s, nil
+}
+
+// Save records the given response as the last one of its type sent to the proxy with the given common name.
+// The snapshot is persisted in the background, so that sending responses never waits on the disk.
+// Secrets are not persisted: the private keys they hold never leave the memory of the controller.
+func (s *Store) Save(cn certificate.CommonName, response *xds_discovery.DiscoveryResponse) error {
+	if envoy.TypeURI(response.TypeUrl) == envoy.TypeSDS {
+		return nil
+	}
+
+	if _, err := s.getPath(cn); err != nil {
+		return err
+	}
+
+	data, err := proto.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	e := s.getEntry(cn)
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.snap[response.TypeUrl] = data
+	e.dirty = true
+	e.updatedAt = time.Now()
+	return nil
+}
+
+// Load returns the persisted responses of the proxy with the given common name, in the order they must be sent.
+func (s *Store) Load(cn certificate.CommonName) ([]*xds_discovery.DiscoveryResponse, error) {
+	path, err := s.getPath(cn)
+	if err != nil {
+		return nil, err
+	}
+
+	s.entriesMutex.Lock()
+	e, ok := s.entries[cn]
+	s.entriesMutex.Unlock()
+
+	var snap snapshot
+	if ok {
+		e.mutex.Lock()
+		snap, err = e.load(path)
+		e.mutex.Unlock()
+	} else {
+		snap, err = read(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []*xds_discovery.DiscoveryResponse
+	for _, typeURI := range envoy.XDSResponseOrder {
+		data, ok := snap[typeURI.String()]
+		if !ok {
+			continue
+		}
+		response := &xds_discovery.DiscoveryResponse{}
+		if err := proto.Unmarshal(data, response); err != nil {
+			return nil, err
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+// getEntry returns the in-memory snapshot of the proxy with the given common name, created if needed
+func (s *Store) getEntry(cn certificate.CommonName) *entry {
+	s.entriesMutex.Lock()
+	defer s.entriesMutex.Unlock()
+	e, ok := s.entries[cn]
+	if !ok {
+		e = &entry{snap: make(snapshot)}
+		s.entries[cn] = e
+	}
+	return e
+}
+
+// run persists the updated snapshots and prunes the stale ones until the given stop channel is closed
+func (s *Store) run(stop <-chan struct{}) {
+	persistTicker := time.NewTicker(persistInterval)
+	defer persistTicker.Stop()
+	pruneTicker := time.NewTicker(pruneInterval)
+	defer pruneTicker.Stop()
+
+	for {
+		select {
+		case <-persistTicker.C:
+			s.Flush()
+		case <-pruneTicker.C:
+			s.prune(maxSnapshotAge)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Flush writes the snapshots updated since they were last written. Each file is written without holding the
+// lock of its proxy, which is only held to copy its snapshot.
+func (s *Store) Flush() {
+	s.entriesMutex.Lock()
+	entries := make(map[certificate.CommonName]*entry, len(s.entries))
+	for cn, e := range s.entries {
+		entries[cn] = e
+	}
+	s.entriesMutex.Unlock()
+
+	for cn, e := range entries {
+		path, err := s.getPath(cn)
+		if err != nil {
+			continue
+		}
+
+		e.mutex.Lock()
+		if !e.dirty {
+			e.mutex.Unlock()
+			continue
+		}
+		snap, err := e.load(path)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error reading snapshot of proxy with CN=%s; overwriting it", cn)
+			snap = e.copy()
+		}
+		e.dirty = false
+		e.mutex.Unlock()
+
+		if err := write(path, snap); err != nil {
+			log.Error().Err(err).Msgf("Error writing snapshot of proxy with CN=%s", cn)
+			e.mutex.Lock()
+			e.dirty = true
+			e.mutex.Unlock()
+		}
+	}
+}
+
+// load returns a copy of the snapshot of the entry, merged once with the responses of the snapshot file which
+// were persisted before the controller restarted and not saved since. It must be called with the entry locked.
+func (e *entry) load(path string) (snapshot, error) {
+	if !e.loaded {
+		persisted, err := read(path)
+		if err != nil {
+			return nil, err
+		}
+		for typeURL, data := range persisted {
+			if _, ok := e.snap[typeURL]; !ok {
+				e.snap[typeURL] = data
+			}
+		}
+		e.loaded = true
+	}
+	return e.copy(), nil
+}
+
+// copy returns a copy of the snapshot of the entry. It must be called with the entry locked.
+func (e *entry) copy() snapshot {
+	snap := make(snapshot, len(e.snap))
+	for typeURL, data := range e.snap {
+		snap[typeURL] = data
+	}
+	return snap
+}
+
+// getPath returns the path of the snapshot file of the proxy with the given common name
+func (s *Store) getPath(cn certificate.CommonName) (string, error) {
+	name := cn.String()
+	if name == "" || strings.HasPrefix(name, ".") || filepath.Base(name) != name {
+		return "", errInvalidCommonName
+	}
+	return filepath.Join(s.dir, name+snapshotFileExtension), nil
+}
+
+// prune removes the snapshot files which have not been updated within the given duration,
+// along with the in-memory snapshots of the proxies which were not sent a response within that duration
+func (s *Store) prune(maxAge time.Duration) {
+	s.entriesMutex.Lock()
+	for cn, e := range s.entries {
+		e.mutex.Lock()
+		stale := !e.dirty && time.Since(e.updatedAt) >= maxAge
+		e.mutex.Unlock()
+		if stale {
+			delete(s.entries, cn)
+		}
+	}
+	s.entriesMutex.Unlock()
+
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing snapshots in %s", s.dir)
+		return
+	}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != snapshotFileExtension || time.Since(file.ModTime()) < maxAge {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, file.Name())); err != nil {
+			log.Error().Err(err).Msgf("Error removing stale snapshot %s", file.Name())
+		}
+	}
+}
+
+// read returns the snapshot in the given file; a missing file is an empty snapshot
+func read(path string) (snapshot, error) {
+	snap := make(snapshot)
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return snap, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &snap); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// write replaces the given file with the given snapshot, so that the file is never read partially written
+func write(path string, snap snapshot) error {
+	content, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, content, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,132 @@
+package snapshot
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+var _ = Describe("Test snapshot store", func() {
+	cn := certificate.CommonName("proxy-1.bookstore.default")
+
+	var dir string
+	var stop chan struct{}
+	var store *Store
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "snapshots")
+		Expect(err).ToNot(HaveOccurred())
+		stop = make(chan struct{})
+		store, err = NewStore(dir, stop)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		close(stop)
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Context("Test Save() and Load()", func() {
+		It("returns no responses for a proxy without a snapshot", func() {
+			responses, err := store.Load(cn)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(responses).To(BeEmpty())
+		})
+
+		It("returns the last response of each type in the order they must be sent", func() {
+			Expect(store.Save(cn, &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeLDS.String(), VersionInfo: "1"})).To(Succeed())
+			Expect(store.Save(cn, &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeCDS.String(), VersionInfo: "1"})).To(Succeed())
+			Expect(store.Save(cn, &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeCDS.String(), VersionInfo: "2"})).To(Succeed())
+
+			responses, err := store.Load(cn)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(responses).To(HaveLen(2))
+			Expect(proto.Equal(responses[0], &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeCDS.String(), VersionInfo: "2"})).To(BeTrue())
+			Expect(proto.Equal(responses[1], &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeLDS.String(), VersionInfo: "1"})).To(BeTrue())
+		})
+
+		It("does not persist secrets", func() {
+			Expect(store.Save(cn, &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeSDS.String(), VersionInfo: "1"})).To(Succeed())
+
+			responses, err := store.Load(cn)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(responses).To(BeEmpty())
+		})
+
+		It("rejects common names which are not file names", func() {
+			Expect(store.Save("../proxy", &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeCDS.String()})).To(Equal(errInvalidCommonName))
+			_, err := store.Load("")
+			Expect(err).To(Equal(errInvalidCommonName))
+		})
+	})
+
+	Context("Test Flush()", func() {
+		path := func(cn certificate.CommonName) string {
+			return filepath.Join(dir, cn.String()+snapshotFileExtension)
+		}
+
+		It("does not write the snapshot when a response is saved", func() {
+			Expect(store.Save(cn, &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeCDS.String()})).To(Succeed())
+			Expect(path(cn)).ToNot(BeAnExistingFile())
+
+			store.Flush()
+			Expect(path(cn)).To(BeAnExistingFile())
+		})
+
+		It("writes the responses saved meanwhile, merged with the ones persisted before a restart", func() {
+			Expect(store.Save(cn, &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeLDS.String(), VersionInfo: "1"})).To(Succeed())
+			store.Flush()
+
+			restarted, err := NewStore(dir, stop)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(restarted.Save(cn, &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeCDS.String(), VersionInfo: "1"})).To(Succeed())
+			Expect(restarted.Save(cn, &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeCDS.String(), VersionInfo: "2"})).To(Succeed())
+			restarted.Flush()
+
+			reloaded, err := NewStore(dir, stop)
+			Expect(err).ToNot(HaveOccurred())
+			responses, err := reloaded.Load(cn)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(responses).To(HaveLen(2))
+			Expect(proto.Equal(responses[0], &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeCDS.String(), VersionInfo: "2"})).To(BeTrue())
+			Expect(proto.Equal(responses[1], &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeLDS.String(), VersionInfo: "1"})).To(BeTrue())
+		})
+
+		It("does not write the snapshots which were not updated", func() {
+			Expect(store.Save(cn, &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeCDS.String()})).To(Succeed())
+			store.Flush()
+			Expect(os.Remove(path(cn))).To(Succeed())
+
+			store.Flush()
+			Expect(path(cn)).ToNot(BeAnExistingFile())
+		})
+	})
+
+	Context("Test prune()", func() {
+		It("removes the snapshots which were not updated within the given duration", func() {
+			Expect(store.Save(cn, &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeCDS.String()})).To(Succeed())
+			staleCN := certificate.CommonName("proxy-2.bookstore.default")
+			Expect(store.Save(staleCN, &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeCDS.String()})).To(Succeed())
+			store.Flush()
+
+			stalePath := filepath.Join(dir, staleCN.String()+snapshotFileExtension)
+			yesterday := time.Now().Add(-25 * time.Hour)
+			Expect(os.Chtimes(stalePath, yesterday, yesterday)).To(Succeed())
+
+			store.prune(maxSnapshotAge)
+
+			Expect(filepath.Join(dir, cn.String()+snapshotFileExtension)).To(BeAnExistingFile())
+			Expect(stalePath).ToNot(BeAnExistingFile())
+		})
+	})
+})
@@ -0,0 +1,41 @@
+package snapshot
+
+import (
+	"sync"
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var (
+	log = logger.New("envoy/snapshot")
+)
+
+// Store persists the last xDS responses sent to each proxy in a directory, so that a restarted
+// controller can serve the proxies before its caches are synced.
+type Store struct {
+	dir string
+
+	// entries are the in-memory snapshots of the proxies, persisted in the background
+	entries      map[certificate.CommonName]*entry
+	entriesMutex sync.Mutex
+}
+
+// entry is the in-memory snapshot of a proxy
+type entry struct {
+	mutex sync.Mutex
+	snap  snapshot
+
+	// dirty is true when the snapshot was updated since it was last written
+	dirty bool
+
+	// loaded is true once the responses persisted before the controller restarted were merged into the snapshot
+	loaded bool
+
+	// updatedAt is the time the proxy was last sent a response
+	updatedAt time.Time
+}
+
+// snapshot is the content of the snapshot file of a proxy: the last response of each xDS type, serialized
+type snapshot map[string][]byte
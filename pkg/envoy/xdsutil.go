@@ -17,6 +17,8 @@ import (
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/jinzhu/copier"
 
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/service"
 )
 
@@ -144,6 +146,34 @@ func GetAddress(address string, port uint32) *xds_core.Address {
 	}
 }
 
+// GetListenerAddress creates an Envoy Address struct for a listener bound to all local addresses of the given IP family.
+// Dual-stack listeners bind the IPv6 wildcard address and accept IPv4 connections as IPv4-mapped IPv6 addresses.
+func GetListenerAddress(ipFamily configurator.IPFamily, port uint32) *xds_core.Address {
+	if ipFamily != configurator.DualStack {
+		return GetAddress(GetWildcardIPAddr(ipFamily), port)
+	}
+	address := GetAddress(constants.WildcardIPv6Addr, port)
+	address.GetSocketAddress().Ipv4Compat = true
+	return address
+}
+
+// GetWildcardIPAddr returns the address matching any local address of the given IP family.
+// Dual-stack pods are addressed with IPv4, which every application listening on a wildcard address accepts.
+func GetWildcardIPAddr(ipFamily configurator.IPFamily) string {
+	if ipFamily == configurator.IPv6 {
+		return constants.WildcardIPv6Addr
+	}
+	return constants.WildcardIPAddr
+}
+
+// GetLocalhostIPAddr returns the local host address of the given IP family.
+func GetLocalhostIPAddr(ipFamily configurator.IPFamily) string {
+	if ipFamily == configurator.IPv6 {
+		return constants.LocalhostIPv6Address
+	}
+	return constants.LocalhostIPAddress
+}
+
 // GetTLSParams creates Envoy TlsParameters struct.
 func GetTLSParams() *xds_auth.TlsParameters {
 	return &xds_auth.TlsParameters{
@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
@@ -34,6 +35,31 @@ var _ = Describe("Test Envoy tools", func() {
 		})
 	})
 
+	Context("Test GetListenerAddress()", func() {
+		It("binds the wildcard address of single-stack IP families", func() {
+			Expect(GetListenerAddress(configurator.IPv4, 15001)).To(Equal(GetAddress("0.0.0.0", 15001)))
+			Expect(GetListenerAddress(configurator.IPv6, 15001)).To(Equal(GetAddress("::", 15001)))
+		})
+
+		It("binds the IPv6 wildcard address accepting IPv4 connections for dual-stack", func() {
+			actual := GetListenerAddress(configurator.DualStack, 15001)
+			Expect(actual.GetSocketAddress().GetAddress()).To(Equal("::"))
+			Expect(actual.GetSocketAddress().GetIpv4Compat()).To(BeTrue())
+			Expect(actual.GetSocketAddress().GetPortValue()).To(Equal(uint32(15001)))
+		})
+	})
+
+	Context("Test GetWildcardIPAddr() and GetLocalhostIPAddr()", func() {
+		It("returns the addresses of the given IP family", func() {
+			Expect(GetWildcardIPAddr(configurator.IPv4)).To(Equal("0.0.0.0"))
+			Expect(GetWildcardIPAddr(configurator.DualStack)).To(Equal("0.0.0.0"))
+			Expect(GetWildcardIPAddr(configurator.IPv6)).To(Equal("::"))
+			Expect(GetLocalhostIPAddr(configurator.IPv4)).To(Equal("127.0.0.1"))
+			Expect(GetLocalhostIPAddr(configurator.DualStack)).To(Equal("127.0.0.1"))
+			Expect(GetLocalhostIPAddr(configurator.IPv6)).To(Equal("::1"))
+		})
+	})
+
 	Context("Test CertName interface", func() {
 		It("Interface marshals and unmarshals preserving the exact same data", func() {
 			InitialObj := SDSCert{
@@ -9,7 +9,10 @@ import (
 // OptionalFeatures is a struct to enable/disable optional features
 type OptionalFeatures struct {
 	// FeatureName bool
-	Backpressure bool
+	Backpressure   bool
+	GatewayAPI     bool
+	TLSOrigination bool
+	SidecarScope   bool
 }
 
 var (
@@ -38,3 +41,18 @@ func IsFeatureNameEnabled() bool {
 func IsBackpressureEnabled() bool {
 	return Features.Backpressure
 }
+
+// IsGatewayAPIEnabled returns a boolean indicating if the experimental Gateway API feature is enabled
+func IsGatewayAPIEnabled() bool {
+	return Features.GatewayAPI
+}
+
+// IsTLSOriginationEnabled returns a boolean indicating if the experimental egress TLS origination feature is enabled
+func IsTLSOriginationEnabled() bool {
+	return Features.TLSOrigination
+}
+
+// IsSidecarScopeEnabled returns a boolean indicating if the experimental sidecar scope feature is enabled
+func IsSidecarScopeEnabled() bool {
+	return Features.SidecarScope
+}
@@ -0,0 +1,222 @@
+package gateway
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// The types in this file mirror the subset of the Kubernetes Gateway API (networking.x-k8s.io/v1alpha1)
+// consumed by OSM. The resources are read with the dynamic client and converted into these types.
+
+var (
+	// GatewayClassResource is the GroupVersionResource of the GatewayClass resource
+	GatewayClassResource = schema.GroupVersionResource{Group: "networking.x-k8s.io", Version: "v1alpha1", Resource: "gatewayclasses"}
+
+	// GatewayResource is the GroupVersionResource of the Gateway resource
+	GatewayResource = schema.GroupVersionResource{Group: "networking.x-k8s.io", Version: "v1alpha1", Resource: "gateways"}
+
+	// HTTPRouteResource is the GroupVersionResource of the HTTPRoute resource
+	HTTPRouteResource = schema.GroupVersionResource{Group: "networking.x-k8s.io", Version: "v1alpha1", Resource: "httproutes"}
+)
+
+const (
+	// ControllerName is the GatewayClass controller value OSM reconciles Gateways for
+	ControllerName = "openservicemesh.io/gateway-controller"
+
+	// HTTPRouteKind is the kind of the HTTPRoute resource
+	HTTPRouteKind = "HTTPRoute"
+
+	// ProtocolHTTP is the protocol of listeners accepting HTTP traffic
+	ProtocolHTTP = "HTTP"
+
+	// ProtocolHTTPS is the protocol of listeners accepting HTTPS traffic
+	ProtocolHTTPS = "HTTPS"
+
+	// RouteSelectAll selects routes from all namespaces
+	RouteSelectAll = "All"
+
+	// RouteSelectSame selects routes from the namespace of the Gateway
+	RouteSelectSame = "Same"
+
+	// RouteSelectSelector selects routes from the namespaces matching a label selector
+	RouteSelectSelector = "Selector"
+
+	// PathMatchExact matches the path exactly
+	PathMatchExact = "Exact"
+
+	// PathMatchPrefix matches the path by prefix
+	PathMatchPrefix = "Prefix"
+
+	// PathMatchRegularExpression matches the path by regular expression
+	PathMatchRegularExpression = "RegularExpression"
+
+	// HeaderMatchExact matches the header values exactly
+	HeaderMatchExact = "Exact"
+
+	// HeaderMatchRegularExpression matches the header values by regular expression
+	HeaderMatchRegularExpression = "RegularExpression"
+)
+
+// GatewayClass describes a class of Gateways available to users for creating Gateway resources
+type GatewayClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GatewayClassSpec `json:"spec,omitempty"`
+}
+
+// GatewayClassSpec is the spec of a GatewayClass
+type GatewayClassSpec struct {
+	// Controller is the name of the controller managing Gateways of this class
+	Controller string `json:"controller"`
+}
+
+// Gateway represents an instantiation of a service-traffic handling infrastructure
+type Gateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GatewaySpec `json:"spec,omitempty"`
+
+	Status GatewayStatus `json:"status,omitempty"`
+}
+
+// GatewayStatus is the status of a Gateway
+type GatewayStatus struct {
+	// Conditions describe the current conditions of the Gateway
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// Condition describes an aspect of the state of a Gateway API resource
+type Condition struct {
+	// Type is the type of the condition, such as Ready
+	Type string `json:"type"`
+
+	// Status is one of True, False or Unknown
+	Status string `json:"status"`
+
+	// ObservedGeneration is the generation of the resource the condition was set for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastTransitionTime is the last time the status of the condition changed
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+
+	// Reason is a CamelCase reason for the last transition of the condition
+	Reason string `json:"reason"`
+
+	// Message is a human readable message about the transition
+	Message string `json:"message"`
+}
+
+// GatewaySpec is the spec of a Gateway
+type GatewaySpec struct {
+	// GatewayClassName is the name of the GatewayClass used by this Gateway
+	GatewayClassName string `json:"gatewayClassName"`
+
+	// Listeners are the logical endpoints bound on this Gateway's addresses
+	Listeners []Listener `json:"listeners"`
+}
+
+// Listener embodies the concept of a logical endpoint where a Gateway accepts connections
+type Listener struct {
+	// Hostname is the virtual hostname to match for protocol types that define this concept
+	Hostname *string `json:"hostname,omitempty"`
+
+	// Port is the network port
+	Port int32 `json:"port"`
+
+	// Protocol is the network protocol this listener expects to receive
+	Protocol string `json:"protocol"`
+
+	// Routes specifies the routes this listener binds to
+	Routes RouteBindingSelector `json:"routes"`
+}
+
+// RouteBindingSelector selects the routes bound to a Listener
+type RouteBindingSelector struct {
+	// Namespaces indicates the namespaces from which routes may be selected
+	Namespaces *RouteNamespaces `json:"namespaces,omitempty"`
+
+	// Selector specifies a set of route labels used for selecting routes
+	Selector metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Group is the API group of the route resource
+	Group string `json:"group,omitempty"`
+
+	// Kind is the kind of the route resource
+	Kind string `json:"kind"`
+}
+
+// RouteNamespaces indicates the namespaces from which routes may be selected
+type RouteNamespaces struct {
+	// From is one of All, Same or Selector
+	From string `json:"from,omitempty"`
+
+	// Selector selects the namespaces when From is set to Selector
+	Selector metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// HTTPRoute is the Gateway API resource describing HTTP routing from a Gateway to Kubernetes services
+type HTTPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HTTPRouteSpec `json:"spec,omitempty"`
+}
+
+// HTTPRouteSpec is the spec of an HTTPRoute
+type HTTPRouteSpec struct {
+	// Hostnames are the hosts the route applies to
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// Rules are the list of HTTP matchers and the backends they forward to
+	Rules []HTTPRouteRule `json:"rules,omitempty"`
+}
+
+// HTTPRouteRule defines the semantics for matching an HTTP request and forwarding it to a backend
+type HTTPRouteRule struct {
+	// Matches are the conditions used for matching the rule against incoming HTTP requests
+	Matches []HTTPRouteMatch `json:"matches,omitempty"`
+
+	// ForwardTo are the backends the matching requests are forwarded to
+	ForwardTo []HTTPRouteForwardTo `json:"forwardTo,omitempty"`
+}
+
+// HTTPRouteMatch defines the predicate used to match requests to a given action
+type HTTPRouteMatch struct {
+	// Path specifies the HTTP request path matcher
+	Path *HTTPPathMatch `json:"path,omitempty"`
+
+	// Headers specifies the HTTP request header matcher
+	Headers *HTTPHeaderMatch `json:"headers,omitempty"`
+}
+
+// HTTPPathMatch describes how to select an HTTP route by matching the HTTP request path
+type HTTPPathMatch struct {
+	// Type is one of Exact, Prefix or RegularExpression
+	Type string `json:"type,omitempty"`
+
+	// Value is the value of the HTTP path to match against
+	Value string `json:"value,omitempty"`
+}
+
+// HTTPHeaderMatch describes how to select an HTTP route by matching HTTP request headers
+type HTTPHeaderMatch struct {
+	// Type is one of Exact or RegularExpression
+	Type string `json:"type,omitempty"`
+
+	// Values is a map of HTTP header names to the values to match against
+	Values map[string]string `json:"values"`
+}
+
+// HTTPRouteForwardTo defines a backend the matching requests are forwarded to
+type HTTPRouteForwardTo struct {
+	// ServiceName is the name of the Kubernetes service to forward to
+	ServiceName *string `json:"serviceName,omitempty"`
+
+	// Port is the port of the service to forward to
+	Port *int32 `json:"port,omitempty"`
+
+	// Weight is the proportion of traffic forwarded to the backend
+	Weight int32 `json:"weight,omitempty"`
+}
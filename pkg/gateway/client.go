@@ -0,0 +1,352 @@
+package gateway
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/namespace"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// NewGatewayClient implements gateway.Monitor and creates the Kubernetes client to monitor Gateway API resources.
+func NewGatewayClient(dynamicClient dynamic.Interface, namespaceController namespace.Controller, stop <-chan struct{}) (Monitor, error) {
+	client := newGatewayClient(dynamicClient, namespaceController)
+
+	if !featureflags.IsGatewayAPIEnabled() {
+		return client, nil
+	}
+
+	if err := client.run(stop); err != nil {
+		log.Error().Err(err).Msg("Could not start Gateway API client")
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func newGatewayClient(dynamicClient dynamic.Interface, namespaceController namespace.Controller) *Client {
+	client := Client{
+		dynamicClient:       dynamicClient,
+		cacheSynced:         make(chan interface{}),
+		announcements:       make(chan interface{}),
+		namespaceController: namespaceController,
+		gatewayChanges:      make(chan struct{}, 1),
+	}
+
+	if !featureflags.IsGatewayAPIEnabled() {
+		return &client
+	}
+
+	informerFactory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, k8s.DefaultKubeEventResyncInterval)
+	informerCollection := InformerCollection{
+		GatewayClass: informerFactory.ForResource(GatewayClassResource).Informer(),
+		Gateway:      informerFactory.ForResource(GatewayResource).Informer(),
+		HTTPRoute:    informerFactory.ForResource(HTTPRouteResource).Informer(),
+	}
+	cacheCollection := CacheCollection{
+		GatewayClass: informerCollection.GatewayClass.GetStore(),
+		Gateway:      informerCollection.Gateway.GetStore(),
+		HTTPRoute:    informerCollection.HTTPRoute.GetStore(),
+	}
+	client.informers = &informerCollection
+	client.caches = &cacheCollection
+
+	// The Gateway API resources are unstructured, so the namespace is looked up with the meta accessor
+	// instead of the reflection based lookup used for typed resources.
+	shouldObserve := func(obj interface{}) bool {
+		object, err := meta.Accessor(obj)
+		if err != nil {
+			return false
+		}
+		return namespaceController.IsMonitoredNamespace(object.GetNamespace())
+	}
+	informerCollection.GatewayClass.AddEventHandler(k8s.GetKubernetesEventHandlers("GatewayClass", "GatewayAPI", client.announcements, nil))
+	informerCollection.Gateway.AddEventHandler(k8s.GetKubernetesEventHandlers("Gateway", "GatewayAPI", client.announcements, shouldObserve))
+	informerCollection.HTTPRoute.AddEventHandler(k8s.GetKubernetesEventHandlers("HTTPRoute", "GatewayAPI", client.announcements, shouldObserve))
+
+	// The status of the Gateways is reported when they, or their GatewayClass, change
+	gatewayChangeHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(_ interface{}) {
+			client.notifyGatewayChange()
+		},
+		UpdateFunc: func(_, _ interface{}) {
+			client.notifyGatewayChange()
+		},
+	}
+	informerCollection.GatewayClass.AddEventHandler(gatewayChangeHandler)
+	informerCollection.Gateway.AddEventHandler(gatewayChangeHandler)
+
+	return &client
+}
+
+// run executes informer collection.
+func (c *Client) run(stop <-chan struct{}) error {
+	log.Info().Msg("Gateway API client started")
+
+	if c.informers == nil {
+		return errInitInformers
+	}
+
+	sharedInformers := map[string]cache.SharedInformer{
+		"GatewayClass": c.informers.GatewayClass,
+		"Gateway":      c.informers.Gateway,
+		"HTTPRoute":    c.informers.HTTPRoute,
+	}
+
+	var names []string
+	var hasSynced []cache.InformerSynced
+	for name, informer := range sharedInformers {
+		names = append(names, name)
+		log.Info().Msgf("Starting informer: %s", name)
+		go informer.Run(stop)
+		hasSynced = append(hasSynced, informer.HasSynced)
+	}
+
+	log.Info().Msgf("Waiting for Gateway API informers' cache to sync: %+v", strings.Join(names, ", "))
+	if !cache.WaitForCacheSync(stop, hasSynced...) {
+		return errSyncingCaches
+	}
+
+	// Closing the cacheSynced channel signals to the rest of the system that... caches have been synced.
+	close(c.cacheSynced)
+
+	log.Info().Msgf("Cache sync finished for Gateway API informers %+v", names)
+	return nil
+}
+
+// GetAnnouncementsChannel returns the announcement channel for the Gateway API client
+func (c *Client) GetAnnouncementsChannel() <-chan interface{} {
+	return c.announcements
+}
+
+// GetHTTPRoutes returns the HTTPRoutes bound to an OSM managed Gateway which forward traffic to the given service.
+// The hostnames of each returned HTTPRoute are restricted to those of the HTTP listeners it is bound to.
+func (c *Client) GetHTTPRoutes(meshService service.MeshService) ([]*HTTPRoute, error) {
+	var httpRoutes []*HTTPRoute
+	if !featureflags.IsGatewayAPIEnabled() {
+		return httpRoutes, nil
+	}
+
+	gateways := c.listManagedGateways()
+	if len(gateways) == 0 {
+		return httpRoutes, nil
+	}
+
+	for _, obj := range c.caches.HTTPRoute.List() {
+		httpRoute := &HTTPRoute{}
+		if err := fromUnstructured(obj, httpRoute); err != nil {
+			log.Error().Err(err).Msg("Failed to convert HTTPRoute in cache")
+			continue
+		}
+
+		// Extra safety - make sure we do not pay attention to HTTPRoutes outside of observed namespaces
+		if !c.namespaceController.IsMonitoredNamespace(httpRoute.Namespace) {
+			continue
+		}
+
+		// Backends are referenced by name, so they belong to the namespace of the HTTPRoute
+		if httpRoute.Namespace != meshService.Namespace {
+			continue
+		}
+
+		if !forwardsToService(httpRoute, meshService) {
+			continue
+		}
+
+		hostnames, bound := getBoundHostnames(gateways, httpRoute)
+		if !bound {
+			continue
+		}
+
+		// The route is only served for the hostnames of the listeners it is bound to
+		boundRoute := *httpRoute
+		boundRoute.Spec.Hostnames = hostnames
+		httpRoutes = append(httpRoutes, &boundRoute)
+	}
+
+	return httpRoutes, nil
+}
+
+// listManagedGateways returns the Gateways whose GatewayClass is managed by OSM
+func (c *Client) listManagedGateways() []*Gateway {
+	managedClasses := make(map[string]interface{})
+	for _, obj := range c.caches.GatewayClass.List() {
+		gatewayClass := &GatewayClass{}
+		if err := fromUnstructured(obj, gatewayClass); err != nil {
+			log.Error().Err(err).Msg("Failed to convert GatewayClass in cache")
+			continue
+		}
+		if gatewayClass.Spec.Controller == ControllerName {
+			managedClasses[gatewayClass.Name] = nil
+		}
+	}
+
+	var gateways []*Gateway
+	for _, obj := range c.caches.Gateway.List() {
+		gw := &Gateway{}
+		if err := fromUnstructured(obj, gw); err != nil {
+			log.Error().Err(err).Msg("Failed to convert Gateway in cache")
+			continue
+		}
+		if !c.namespaceController.IsMonitoredNamespace(gw.Namespace) {
+			continue
+		}
+		if _, ok := managedClasses[gw.Spec.GatewayClassName]; !ok {
+			continue
+		}
+		gateways = append(gateways, gw)
+	}
+	return gateways
+}
+
+// getBoundHostnames returns the hostnames the HTTPRoute is served for by the HTTP listeners of the given Gateways
+// selecting it, and whether any such listener selects it. An empty list of hostnames matches any host.
+func getBoundHostnames(gateways []*Gateway, httpRoute *HTTPRoute) ([]string, bool) {
+	bound := false
+	anyHostname := false
+	hostnameSet := make(map[string]interface{})
+	for _, gw := range gateways {
+		for _, listener := range gw.Spec.Listeners {
+			if !isHTTPListener(listener) || !listenerSelectsRoute(gw, listener, httpRoute) {
+				continue
+			}
+			hostnames, ok := intersectHostnames(listener.Hostname, httpRoute.Spec.Hostnames)
+			if !ok {
+				continue
+			}
+			bound = true
+			if len(hostnames) == 0 {
+				anyHostname = true
+			}
+			for _, hostname := range hostnames {
+				hostnameSet[hostname] = nil
+			}
+		}
+	}
+
+	if !bound || anyHostname {
+		return nil, bound
+	}
+	hostnames := make([]string, 0, len(hostnameSet))
+	for hostname := range hostnameSet {
+		hostnames = append(hostnames, hostname)
+	}
+	sort.Strings(hostnames)
+	return hostnames, true
+}
+
+// isHTTPListener returns true if the listener accepts HTTP or HTTPS traffic on a valid port
+func isHTTPListener(listener Listener) bool {
+	if listener.Port < 1 || listener.Port > 65535 {
+		return false
+	}
+	return strings.EqualFold(listener.Protocol, ProtocolHTTP) || strings.EqualFold(listener.Protocol, ProtocolHTTPS)
+}
+
+// listenerSelectsRoute returns true if the Gateway's listener selects the given HTTPRoute
+func listenerSelectsRoute(gw *Gateway, listener Listener, httpRoute *HTTPRoute) bool {
+	if listener.Routes.Kind != HTTPRouteKind {
+		return false
+	}
+
+	from := RouteSelectSame
+	if listener.Routes.Namespaces != nil && listener.Routes.Namespaces.From != "" {
+		from = listener.Routes.Namespaces.From
+	}
+	switch from {
+	case RouteSelectAll:
+	case RouteSelectSame:
+		if gw.Namespace != httpRoute.Namespace {
+			return false
+		}
+	default:
+		log.Warn().Msgf("Route namespace selection %q on Gateway %s/%s is not supported", from, gw.Namespace, gw.Name)
+		return false
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&listener.Routes.Selector)
+	if err != nil {
+		log.Error().Err(err).Msgf("Invalid route selector on Gateway %s/%s", gw.Namespace, gw.Name)
+		return false
+	}
+	return selector.Matches(labels.Set(httpRoute.Labels))
+}
+
+// intersectHostnames returns the hostnames matched by both the listener hostname and the HTTPRoute hostnames,
+// and whether they intersect. A listener without a hostname matches any host, as does a route without hostnames.
+// When a wildcard hostname matches a more specific one, the more specific hostname is returned.
+func intersectHostnames(listenerHostname *string, routeHostnames []string) ([]string, bool) {
+	if listenerHostname == nil || *listenerHostname == "" || *listenerHostname == "*" {
+		return routeHostnames, true
+	}
+	if len(routeHostnames) == 0 {
+		return []string{*listenerHostname}, true
+	}
+
+	var hostnames []string
+	for _, routeHostname := range routeHostnames {
+		switch {
+		case hostnameMatches(*listenerHostname, routeHostname):
+			hostnames = append(hostnames, routeHostname)
+		case hostnameMatches(routeHostname, *listenerHostname):
+			hostnames = append(hostnames, *listenerHostname)
+		}
+	}
+	return hostnames, len(hostnames) > 0
+}
+
+// hostnameMatches returns true if the hostname matches the pattern, whose "*." prefix matches a single leading label
+func hostnameMatches(pattern, hostname string) bool {
+	if strings.EqualFold(pattern, hostname) {
+		return true
+	}
+	if !strings.HasPrefix(pattern, "*.") {
+		return false
+	}
+	suffix := pattern[1:]
+	if len(hostname) <= len(suffix) || !strings.EqualFold(hostname[len(hostname)-len(suffix):], suffix) {
+		return false
+	}
+	label := hostname[:len(hostname)-len(suffix)]
+	return label != "*" && !strings.Contains(label, ".")
+}
+
+// forwardsToService returns true if any of the HTTPRoute's rules forwards traffic to the given service
+func forwardsToService(httpRoute *HTTPRoute, meshService service.MeshService) bool {
+	for _, rule := range httpRoute.Spec.Rules {
+		if RuleForwardsToService(rule, meshService) {
+			return true
+		}
+	}
+	return false
+}
+
+// RuleForwardsToService returns true if the HTTPRoute rule forwards traffic to the given service
+func RuleForwardsToService(rule HTTPRouteRule, meshService service.MeshService) bool {
+	for _, forwardTo := range rule.ForwardTo {
+		if forwardTo.ServiceName != nil && *forwardTo.ServiceName == meshService.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// fromUnstructured converts an object from an informer cache into the given Gateway API type
+func fromUnstructured(obj interface{}, into interface{}) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return errInvalidObjectType
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), into)
+}
@@ -0,0 +1,231 @@
+package gateway
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/namespace"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	testNamespace    = "gateway-ns"
+	testGatewayClass = "osm"
+	testServiceName  = "bookstore"
+)
+
+func toUnstructured(obj interface{}) *unstructured.Unstructured {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	Expect(err).ToNot(HaveOccurred())
+	return &unstructured.Unstructured{Object: content}
+}
+
+func newTestHTTPRoute(name string, routeLabels map[string]string, serviceName string) *HTTPRoute {
+	return &HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    routeLabels,
+		},
+		Spec: HTTPRouteSpec{
+			Hostnames: []string{"bookstore.example.com"},
+			Rules: []HTTPRouteRule{
+				{
+					Matches: []HTTPRouteMatch{
+						{
+							Path: &HTTPPathMatch{Type: PathMatchPrefix, Value: "/books"},
+						},
+					},
+					ForwardTo: []HTTPRouteForwardTo{
+						{
+							ServiceName: &serviceName,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+var _ = Describe("Gateway API client", func() {
+	featureflags.Initialize(featureflags.OptionalFeatures{GatewayAPI: true})
+
+	meshService := service.MeshService{
+		Namespace: testNamespace,
+		Name:      testServiceName,
+	}
+
+	gatewayClass := &GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: testGatewayClass},
+		Spec:       GatewayClassSpec{Controller: ControllerName},
+	}
+
+	gw := &Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gateway",
+			Namespace: testNamespace,
+		},
+		Spec: GatewaySpec{
+			GatewayClassName: testGatewayClass,
+			Listeners: []Listener{
+				{
+					Port:     80,
+					Protocol: "HTTP",
+					Routes: RouteBindingSelector{
+						Kind: HTTPRouteKind,
+						Selector: metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "bookstore"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	newClient := func(gatewayClasses []*GatewayClass, gateways []*Gateway, httpRoutes []*HTTPRoute) *Client {
+		caches := CacheCollection{
+			GatewayClass: cache.NewStore(cache.MetaNamespaceKeyFunc),
+			Gateway:      cache.NewStore(cache.MetaNamespaceKeyFunc),
+			HTTPRoute:    cache.NewStore(cache.MetaNamespaceKeyFunc),
+		}
+		for _, obj := range gatewayClasses {
+			Expect(caches.GatewayClass.Add(toUnstructured(obj))).To(Succeed())
+		}
+		for _, obj := range gateways {
+			Expect(caches.Gateway.Add(toUnstructured(obj))).To(Succeed())
+		}
+		for _, obj := range httpRoutes {
+			Expect(caches.HTTPRoute.Add(toUnstructured(obj))).To(Succeed())
+		}
+		return &Client{
+			caches:              &caches,
+			namespaceController: namespace.NewFakeNamespaceController([]string{testNamespace}),
+		}
+	}
+
+	Context("Test GetHTTPRoutes()", func() {
+		It("returns the HTTPRoutes bound to an OSM managed Gateway", func() {
+			httpRoute := newTestHTTPRoute("bookstore-route", map[string]string{"app": "bookstore"}, testServiceName)
+			c := newClient([]*GatewayClass{gatewayClass}, []*Gateway{gw}, []*HTTPRoute{httpRoute})
+
+			actual, err := c.GetHTTPRoutes(meshService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(actual)).To(Equal(1))
+			Expect(actual[0].Name).To(Equal("bookstore-route"))
+			Expect(actual[0].Spec.Rules[0].Matches[0].Path.Value).To(Equal("/books"))
+		})
+
+		It("ignores HTTPRoutes not selected by the Gateway listeners", func() {
+			httpRoute := newTestHTTPRoute("bookstore-route", map[string]string{"app": "bookbuyer"}, testServiceName)
+			c := newClient([]*GatewayClass{gatewayClass}, []*Gateway{gw}, []*HTTPRoute{httpRoute})
+
+			actual, err := c.GetHTTPRoutes(meshService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(actual)).To(Equal(0))
+		})
+
+		It("ignores HTTPRoutes forwarding to other services", func() {
+			httpRoute := newTestHTTPRoute("bookstore-route", map[string]string{"app": "bookstore"}, "bookthief")
+			c := newClient([]*GatewayClass{gatewayClass}, []*Gateway{gw}, []*HTTPRoute{httpRoute})
+
+			actual, err := c.GetHTTPRoutes(meshService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(actual)).To(Equal(0))
+		})
+
+		It("ignores Gateways whose GatewayClass is not managed by OSM", func() {
+			otherClass := &GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: testGatewayClass},
+				Spec:       GatewayClassSpec{Controller: "example.com/other-controller"},
+			}
+			httpRoute := newTestHTTPRoute("bookstore-route", map[string]string{"app": "bookstore"}, testServiceName)
+			c := newClient([]*GatewayClass{otherClass}, []*Gateway{gw}, []*HTTPRoute{httpRoute})
+
+			actual, err := c.GetHTTPRoutes(meshService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(actual)).To(Equal(0))
+		})
+	})
+
+	Context("Test listenerSelectsRoute()", func() {
+		httpRoute := newTestHTTPRoute("bookstore-route", map[string]string{"app": "bookstore"}, testServiceName)
+		httpRoute.Namespace = "other-ns"
+
+		It("does not select routes from other namespaces by default", func() {
+			Expect(listenerSelectsRoute(gw, gw.Spec.Listeners[0], httpRoute)).To(BeFalse())
+		})
+
+		It("selects routes from other namespaces when all namespaces are selected", func() {
+			listener := gw.Spec.Listeners[0]
+			listener.Routes.Namespaces = &RouteNamespaces{From: RouteSelectAll}
+			Expect(listenerSelectsRoute(gw, listener, httpRoute)).To(BeTrue())
+		})
+	})
+
+	Context("Test getBoundHostnames()", func() {
+		newGateway := func(listeners ...Listener) *Gateway {
+			boundGateway := *gw
+			boundGateway.Spec.Listeners = listeners
+			return &boundGateway
+		}
+		newListener := func(hostname string, port int32, protocol string) Listener {
+			listener := gw.Spec.Listeners[0]
+			listener.Port = port
+			listener.Protocol = protocol
+			if hostname != "" {
+				listener.Hostname = &hostname
+			}
+			return listener
+		}
+		httpRoute := newTestHTTPRoute("bookstore-route", map[string]string{"app": "bookstore"}, testServiceName)
+
+		It("keeps the route hostnames for listeners without a hostname", func() {
+			hostnames, bound := getBoundHostnames([]*Gateway{newGateway(newListener("", 80, ProtocolHTTP))}, httpRoute)
+			Expect(bound).To(BeTrue())
+			Expect(hostnames).To(Equal([]string{"bookstore.example.com"}))
+		})
+
+		It("matches the route hostnames against wildcard listener hostnames", func() {
+			hostnames, bound := getBoundHostnames([]*Gateway{newGateway(newListener("*.example.com", 80, ProtocolHTTP))}, httpRoute)
+			Expect(bound).To(BeTrue())
+			Expect(hostnames).To(Equal([]string{"bookstore.example.com"}))
+		})
+
+		It("uses the listener hostname for routes without hostnames", func() {
+			route := *httpRoute
+			route.Spec.Hostnames = nil
+			hostnames, bound := getBoundHostnames([]*Gateway{newGateway(newListener("bookstore.example.com", 443, ProtocolHTTPS))}, &route)
+			Expect(bound).To(BeTrue())
+			Expect(hostnames).To(Equal([]string{"bookstore.example.com"}))
+		})
+
+		It("matches any host when neither the listener nor the route has a hostname", func() {
+			route := *httpRoute
+			route.Spec.Hostnames = nil
+			hostnames, bound := getBoundHostnames([]*Gateway{newGateway(newListener("", 80, ProtocolHTTP))}, &route)
+			Expect(bound).To(BeTrue())
+			Expect(hostnames).To(BeEmpty())
+		})
+
+		It("does not bind routes whose hostnames do not match the listener hostname", func() {
+			_, bound := getBoundHostnames([]*Gateway{newGateway(newListener("bookbuyer.example.com", 80, ProtocolHTTP))}, httpRoute)
+			Expect(bound).To(BeFalse())
+		})
+
+		It("matches a single leading label with wildcard listener hostnames", func() {
+			_, bound := getBoundHostnames([]*Gateway{newGateway(newListener("*.com", 80, ProtocolHTTP))}, httpRoute)
+			Expect(bound).To(BeFalse())
+		})
+
+		It("does not bind routes to listeners which are not HTTP or lack a valid port", func() {
+			_, bound := getBoundHostnames([]*Gateway{newGateway(newListener("", 80, "TCP"), newListener("", 0, ProtocolHTTP))}, httpRoute)
+			Expect(bound).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,9 @@
+package gateway
+
+import "github.com/pkg/errors"
+
+var (
+	errSyncingCaches     = errors.New("Failed initial cache sync for Gateway API informers")
+	errInitInformers     = errors.New("Gateway API informers not initialized")
+	errInvalidObjectType = errors.New("Gateway API object is not *unstructured.Unstructured")
+)
@@ -0,0 +1,29 @@
+package gateway
+
+import (
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// FakeGatewayMonitor is a fake gateway.Monitor used for testing
+type FakeGatewayMonitor struct {
+	FakeHTTPRoutes []*HTTPRoute
+	Monitor
+}
+
+// NewFakeGatewayMonitor returns a fake gateway.Monitor used for testing
+func NewFakeGatewayMonitor() FakeGatewayMonitor {
+	return FakeGatewayMonitor{}
+}
+
+// GetHTTPRoutes returns the HTTPRoutes bound to an OSM managed Gateway which forward traffic to the given service
+func (f FakeGatewayMonitor) GetHTTPRoutes(service.MeshService) ([]*HTTPRoute, error) {
+	return f.FakeHTTPRoutes, nil
+}
+
+// GetAnnouncementsChannel returns the channel on which the Gateway API Monitor makes annoucements
+func (f FakeGatewayMonitor) GetAnnouncementsChannel() <-chan interface{} {
+	return make(chan interface{})
+}
+
+// ReportStatus reports in the status of the Gateways managed by OSM that their listeners are not served
+func (f FakeGatewayMonitor) ReportStatus(<-chan struct{}) {}
@@ -0,0 +1,117 @@
+package gateway
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/openservicemesh/osm/pkg/featureflags"
+)
+
+const (
+	// GatewayConditionReady is the type of the condition of a Gateway telling whether its listeners are served
+	GatewayConditionReady = "Ready"
+
+	// GatewayReasonListenersNotReady is the reason of the Ready condition of the Gateways managed by OSM,
+	// whose listeners are not served
+	GatewayReasonListenersNotReady = "ListenersNotReady"
+
+	// listenersNotServedMessage is the message of the Ready condition of the Gateways managed by OSM
+	listenersNotServedMessage = "OSM does not provision a data plane for Gateways, so their listeners are not served. " +
+		"The HTTPRoutes bound to the Gateway only configure the sidecars of their backend services to accept the traffic of an ingress controller."
+
+	conditionFalse = "False"
+)
+
+// ReportStatus reports in the Ready condition of the Gateways managed by OSM that their listeners are not served,
+// whenever a Gateway or GatewayClass changes, until the given stop channel is closed.
+func (c *Client) ReportStatus(stop <-chan struct{}) {
+	if !featureflags.IsGatewayAPIEnabled() {
+		return
+	}
+
+	go func() {
+		for {
+			for _, gw := range c.listManagedGateways() {
+				if err := c.updateGatewayStatus(gw); err != nil {
+					log.Error().Err(err).Msgf("Error updating the status of Gateway %s/%s", gw.Namespace, gw.Name)
+				}
+			}
+			select {
+			case <-stop:
+				return
+			case <-c.gatewayChanges:
+			}
+		}
+	}()
+}
+
+// notifyGatewayChange signals that the status of the Gateways may need an update, without blocking the informers
+func (c *Client) notifyGatewayChange() {
+	select {
+	case c.gatewayChanges <- struct{}{}:
+	default:
+	}
+}
+
+// updateGatewayStatus sets the Ready condition of the given Gateway to False, unless it already is.
+// The other conditions and fields of the Gateway, which OSM does not know of, are left untouched.
+func (c *Client) updateGatewayStatus(gw *Gateway) error {
+	obj, exists, err := c.caches.Gateway.GetByKey(gw.Namespace + "/" + gw.Name)
+	if err != nil || !exists {
+		return err
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return errInvalidObjectType
+	}
+	u = u.DeepCopy()
+
+	existingConditions, _, err := unstructured.NestedSlice(u.Object, "status", "conditions")
+	if err != nil {
+		return err
+	}
+
+	desired := Condition{
+		Type:               GatewayConditionReady,
+		Status:             conditionFalse,
+		ObservedGeneration: gw.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             GatewayReasonListenersNotReady,
+		Message:            listenersNotServedMessage,
+	}
+
+	var conditions []interface{}
+	for _, existing := range existingConditions {
+		condition := Condition{}
+		content, ok := existing.(map[string]interface{})
+		if !ok || runtime.DefaultUnstructuredConverter.FromUnstructured(content, &condition) != nil || condition.Type != GatewayConditionReady {
+			conditions = append(conditions, existing)
+			continue
+		}
+		if condition.Status == desired.Status && condition.Reason == desired.Reason &&
+			condition.Message == desired.Message && condition.ObservedGeneration == desired.ObservedGeneration {
+			return nil
+		}
+		if condition.Status == desired.Status {
+			desired.LastTransitionTime = condition.LastTransitionTime
+		}
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&desired)
+	if err != nil {
+		return err
+	}
+	conditions = append(conditions, content)
+	if err := unstructured.SetNestedSlice(u.Object, conditions, "status", "conditions"); err != nil {
+		return err
+	}
+
+	if _, err := c.dynamicClient.Resource(GatewayResource).Namespace(gw.Namespace).UpdateStatus(context.Background(), u, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	log.Info().Msgf("Reported in the status of Gateway %s/%s that its listeners are not served", gw.Namespace, gw.Name)
+	return nil
+}
@@ -0,0 +1,91 @@
+package gateway
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ = Describe("Gateway status", func() {
+	gw := &Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "gateway",
+			Namespace:  testNamespace,
+			Generation: 2,
+		},
+		Spec: GatewaySpec{GatewayClassName: testGatewayClass},
+		Status: GatewayStatus{
+			Conditions: []Condition{
+				{
+					Type:   "Scheduled",
+					Status: "True",
+					Reason: "Scheduled",
+				},
+			},
+		},
+	}
+
+	newGatewayObject := func() *unstructured.Unstructured {
+		u := toUnstructured(gw)
+		u.SetAPIVersion(GatewayResource.GroupVersion().String())
+		u.SetKind("Gateway")
+		return u
+	}
+
+	newClient := func(u *unstructured.Unstructured) *Client {
+		caches := CacheCollection{
+			Gateway: cache.NewStore(cache.MetaNamespaceKeyFunc),
+		}
+		Expect(caches.Gateway.Add(u)).To(Succeed())
+		return &Client{
+			caches:        &caches,
+			dynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), u),
+		}
+	}
+
+	getConditions := func(c *Client) []Condition {
+		u, err := c.dynamicClient.Resource(GatewayResource).Namespace(testNamespace).Get(context.Background(), gw.Name, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		updated := &Gateway{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, updated)).To(Succeed())
+		return updated.Status.Conditions
+	}
+
+	Context("Test updateGatewayStatus()", func() {
+		It("reports that the listeners are not served and keeps the other conditions", func() {
+			c := newClient(newGatewayObject())
+
+			Expect(c.updateGatewayStatus(gw)).To(Succeed())
+
+			conditions := getConditions(c)
+			Expect(len(conditions)).To(Equal(2))
+			Expect(conditions[0].Type).To(Equal("Scheduled"))
+			Expect(conditions[1].Type).To(Equal(GatewayConditionReady))
+			Expect(conditions[1].Status).To(Equal(conditionFalse))
+			Expect(conditions[1].Reason).To(Equal(GatewayReasonListenersNotReady))
+			Expect(conditions[1].ObservedGeneration).To(Equal(gw.Generation))
+		})
+
+		It("does not update a Gateway which already reports that its listeners are not served", func() {
+			c := newClient(newGatewayObject())
+			Expect(c.updateGatewayStatus(gw)).To(Succeed())
+
+			reported, err := c.dynamicClient.Resource(GatewayResource).Namespace(testNamespace).Get(context.Background(), gw.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			c = newClient(reported)
+			Expect(c.updateGatewayStatus(gw)).To(Succeed())
+
+			fakeClient := c.dynamicClient.(*dynamicfake.FakeDynamicClient)
+			for _, action := range fakeClient.Actions() {
+				Expect(action.GetVerb()).ToNot(Equal("update"))
+			}
+		})
+	})
+})
@@ -0,0 +1,53 @@
+package gateway
+
+import (
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/namespace"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+var (
+	log = logger.New("gateway-api")
+)
+
+// InformerCollection is a struct of the Gateway API informers used in OSM
+type InformerCollection struct {
+	GatewayClass cache.SharedIndexInformer
+	Gateway      cache.SharedIndexInformer
+	HTTPRoute    cache.SharedIndexInformer
+}
+
+// CacheCollection is a struct of the Gateway API caches used in OSM
+type CacheCollection struct {
+	GatewayClass cache.Store
+	Gateway      cache.Store
+	HTTPRoute    cache.Store
+}
+
+// Client is a struct for all components necessary to observe Gateway API resources.
+type Client struct {
+	dynamicClient       dynamic.Interface
+	informers           *InformerCollection
+	caches              *CacheCollection
+	cacheSynced         chan interface{}
+	announcements       chan interface{}
+	namespaceController namespace.Controller
+
+	// gatewayChanges receives a signal when a Gateway or GatewayClass changes
+	gatewayChanges chan struct{}
+}
+
+// Monitor is the client interface for Gateway API resources
+type Monitor interface {
+	// GetHTTPRoutes returns the HTTPRoutes bound to an OSM managed Gateway which forward traffic to the given service
+	GetHTTPRoutes(service.MeshService) ([]*HTTPRoute, error)
+
+	// GetAnnouncementsChannel returns the channel on which the Gateway API Monitor makes annoucements
+	GetAnnouncementsChannel() <-chan interface{}
+
+	// ReportStatus reports in the status of the Gateways managed by OSM that their listeners are not served
+	ReportStatus(stop <-chan struct{})
+}
@@ -29,3 +29,31 @@ func ReadinessHandler(probe Probes) http.Handler {
 func LivenessHandler(probe Probes) http.Handler {
 	return makeHandler(probe.Liveness)
 }
+
+// combinedProbes is live and ready when all its probes are
+type combinedProbes []Probes
+
+// Combine returns the probes which are live and ready when all the given probes are
+func Combine(probes ...Probes) Probes {
+	return combinedProbes(probes)
+}
+
+// Liveness returns true if all the probes are live
+func (c combinedProbes) Liveness() bool {
+	for _, probe := range c {
+		if !probe.Liveness() {
+			return false
+		}
+	}
+	return true
+}
+
+// Readiness returns true if all the probes are ready
+func (c combinedProbes) Readiness() bool {
+	for _, probe := range c {
+		if !probe.Readiness() {
+			return false
+		}
+	}
+	return true
+}
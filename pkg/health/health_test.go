@@ -0,0 +1,35 @@
+package health
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeProbes returns the given liveness and readiness
+type fakeProbes struct {
+	live, ready bool
+}
+
+func (f fakeProbes) Liveness() bool {
+	return f.live
+}
+
+func (f fakeProbes) Readiness() bool {
+	return f.ready
+}
+
+var _ = Describe("Test health probes", func() {
+	Context("Test Combine()", func() {
+		It("is ready when all the probes are ready", func() {
+			probes := Combine(fakeProbes{live: true, ready: true}, fakeProbes{live: true, ready: true})
+			Expect(probes.Liveness()).To(BeTrue())
+			Expect(probes.Readiness()).To(BeTrue())
+		})
+
+		It("is not ready when any probe is not ready", func() {
+			probes := Combine(fakeProbes{live: true, ready: true}, fakeProbes{live: true, ready: false})
+			Expect(probes.Liveness()).To(BeTrue())
+			Expect(probes.Readiness()).To(BeFalse())
+		})
+	})
+})
@@ -39,14 +39,23 @@ func NewHTTPServer(probes health.Probes, metricStore metricsstore.MetricStore, a
 		}
 	}
 
+	mux := NewHealthMux(handlers)
 	return &httpServer{
+		mux: mux,
 		server: &http.Server{
 			Addr:    fmt.Sprintf(":%d", apiPort),
-			Handler: NewHealthMux(handlers),
+			Handler: mux,
 		},
 	}
 }
 
+// AddHandlers serves the given handlers, including when the server is already started
+func (s *httpServer) AddHandlers(handlers map[string]http.Handler) {
+	for url, handler := range handlers {
+		s.mux.Handle(url, handler)
+	}
+}
+
 func (s *httpServer) Start() {
 	go func() {
 		log.Info().Msgf("Starting API Server on %s", s.server.Addr)
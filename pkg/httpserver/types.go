@@ -14,8 +14,10 @@ var (
 type HTTPServer interface {
 	Start()
 	Stop()
+	AddHandlers(handlers map[string]http.Handler)
 }
 
 type httpServer struct {
 	server *http.Server
+	mux    *http.ServeMux
 }
@@ -15,9 +15,11 @@ import (
 )
 
 // NewIngressClient implements ingress.Monitor and creates the Kubernetes client to monitor Ingress resources.
-func NewIngressClient(kubeClient kubernetes.Interface, namespaceController namespace.Controller, stop chan struct{}, cfg configurator.Configurator) (Monitor, error) {
-	informerFactory := informers.NewSharedInformerFactory(kubeClient, k8s.DefaultKubeEventResyncInterval)
-	informer := informerFactory.Extensions().V1beta1().Ingresses().Informer()
+// When watchedNamespaces is not empty, only the Ingresses of these namespaces are watched.
+func NewIngressClient(kubeClient kubernetes.Interface, namespaceController namespace.Controller, watchedNamespaces []string, stop chan struct{}, cfg configurator.Configurator) (Monitor, error) {
+	informer := k8s.NewInformer(watchedNamespaces, func(ns string) cache.SharedIndexInformer {
+		return informers.NewSharedInformerFactoryWithOptions(kubeClient, k8s.DefaultKubeEventResyncInterval, informers.WithNamespace(ns)).Extensions().V1beta1().Ingresses().Informer()
+	})
 
 	client := Client{
 		informer:            informer,
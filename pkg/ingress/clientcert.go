@@ -0,0 +1,188 @@
+package ingress
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/namespace"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// The client certificate is refreshed once less than this fraction of its validity period is left,
+	// giving the ingress controller time to reload the secret before the certificate expires.
+	clientCertRenewFraction = 3
+
+	// clientCertManagedByLabel is the label set on the secrets managed by the provisioner
+	clientCertManagedByLabel = "app.kubernetes.io/managed-by"
+)
+
+// NewClientCertProvisioner creates a provisioner issuing the client certificate an ingress controller presents
+// to backend pods, as configured in the OSM ConfigMap. The secret must be in a namespace monitored by the mesh.
+func NewClientCertProvisioner(kubeClient kubernetes.Interface, namespaceController namespace.Controller, certManager certificate.Manager, cfg configurator.Configurator, validityPeriod time.Duration) *ClientCertProvisioner {
+	return &ClientCertProvisioner{
+		kubeClient:          kubeClient,
+		namespaceController: namespaceController,
+		certManager:         certManager,
+		cfg:                 cfg,
+		validityPeriod:      validityPeriod,
+	}
+}
+
+// Start periodically checks the ingress client certificate secret and refreshes it before the certificate expires.
+func (p *ClientCertProvisioner) Start(checkInterval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(checkInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			if err := p.reconcile(); err != nil {
+				log.Error().Err(err).Msg("Error provisioning ingress client certificate")
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// reconcile ensures the configured secret holds a valid client certificate for the configured ingress service
+func (p *ClientCertProvisioner) reconcile() error {
+	svcName := p.cfg.GetIngressClientCertService()
+	secretName := p.cfg.GetIngressClientCertSecret()
+	if svcName == "" || secretName == "" {
+		log.Trace().Msg("Ingress client certificate is not configured")
+		return nil
+	}
+
+	svc, err := service.UnmarshalMeshService(svcName)
+	if err != nil {
+		log.Error().Err(err).Msgf("Invalid ingress client certificate service %s", svcName)
+		return err
+	}
+	secretNamespace, secretName, err := parseNamespacedName(secretName)
+	if err != nil {
+		log.Error().Err(err).Msgf("Invalid ingress client certificate secret %s", p.cfg.GetIngressClientCertSecret())
+		return err
+	}
+	if !p.namespaceController.IsMonitoredNamespace(secretNamespace) {
+		log.Error().Err(errUnmonitoredSecretNamespace).Msgf("Not provisioning ingress client certificate secret %s/%s", secretNamespace, secretName)
+		return errUnmonitoredSecretNamespace
+	}
+
+	secret, err := p.kubeClient.CoreV1().Secrets(secretNamespace).Get(context.Background(), secretName, metav1.GetOptions{})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		log.Error().Err(err).Msgf("Error getting ingress client certificate secret %s/%s", secretNamespace, secretName)
+		return err
+	}
+	exists := err == nil
+
+	// Secrets created by others, such as the TLS secrets of the ingress controller, are never overwritten
+	if exists && secret.Labels[clientCertManagedByLabel] != constants.OSMControllerName {
+		log.Error().Err(errUnmanagedSecret).Msgf("Not updating ingress client certificate secret %s/%s without label %s=%s",
+			secretNamespace, secretName, clientCertManagedByLabel, constants.OSMControllerName)
+		return errUnmanagedSecret
+	}
+
+	cn := svc.GetCommonName()
+	if exists && !p.shouldRefresh(secret, cn) {
+		log.Trace().Msgf("Ingress client certificate CN=%s in secret %s/%s is up to date", cn, secretNamespace, secretName)
+		return nil
+	}
+
+	cert, err := p.issue(cn)
+	if err != nil {
+		return err
+	}
+
+	data := map[string][]byte{
+		corev1.TLSCertKey:                     cert.GetCertificateChain(),
+		corev1.TLSPrivateKeyKey:               cert.GetPrivateKey(),
+		constants.KubernetesOpaqueSecretCAKey: cert.GetIssuingCA(),
+	}
+
+	if !exists {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: secretNamespace,
+				Labels: map[string]string{
+					clientCertManagedByLabel: constants.OSMControllerName,
+				},
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: data,
+		}
+		if _, err := p.kubeClient.CoreV1().Secrets(secretNamespace).Create(context.Background(), secret, metav1.CreateOptions{}); err != nil {
+			log.Error().Err(err).Msgf("Error creating ingress client certificate secret %s/%s", secretNamespace, secretName)
+			return err
+		}
+		log.Info().Msgf("Created ingress client certificate CN=%s in secret %s/%s", cn, secretNamespace, secretName)
+		return nil
+	}
+
+	secret.Data = data
+	if _, err := p.kubeClient.CoreV1().Secrets(secretNamespace).Update(context.Background(), secret, metav1.UpdateOptions{}); err != nil {
+		log.Error().Err(err).Msgf("Error updating ingress client certificate secret %s/%s", secretNamespace, secretName)
+		return err
+	}
+	log.Info().Msgf("Refreshed ingress client certificate CN=%s in secret %s/%s", cn, secretNamespace, secretName)
+	return nil
+}
+
+// issue returns a certificate for the given CN with enough validity left to not require a refresh
+func (p *ClientCertProvisioner) issue(cn certificate.CommonName) (certificate.Certificater, error) {
+	cert, err := p.certManager.IssueCertificate(cn, &p.validityPeriod)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error issuing ingress client certificate CN=%s", cn)
+		return nil, err
+	}
+
+	// The certificate manager may return a cached certificate close to its expiration
+	if time.Until(cert.GetExpiration()) > p.renewBefore() {
+		return cert, nil
+	}
+
+	cert, err = p.certManager.RotateCertificate(cn)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error rotating ingress client certificate CN=%s", cn)
+		return nil, err
+	}
+	return cert, nil
+}
+
+// shouldRefresh returns true if the secret does not hold a certificate for the given CN, or the certificate is close to its expiration
+func (p *ClientCertProvisioner) shouldRefresh(secret *corev1.Secret, cn certificate.CommonName) bool {
+	x509Cert, err := certificate.DecodePEMCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		log.Error().Err(err).Msgf("Error decoding certificate in secret %s/%s", secret.Namespace, secret.Name)
+		return true
+	}
+	if x509Cert.Subject.CommonName != cn.String() {
+		return true
+	}
+	return time.Until(x509Cert.NotAfter) <= p.renewBefore()
+}
+
+func (p *ClientCertProvisioner) renewBefore() time.Duration {
+	return p.validityPeriod / clientCertRenewFraction
+}
+
+// parseNamespacedName parses a string of the form <namespace>/<name>
+func parseNamespacedName(str string) (string, string, error) {
+	slices := strings.Split(str, "/")
+	if len(slices) != 2 || slices[0] == "" || slices[1] == "" {
+		return "", "", errInvalidNamespacedName
+	}
+	return slices[0], slices[1], nil
+}
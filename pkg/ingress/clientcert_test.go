@@ -0,0 +1,150 @@
+package ingress
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/namespace"
+)
+
+var _ = Describe("Test ingress client certificate provisioning", func() {
+	const (
+		secretNamespace = "ingress-nginx"
+		secretName      = "osm-ingress-client-cert"
+		expectedCN      = "ingress-nginx-controller.ingress-nginx.svc.cluster.local"
+	)
+
+	validityPeriod := 1 * time.Hour
+	cfg := configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{
+		IngressClientCertService: "ingress-nginx/ingress-nginx-controller",
+		IngressClientCertSecret:  secretNamespace + "/" + secretName,
+	})
+
+	newProvisioner := func() (*ClientCertProvisioner, *testclient.Clientset) {
+		kubeClient := testclient.NewSimpleClientset()
+		cache := make(map[certificate.CommonName]certificate.Certificater)
+		certManager := tresor.NewFakeCertManager(&cache, validityPeriod)
+		return NewClientCertProvisioner(kubeClient, namespace.NewFakeNamespaceController([]string{secretNamespace}), certManager, cfg, validityPeriod), kubeClient
+	}
+
+	getSecret := func(kubeClient *testclient.Clientset) *corev1.Secret {
+		secret, err := kubeClient.CoreV1().Secrets(secretNamespace).Get(context.TODO(), secretName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return secret
+	}
+
+	Context("Test reconcile()", func() {
+		It("creates the secret with a client certificate for the ingress service", func() {
+			p, kubeClient := newProvisioner()
+			Expect(p.reconcile()).To(Succeed())
+
+			secret := getSecret(kubeClient)
+			Expect(secret.Type).To(Equal(corev1.SecretTypeTLS))
+			Expect(secret.Labels[clientCertManagedByLabel]).To(Equal(constants.OSMControllerName))
+			Expect(secret.Data[corev1.TLSPrivateKeyKey]).ToNot(BeEmpty())
+			Expect(secret.Data[constants.KubernetesOpaqueSecretCAKey]).ToNot(BeEmpty())
+
+			x509Cert, err := certificate.DecodePEMCertificate(secret.Data[corev1.TLSCertKey])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(x509Cert.Subject.CommonName).To(Equal(expectedCN))
+		})
+
+		It("does not refresh a certificate which is not close to expiration", func() {
+			p, kubeClient := newProvisioner()
+			Expect(p.reconcile()).To(Succeed())
+			before := getSecret(kubeClient).Data[corev1.TLSCertKey]
+
+			Expect(p.reconcile()).To(Succeed())
+			Expect(getSecret(kubeClient).Data[corev1.TLSCertKey]).To(Equal(before))
+		})
+
+		It("refreshes the secret when it holds a certificate for another identity", func() {
+			p, kubeClient := newProvisioner()
+			otherCert, err := p.certManager.IssueCertificate("other.ingress-nginx.svc.cluster.local", &validityPeriod)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = kubeClient.CoreV1().Secrets(secretNamespace).Create(context.TODO(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      secretName,
+					Namespace: secretNamespace,
+					Labels:    map[string]string{clientCertManagedByLabel: constants.OSMControllerName},
+				},
+				Data: map[string][]byte{
+					corev1.TLSCertKey: otherCert.GetCertificateChain(),
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(p.reconcile()).To(Succeed())
+
+			x509Cert, err := certificate.DecodePEMCertificate(getSecret(kubeClient).Data[corev1.TLSCertKey])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(x509Cert.Subject.CommonName).To(Equal(expectedCN))
+		})
+
+		It("does not update a secret which is not managed by osm-controller", func() {
+			p, kubeClient := newProvisioner()
+			_, err := kubeClient.CoreV1().Secrets(secretNamespace).Create(context.TODO(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      secretName,
+					Namespace: secretNamespace,
+				},
+				Data: map[string][]byte{
+					corev1.TLSCertKey: []byte("ingress-tls-cert"),
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(p.reconcile()).To(MatchError(errUnmanagedSecret))
+			Expect(getSecret(kubeClient).Data[corev1.TLSCertKey]).To(Equal([]byte("ingress-tls-cert")))
+		})
+
+		It("does not provision a secret in a namespace which is not monitored by the mesh", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			cache := make(map[certificate.CommonName]certificate.Certificater)
+			certManager := tresor.NewFakeCertManager(&cache, validityPeriod)
+			p := NewClientCertProvisioner(kubeClient, namespace.NewFakeNamespaceController([]string{"bookstore"}), certManager, cfg, validityPeriod)
+			Expect(p.reconcile()).To(MatchError(errUnmonitoredSecretNamespace))
+
+			secrets, err := kubeClient.CoreV1().Secrets(secretNamespace).List(context.TODO(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secrets.Items).To(BeEmpty())
+		})
+
+		It("does nothing when the client certificate is not configured", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			p := NewClientCertProvisioner(kubeClient, namespace.NewFakeNamespaceController(nil), nil, configurator.NewFakeConfigurator(), validityPeriod)
+			Expect(p.reconcile()).To(Succeed())
+
+			secrets, err := kubeClient.CoreV1().Secrets(secretNamespace).List(context.TODO(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secrets.Items).To(BeEmpty())
+		})
+	})
+
+	Context("Test parseNamespacedName()", func() {
+		It("parses a namespaced name", func() {
+			namespace, name, err := parseNamespacedName("ns/name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(namespace).To(Equal("ns"))
+			Expect(name).To(Equal("name"))
+		})
+
+		It("rejects invalid namespaced names", func() {
+			for _, invalid := range []string{"name", "/name", "ns/", "ns/name/extra"} {
+				_, _, err := parseNamespacedName(invalid)
+				Expect(err).To(HaveOccurred())
+			}
+		})
+	})
+})
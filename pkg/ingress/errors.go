@@ -6,4 +6,8 @@ var (
 	errSyncingCaches = errors.New("Failed initial cache sync for Ingress informer")
 	errInitInformers = errors.New("Ingress informer not initialized")
 	errInvalidObject = errors.New("Ingress object is invalid")
+
+	errInvalidNamespacedName      = errors.New("Invalid namespaced name, expected <namespace>/<name>")
+	errUnmonitoredSecretNamespace = errors.New("Ingress client certificate secret is not in a namespace monitored by the mesh")
+	errUnmanagedSecret            = errors.New("Ingress client certificate secret is not managed by osm-controller")
 )
@@ -1,9 +1,14 @@
 package ingress
 
 import (
+	"time"
+
 	extensionsV1beta "k8s.io/api/extensions/v1beta1"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/namespace"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -22,6 +27,16 @@ type Client struct {
 	namespaceController namespace.Controller
 }
 
+// ClientCertProvisioner issues the client certificate an ingress controller presents to backend pods
+// and keeps it refreshed in a Kubernetes secret consumed by the ingress controller.
+type ClientCertProvisioner struct {
+	kubeClient          kubernetes.Interface
+	namespaceController namespace.Controller
+	certManager         certificate.Manager
+	cfg                 configurator.Configurator
+	validityPeriod      time.Duration
+}
+
 // Monitor is the client interface for K8s Ingress resource
 type Monitor interface {
 	// GetIngressResources returns the ingress resources whose backends correspond to the service
@@ -0,0 +1,334 @@
+package injector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const (
+	// BootstrapPath is the path of the webhook server on which proxies exchange their bootstrap token for their bootstrap config
+	BootstrapPath = "/bootstrap"
+
+	// BootstrapCertificatePath is the path of the webhook server on which proxies exchange their bootstrap token
+	// for the certificate they connect to XDS with
+	BootstrapCertificatePath = "/bootstrap/certificate"
+
+	// BootstrapTokenAudience is the audience of the service account tokens projected in injected pods
+	BootstrapTokenAudience = "openservicemesh.io/bootstrap"
+
+	// bootstrapTokenExpirationSeconds is the validity period of the projected tokens, the minimum allowed by Kubernetes
+	bootstrapTokenExpirationSeconds = 600
+
+	bootstrapContainerName   = "osm-bootstrap"
+	certRotatorContainerName = "osm-cert-rotator"
+	bootstrapTokenVolume     = "osm-bootstrap-token"
+	bootstrapTokenPath       = "/var/run/secrets/openservicemesh.io/bootstrap"
+	bootstrapTokenFile       = "token"
+	bootstrapCABundleEnvVar  = "BOOTSTRAP_CA_BUNDLE"
+
+	// webhookServicePort is the port of the osm-controller service forwarding to the webhook server
+	webhookServicePort = 443
+
+	// Keys of the extra info of the user of a service account token bound to a pod
+	podNameExtraKey = "authentication.kubernetes.io/pod-name"
+	podUIDExtraKey  = "authentication.kubernetes.io/pod-uid"
+
+	serviceAccountUsernamePrefix = "system:serviceaccount:"
+
+	// minXDSCertificateRefreshPeriod bounds the rate at which a pod refreshes its XDS certificate
+	minXDSCertificateRefreshPeriod = 30 * time.Second
+)
+
+// bootstrapHandler responds to a request authenticated with the bootstrap token of an injected pod with the bootstrap
+// config of its proxy. The config holds no key material: the proxy reads its certificate from the file written by the
+// certificate handler.
+func (wh *webhook) bootstrapHandler(w http.ResponseWriter, req *http.Request) {
+	pod, ok := wh.authenticateBootstrapRequest(w, req)
+	if !ok {
+		return
+	}
+
+	bootstrapConfig, err := wh.getBootstrapConfig(pod)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error creating bootstrap config for pod %s/%s", pod.Namespace, pod.Name)
+		http.Error(w, "Error creating bootstrap config", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	if _, err := w.Write(bootstrapConfig); err != nil {
+		log.Error().Err(err).Msgf("Error writing bootstrap config for pod %s/%s", pod.Namespace, pod.Name)
+	}
+}
+
+// certificateHandler responds to a request authenticated with the bootstrap token of an injected pod with the SDS file
+// holding the short-lived certificate its proxy connects to XDS with. The Cache-Control header of the response holds
+// the number of seconds after which the pod must refresh the certificate.
+func (wh *webhook) certificateHandler(w http.ResponseWriter, req *http.Request) {
+	// The proxies expected by the catalog are only tracked once its caches are synced
+	if !wh.isSynced() {
+		http.Error(w, "osm-controller is starting", http.StatusServiceUnavailable)
+		return
+	}
+
+	pod, ok := wh.authenticateBootstrapRequest(w, req)
+	if !ok {
+		return
+	}
+
+	cert, refreshAfter, err := wh.issueXDSCertificate(pod)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error issuing XDS certificate for pod %s/%s", pod.Namespace, pod.Name)
+		http.Error(w, "Error issuing certificate", http.StatusInternalServerError)
+		return
+	}
+	certYAML, err := getXDSCertificateYAML(cert)
+	if err != nil {
+		http.Error(w, "Error issuing certificate", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Header().Set("Cache-Control", fmt.Sprintf("no-store, max-age=%d", int(refreshAfter.Seconds())))
+	if _, err := w.Write(certYAML); err != nil {
+		log.Error().Err(err).Msgf("Error writing XDS certificate for pod %s/%s", pod.Namespace, pod.Name)
+	}
+}
+
+// authenticateBootstrapRequest returns the pod the bootstrap token of the given request is bound to.
+// The request is responded to with an error when it is not authenticated.
+func (wh *webhook) authenticateBootstrapRequest(w http.ResponseWriter, req *http.Request) (*corev1.Pod, bool) {
+	if req.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("Invalid method %s", req.Method), http.StatusMethodNotAllowed)
+		return nil, false
+	}
+
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == req.Header.Get("Authorization") {
+		http.Error(w, "Missing bearer token", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	pod, err := wh.authenticateBootstrapToken(token)
+	if err != nil {
+		log.Error().Err(err).Msg("Error authenticating bootstrap request")
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return nil, false
+	}
+	return pod, true
+}
+
+// authenticateBootstrapToken returns the monitored pod the given service account token is bound to.
+func (wh *webhook) authenticateBootstrapToken(token string) (*corev1.Pod, error) {
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: []string{BootstrapTokenAudience},
+		},
+	}
+	review, err := wh.kubeClient.AuthenticationV1().TokenReviews().Create(context.Background(), review, metav1.CreateOptions{})
+	if err != nil {
+		return nil, errors.Errorf("Error reviewing bootstrap token: %+v", err)
+	}
+	if !review.Status.Authenticated {
+		return nil, errors.Errorf("Invalid bootstrap token: %s", review.Status.Error)
+	}
+
+	namespace, serviceAccount, err := parseServiceAccountUsername(review.Status.User.Username)
+	if err != nil {
+		return nil, err
+	}
+	podName := getUserExtra(review.Status.User, podNameExtraKey)
+	podUID := getUserExtra(review.Status.User, podUIDExtraKey)
+	if podName == "" || podUID == "" {
+		return nil, errors.Errorf("Bootstrap token of service account %s/%s is not bound to a pod", namespace, serviceAccount)
+	}
+	if !wh.namespaceController.IsMonitoredNamespace(namespace) {
+		return nil, errors.Errorf("Namespace %s of pod %s is not monitored", namespace, podName)
+	}
+
+	pod, err := wh.kubeClient.CoreV1().Pods(namespace).Get(context.Background(), podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Errorf("Error getting pod %s/%s: %+v", namespace, podName, err)
+	}
+	// A pod recreated with the same name is not bound to the token
+	if string(pod.UID) != podUID || pod.Spec.ServiceAccountName != serviceAccount {
+		return nil, errors.Errorf("Bootstrap token is not bound to pod %s/%s", namespace, podName)
+	}
+	return pod, nil
+}
+
+// getBootstrapConfig returns the bootstrap config of the proxy of the given pod.
+func (wh *webhook) getBootstrapConfig(pod *corev1.Pod) ([]byte, error) {
+	if _, ok := pod.Labels[constants.EnvoyUniqueIDLabelName]; !ok {
+		return nil, errors.Errorf("Pod %s/%s has no %s label", pod.Namespace, pod.Name, constants.EnvoyUniqueIDLabelName)
+	}
+	return getSidecarBootstrapConfigYAML(wh.configurator, wh.osmNamespace, wh.cert.GetIssuingCA())
+}
+
+// issueXDSCertificate returns the certificate the proxy of the given pod connects to XDS with, valid for the validity
+// period of the service certificates, and the time after which the proxy must refresh it.
+// A certificate past half of its validity period is renewed, so that the certificate of the proxy never gets
+// closer than half of its validity period to its expiration.
+func (wh *webhook) issueXDSCertificate(pod *corev1.Pod) (certificate.Certificater, time.Duration, error) {
+	proxyUUID, ok := pod.Labels[constants.EnvoyUniqueIDLabelName]
+	if !ok {
+		return nil, 0, errors.Errorf("Pod %s/%s has no %s label", pod.Namespace, pod.Name, constants.EnvoyUniqueIDLabelName)
+	}
+
+	// The certificate is only used for Envoy to connect to XDS (not Envoy-to-Envoy connections)
+	cn := catalog.NewCertCommonNameWithProxyID(proxyUUID, pod.Spec.ServiceAccountName, pod.Namespace)
+	cert, err := wh.certManager.IssueCertificate(cn, nil)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error issuing XDS certificate for Envoy with CN=%s", cn)
+		return nil, 0, err
+	}
+
+	halfLife, err := getCertificateHalfLife(cert)
+	if err != nil {
+		return nil, 0, err
+	}
+	if time.Now().After(halfLife) {
+		if cert, err = wh.certManager.RotateCertificate(cn); err != nil {
+			log.Error().Err(err).Msgf("Error renewing XDS certificate for Envoy with CN=%s", cn)
+			return nil, 0, err
+		}
+		if halfLife, err = getCertificateHalfLife(cert); err != nil {
+			return nil, 0, err
+		}
+	}
+	log.Info().Msgf("Issued XDS certificate for proxy of pod %s/%s with CN=%s, expiring at %s", pod.Namespace, pod.Name, cn, cert.GetExpiration())
+
+	wh.meshCatalog.ExpectProxy(cn)
+
+	refreshAfter := time.Until(halfLife)
+	if refreshAfter < minXDSCertificateRefreshPeriod {
+		refreshAfter = minXDSCertificateRefreshPeriod
+	}
+	return cert, refreshAfter, nil
+}
+
+// getCertificateHalfLife returns the time at which the given certificate is past half of its validity period.
+func getCertificateHalfLife(cert certificate.Certificater) (time.Time, error) {
+	x509Cert, err := certificate.DecodePEMCertificate(cert.GetCertificateChain())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error decoding certificate with CN=%s", cert.GetCommonName())
+		return time.Time{}, err
+	}
+	return x509Cert.NotBefore.Add(x509Cert.NotAfter.Sub(x509Cert.NotBefore) / 2), nil
+}
+
+// getBootstrapContainerSpec returns the init container exchanging the bootstrap token of the pod for the bootstrap config
+// of Envoy and the certificate it connects to XDS with.
+// The container runs as the user of Envoy, whose traffic is not redirected.
+func (wh *webhook) getBootstrapContainerSpec(image string, isWindows bool) corev1.Container {
+	uid := constants.EnvoyUID
+	runAsNonRoot := true
+	allowPrivilegeEscalation := false
+	readOnlyRootFilesystem := true
+
+	container := corev1.Container{
+		Name:    bootstrapContainerName,
+		Image:   image,
+		Command: []string{"sh", "init-bootstrap.sh"},
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:                &uid,
+			RunAsNonRoot:             &runAsNonRoot,
+			AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+			ReadOnlyRootFilesystem:   &readOnlyRootFilesystem,
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{capabilityAll},
+			},
+		},
+		Env: []corev1.EnvVar{
+			{
+				Name:  "BOOTSTRAP_URL",
+				Value: fmt.Sprintf("https://%s.%s.svc:%d%s", constants.OSMControllerName, wh.osmNamespace, webhookServicePort, BootstrapPath),
+			},
+			{
+				Name:  "BOOTSTRAP_CERTIFICATE_URL",
+				Value: fmt.Sprintf("https://%s.%s.svc:%d%s", constants.OSMControllerName, wh.osmNamespace, webhookServicePort, BootstrapCertificatePath),
+			},
+			{
+				// The root certificate is not secret: it only lets the container verify the webhook server
+				Name:  bootstrapCABundleEnvVar,
+				Value: string(wh.cert.GetIssuingCA()),
+			},
+			{
+				Name:  "BOOTSTRAP_TOKEN_FILE",
+				Value: strings.Join([]string{bootstrapTokenPath, bootstrapTokenFile}, "/"),
+			},
+			{
+				Name:  "BOOTSTRAP_CONFIG_FILE",
+				Value: strings.Join([]string{envoyProxyConfigPath, envoyBootstrapConfigFile}, "/"),
+			},
+			{
+				Name:  "XDS_CERTIFICATE_FILE",
+				Value: strings.Join([]string{envoyProxyConfigPath, envoyXDSCertificateFile}, "/"),
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      bootstrapTokenVolume,
+				ReadOnly:  true,
+				MountPath: bootstrapTokenPath,
+			},
+			{
+				Name:      envoyBootstrapConfigVolume,
+				MountPath: envoyProxyConfigPath,
+			},
+		},
+	}
+
+	if isWindows {
+		userName := envoyWindowsUserName
+		container.Command = []string{"powershell", "-NoProfile", "-ExecutionPolicy", "Bypass", "-File", "init-bootstrap.ps1"}
+		container.SecurityContext = &corev1.SecurityContext{
+			WindowsOptions: &corev1.WindowsSecurityContextOptions{
+				RunAsUserName: &userName,
+			},
+		}
+	}
+	return container
+}
+
+// getCertRotatorContainerSpec returns the container exchanging the bootstrap token of the pod for a new certificate
+// for Envoy to connect to XDS with, before the certificate written by the bootstrap container expires.
+func (wh *webhook) getCertRotatorContainerSpec(image string, isWindows bool) corev1.Container {
+	container := wh.getBootstrapContainerSpec(image, isWindows)
+	container.Name = certRotatorContainerName
+	if isWindows {
+		container.Command = append(container.Command, "-Rotate")
+	} else {
+		container.Command = append(container.Command, "rotate")
+	}
+	return container
+}
+
+// parseServiceAccountUsername returns the namespace and name of the service account with the given user name.
+func parseServiceAccountUsername(username string) (string, string, error) {
+	parts := strings.Split(strings.TrimPrefix(username, serviceAccountUsernamePrefix), ":")
+	if !strings.HasPrefix(username, serviceAccountUsernamePrefix) || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf("Bootstrap token of user %s is not a service account token", username)
+	}
+	return parts[0], parts[1], nil
+}
+
+func getUserExtra(user authenticationv1.UserInfo, key string) string {
+	if values := user.Extra[key]; len(values) == 1 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,217 @@
+package injector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/namespace"
+)
+
+var _ = Describe("Test proxy bootstrap", func() {
+	const (
+		monitoredNamespace = "bookstore"
+		validToken         = "valid-token"
+	)
+
+	var (
+		kubeClient *fake.Clientset
+		wh         *webhook
+	)
+
+	BeforeEach(func() {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bookstore-1",
+				Namespace: monitoredNamespace,
+				UID:       "pod-uid",
+				Labels:    map[string]string{constants.EnvoyUniqueIDLabelName: "proxy-uuid"},
+			},
+			Spec: corev1.PodSpec{ServiceAccountName: "bookstore"},
+		}
+		kubeClient = fake.NewSimpleClientset(pod)
+
+		// Tokens are reviewed by the API server: only validToken is bound to the pod
+		kubeClient.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+			if review.Spec.Token == validToken && len(review.Spec.Audiences) == 1 && review.Spec.Audiences[0] == BootstrapTokenAudience {
+				review.Status = authenticationv1.TokenReviewStatus{
+					Authenticated: true,
+					User: authenticationv1.UserInfo{
+						Username: "system:serviceaccount:bookstore:bookstore",
+						Extra: map[string]authenticationv1.ExtraValue{
+							podNameExtraKey: {"bookstore-1"},
+							podUIDExtraKey:  {"pod-uid"},
+						},
+					},
+				}
+			} else {
+				review.Status = authenticationv1.TokenReviewStatus{Error: "invalid token"}
+			}
+			return true, review, nil
+		})
+
+		cache := make(map[certificate.CommonName]certificate.Certificater)
+		certManager := tresor.NewFakeCertManager(&cache, 1*time.Hour)
+		cert, err := certManager.IssueCertificate("osm-controller.osm-system.svc", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		wh = &webhook{
+			kubeClient:          kubeClient,
+			certManager:         certManager,
+			namespaceController: namespace.NewFakeNamespaceController([]string{monitoredNamespace}),
+			osmNamespace:        "osm-system",
+			cert:                cert,
+			configurator:        configurator.NewFakeConfigurator(),
+			synced:              make(chan struct{}),
+		}
+		wh.Start(catalog.NewFakeMeshCatalog(kubeClient))
+	})
+
+	bootstrap := func(method, authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, BootstrapPath, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		wh.bootstrapHandler(w, req)
+		return w
+	}
+
+	Context("Test bootstrapHandler()", func() {
+		It("returns the bootstrap config of the pod the token is bound to, without key material", func() {
+			w := bootstrap(http.MethodPost, "Bearer "+validToken)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring("osm-controller.osm-system.svc.cluster.local"))
+			Expect(w.Body.String()).To(ContainSubstring("path: /etc/envoy/xds_certificate.yaml"))
+			Expect(w.Body.String()).ToNot(ContainSubstring("private_key"))
+		})
+
+		It("rejects requests without a valid token", func() {
+			Expect(bootstrap(http.MethodPost, "").Code).To(Equal(http.StatusUnauthorized))
+			Expect(bootstrap(http.MethodPost, validToken).Code).To(Equal(http.StatusUnauthorized))
+			Expect(bootstrap(http.MethodPost, "Bearer invalid").Code).To(Equal(http.StatusUnauthorized))
+			Expect(bootstrap(http.MethodGet, "Bearer "+validToken).Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+
+	Context("Test certificateHandler()", func() {
+		It("returns a short-lived certificate of the pod the token is bound to", func() {
+			req := httptest.NewRequest(http.MethodPost, BootstrapCertificatePath, nil)
+			req.Header.Set("Authorization", "Bearer "+validToken)
+			w := httptest.NewRecorder()
+			wh.certificateHandler(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring("name: xds_certificate"))
+			Expect(w.Body.String()).To(ContainSubstring("private_key"))
+			// The certificate is valid for an hour, and refreshed halfway through its validity period
+			Expect(w.Header().Get("Cache-Control")).To(MatchRegexp(`^no-store, max-age=1[78][0-9]{2}$`))
+		})
+
+		It("refuses certificates until the webhook is started", func() {
+			wh.synced = make(chan struct{})
+			req := httptest.NewRequest(http.MethodPost, BootstrapCertificatePath, nil)
+			req.Header.Set("Authorization", "Bearer "+validToken)
+			w := httptest.NewRecorder()
+			wh.certificateHandler(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusServiceUnavailable))
+		})
+	})
+
+	Context("Test authenticateBootstrapToken()", func() {
+		It("rejects tokens bound to a pod recreated with the same name", func() {
+			pod, err := kubeClient.CoreV1().Pods(monitoredNamespace).Get(context.Background(), "bookstore-1", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			pod.UID = "other-pod-uid"
+			_, err = kubeClient.CoreV1().Pods(monitoredNamespace).Update(context.Background(), pod, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = wh.authenticateBootstrapToken(validToken)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects tokens of pods in namespaces which are not monitored", func() {
+			wh.namespaceController = namespace.NewFakeNamespaceController([]string{"other"})
+			_, err := wh.authenticateBootstrapToken(validToken)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Test parseServiceAccountUsername()", func() {
+		It("parses the namespace and name of service accounts", func() {
+			ns, name, err := parseServiceAccountUsername("system:serviceaccount:bookstore:bookstore-v1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ns).To(Equal("bookstore"))
+			Expect(name).To(Equal("bookstore-v1"))
+
+			for _, username := range []string{"admin", "system:serviceaccount:bookstore", "system:serviceaccount::bookstore", "system:node:node-1"} {
+				_, _, err = parseServiceAccountUsername(username)
+				Expect(err).To(HaveOccurred())
+			}
+		})
+	})
+
+	Context("Test getBootstrapContainerSpec()", func() {
+		It("exchanges the projected token for the bootstrap config as the user of Envoy", func() {
+			container := wh.getBootstrapContainerSpec("init", false)
+			Expect(container.Name).To(Equal(bootstrapContainerName))
+			Expect(*container.SecurityContext.RunAsUser).To(Equal(constants.EnvoyUID))
+			Expect(container.Env).To(ContainElement(corev1.EnvVar{
+				Name:  "BOOTSTRAP_URL",
+				Value: "https://osm-controller.osm-system.svc:443/bootstrap",
+			}))
+			Expect(container.Env).To(ContainElement(corev1.EnvVar{
+				Name:  bootstrapCABundleEnvVar,
+				Value: string(wh.cert.GetIssuingCA()),
+			}))
+			Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      envoyBootstrapConfigVolume,
+				MountPath: envoyProxyConfigPath,
+			}))
+		})
+
+		It("refreshes the certificate of Envoy in a long-running container", func() {
+			container := wh.getCertRotatorContainerSpec("init", false)
+			Expect(container.Name).To(Equal(certRotatorContainerName))
+			Expect(container.Command).To(Equal([]string{"sh", "init-bootstrap.sh", "rotate"}))
+			Expect(*container.SecurityContext.RunAsUser).To(Equal(constants.EnvoyUID))
+			Expect(container.Env).To(ContainElement(corev1.EnvVar{
+				Name:  "BOOTSTRAP_CERTIFICATE_URL",
+				Value: "https://osm-controller.osm-system.svc:443/bootstrap/certificate",
+			}))
+
+			Expect(wh.getCertRotatorContainerSpec("init", true).Command).To(ContainElement("-Rotate"))
+		})
+	})
+
+	Context("Test getVolumeSpec()", func() {
+		It("keeps the bootstrap config in memory and projects a short-lived token", func() {
+			volumes := getVolumeSpec(false)
+			Expect(len(volumes)).To(Equal(2))
+			Expect(volumes[0].EmptyDir.Medium).To(Equal(corev1.StorageMediumMemory))
+			Expect(volumes[1].Projected.Sources[0].ServiceAccountToken.Audience).To(Equal(BootstrapTokenAudience))
+			Expect(*volumes[1].Projected.Sources[0].ServiceAccountToken.ExpirationSeconds).To(Equal(int64(bootstrapTokenExpirationSeconds)))
+
+			// Windows does not support memory-backed volumes
+			Expect(getVolumeSpec(true)[0].EmptyDir.Medium).To(Equal(corev1.StorageMediumDefault))
+		})
+	})
+})
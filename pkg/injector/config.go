@@ -5,10 +5,12 @@ import (
 	"encoding/base64"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
@@ -20,10 +22,7 @@ func getEnvoyConfigYAML(config envoyBootstrapConfigMeta, cfg configurator.Config
 		"admin": map[string]interface{}{
 			"access_log_path": "/dev/stdout",
 			"address": map[string]interface{}{
-				"socket_address": map[string]string{
-					"address":    "0.0.0.0",
-					"port_value": strconv.Itoa(config.EnvoyAdminPort),
-				},
+				"socket_address": getAdminSocketAddress(config.EnvoyAdminPort, cfg.GetIPFamily()),
 			},
 		},
 
@@ -60,31 +59,8 @@ func getEnvoyConfigYAML(config envoyBootstrapConfigMeta, cfg configurator.Config
 					"transport_socket": map[string]interface{}{
 						"name": "envoy.transport_sockets.tls",
 						"typed_config": map[string]interface{}{
-							"@type": "type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.UpstreamTlsContext",
-							"common_tls_context": map[string]interface{}{
-								"alpn_protocols": []string{
-									"h2",
-								},
-								"validation_context": map[string]interface{}{
-									"trusted_ca": map[string]interface{}{
-										"inline_bytes": config.RootCert,
-									},
-								},
-								"tls_params": map[string]interface{}{
-									"tls_minimum_protocol_version": "TLSv1_2",
-									"tls_maximum_protocol_version": "TLSv1_3",
-								},
-								"tls_certificates": []map[string]interface{}{
-									{
-										"certificate_chain": map[string]interface{}{
-											"inline_bytes": config.Cert,
-										},
-										"private_key": map[string]interface{}{
-											"inline_bytes": config.Key,
-										},
-									},
-								},
-							},
+							"@type":              "type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.UpstreamTlsContext",
+							"common_tls_context": getXDSCommonTLSContext(config),
 						},
 					},
 					"load_assignment": map[string]interface{}{
@@ -119,7 +95,69 @@ func getEnvoyConfigYAML(config envoyBootstrapConfigMeta, cfg configurator.Config
 	return configYAML, err
 }
 
-func (wh *webhook) createEnvoyBootstrapConfig(name, namespace, osmNamespace string, cert certificate.Certificater) (*corev1.Secret, error) {
+// getXDSCommonTLSContext returns the TLS context of the connection to XDS. The certificate of the proxy is either inlined,
+// or read from an SDS file Envoy reloads when the file is replaced.
+func getXDSCommonTLSContext(config envoyBootstrapConfigMeta) map[string]interface{} {
+	tlsContext := map[string]interface{}{
+		"alpn_protocols": []string{
+			"h2",
+		},
+		"validation_context": map[string]interface{}{
+			"trusted_ca": map[string]interface{}{
+				"inline_bytes": config.RootCert,
+			},
+		},
+		"tls_params": map[string]interface{}{
+			"tls_minimum_protocol_version": "TLSv1_2",
+			"tls_maximum_protocol_version": "TLSv1_3",
+		},
+	}
+
+	if config.CertSDSPath != "" {
+		tlsContext["tls_certificate_sds_secret_configs"] = []map[string]interface{}{
+			{
+				"name": xdsCertificateSecretName,
+				"sds_config": map[string]interface{}{
+					"path":                 config.CertSDSPath,
+					"resource_api_version": "V3",
+				},
+			},
+		}
+		return tlsContext
+	}
+
+	tlsContext["tls_certificates"] = []map[string]interface{}{
+		{
+			"certificate_chain": map[string]interface{}{
+				"inline_bytes": config.Cert,
+			},
+			"private_key": map[string]interface{}{
+				"inline_bytes": config.Key,
+			},
+		},
+	}
+	return tlsContext
+}
+
+// getAdminSocketAddress returns the socket address of Envoy's admin interface, listening on all local addresses of the given IP family.
+func getAdminSocketAddress(port int, ipFamily configurator.IPFamily) map[string]interface{} {
+	socketAddress := map[string]interface{}{
+		"address":    constants.WildcardIPAddr,
+		"port_value": strconv.Itoa(port),
+	}
+	switch ipFamily {
+	case configurator.IPv6:
+		socketAddress["address"] = constants.WildcardIPv6Addr
+	case configurator.DualStack:
+		// IPv4 connections are accepted as IPv4-mapped IPv6 addresses
+		socketAddress["address"] = constants.WildcardIPv6Addr
+		socketAddress["ipv4_compat"] = true
+	}
+	return socketAddress
+}
+
+// getEnvoyBootstrapConfigYAML returns the bootstrap config of an Envoy proxy connecting to XDS with the given certificate.
+func getEnvoyBootstrapConfigYAML(cfg configurator.Configurator, osmNamespace string, cert certificate.Certificater) ([]byte, error) {
 	configMeta := envoyBootstrapConfigMeta{
 		EnvoyAdminPort: constants.EnvoyAdminPort,
 		XDSClusterName: constants.OSMControllerName,
@@ -131,11 +169,69 @@ func (wh *webhook) createEnvoyBootstrapConfig(name, namespace, osmNamespace stri
 		XDSHost: fmt.Sprintf("%s.%s.svc.cluster.local", constants.OSMControllerName, osmNamespace),
 		XDSPort: constants.OSMControllerPort,
 	}
-	yamlContent, err := getEnvoyConfigYAML(configMeta, wh.configurator)
+	yamlContent, err := getEnvoyConfigYAML(configMeta, cfg)
 	if err != nil {
 		log.Error().Err(err).Msg("Error creating Envoy bootstrap YAML")
 		return nil, err
 	}
+	return yamlContent, nil
+}
+
+// getSidecarBootstrapConfigYAML returns the bootstrap config of an injected Envoy proxy, which connects to XDS with the
+// certificate of the SDS file written next to the bootstrap config. The config holds no key material.
+func getSidecarBootstrapConfigYAML(cfg configurator.Configurator, osmNamespace string, rootCert []byte) ([]byte, error) {
+	configMeta := envoyBootstrapConfigMeta{
+		EnvoyAdminPort: constants.EnvoyAdminPort,
+		XDSClusterName: constants.OSMControllerName,
+
+		RootCert:    base64.StdEncoding.EncodeToString(rootCert),
+		CertSDSPath: strings.Join([]string{envoyProxyConfigPath, envoyXDSCertificateFile}, "/"),
+
+		XDSHost: fmt.Sprintf("%s.%s.svc.cluster.local", constants.OSMControllerName, osmNamespace),
+		XDSPort: constants.OSMControllerPort,
+	}
+	yamlContent, err := getEnvoyConfigYAML(configMeta, cfg)
+	if err != nil {
+		log.Error().Err(err).Msg("Error creating Envoy bootstrap YAML")
+		return nil, err
+	}
+	return yamlContent, nil
+}
+
+// getXDSCertificateYAML returns the SDS file holding the certificate an injected Envoy proxy connects to XDS with.
+func getXDSCertificateYAML(cert certificate.Certificater) ([]byte, error) {
+	m := map[string]interface{}{
+		"resources": []map[string]interface{}{
+			{
+				"@type": "type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.Secret",
+				"name":  xdsCertificateSecretName,
+				"tls_certificate": map[string]interface{}{
+					"certificate_chain": map[string]interface{}{
+						"inline_bytes": base64.StdEncoding.EncodeToString(cert.GetCertificateChain()),
+					},
+					"private_key": map[string]interface{}{
+						"inline_bytes": base64.StdEncoding.EncodeToString(cert.GetPrivateKey()),
+					},
+				},
+			},
+		},
+	}
+
+	certYAML, err := yaml.Marshal(&m)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshaling XDS certificate of Envoy into YAML")
+		return nil, err
+	}
+	return certYAML, nil
+}
+
+// CreateEnvoyBootstrapConfig creates or updates the Kubernetes secret with the bootstrap config of an Envoy proxy
+// connecting to XDS with the given certificate.
+func CreateEnvoyBootstrapConfig(kubeClient kubernetes.Interface, cfg configurator.Configurator, name, namespace, osmNamespace string, cert certificate.Certificater) (*corev1.Secret, error) {
+	yamlContent, err := getEnvoyBootstrapConfigYAML(cfg, osmNamespace, cert)
+	if err != nil {
+		return nil, err
+	}
 
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -145,12 +241,12 @@ func (wh *webhook) createEnvoyBootstrapConfig(name, namespace, osmNamespace stri
 			envoyBootstrapConfigFile: yamlContent,
 		},
 	}
-	if existing, err := wh.kubeClient.CoreV1().Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{}); err == nil {
+	if existing, err := kubeClient.CoreV1().Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{}); err == nil {
 		log.Info().Msgf("Updating bootstrap config Envoy: name=%s, namespace=%s", name, namespace)
 		existing.Data = secret.Data
-		return wh.kubeClient.CoreV1().Secrets(namespace).Update(context.Background(), existing, metav1.UpdateOptions{})
+		return kubeClient.CoreV1().Secrets(namespace).Update(context.Background(), existing, metav1.UpdateOptions{})
 	}
 
 	log.Info().Msgf("Creating bootstrap config for Envoy: name=%s, namespace=%s", name, namespace)
-	return wh.kubeClient.CoreV1().Secrets(namespace).Create(context.Background(), secret, metav1.CreateOptions{})
+	return kubeClient.CoreV1().Secrets(namespace).Create(context.Background(), secret, metav1.CreateOptions{})
 }
@@ -88,6 +88,18 @@ var _ = Describe("Test Envoy configuration creation", func() {
 			Expect(string(actual)).To(Equal(expectedEnvoyConfig[1:]),
 				fmt.Sprintf("Expected:\n%s\nActual:\n%s\n", expectedEnvoyConfig, string(actual)))
 		})
+
+		It("listens for admin requests on the addresses of the IP family of the pod", func() {
+			Expect(getAdminSocketAddress(3465, configurator.IPv6)).To(Equal(map[string]interface{}{
+				"address":    "::",
+				"port_value": "3465",
+			}))
+			Expect(getAdminSocketAddress(3465, configurator.DualStack)).To(Equal(map[string]interface{}{
+				"address":     "::",
+				"port_value":  "3465",
+				"ipv4_compat": true,
+			}))
+		})
 	})
 })
 
@@ -106,6 +118,21 @@ var _ = Describe("Test Envoy sidecar", func() {
 						uid := constants.EnvoyUID
 						return &uid
 					}(),
+					RunAsNonRoot: func() *bool {
+						runAsNonRoot := true
+						return &runAsNonRoot
+					}(),
+					AllowPrivilegeEscalation: func() *bool {
+						allowPrivilegeEscalation := false
+						return &allowPrivilegeEscalation
+					}(),
+					ReadOnlyRootFilesystem: func() *bool {
+						readOnlyRootFilesystem := true
+						return &readOnlyRootFilesystem
+					}(),
+					Capabilities: &corev1.Capabilities{
+						Drop: []corev1.Capability{"ALL"},
+					},
 				},
 				Ports: []corev1.ContainerPort{
 					{
@@ -143,3 +170,18 @@ var _ = Describe("Test Envoy sidecar", func() {
 		})
 	})
 })
+
+var _ = Describe("Test init container", func() {
+	Context("create init container", func() {
+		It("only grants the capabilities iptables needs", func() {
+			container, err := getInitContainerSpec(&corev1.Pod{}, &InitContainerData{Name: InitContainerName, Image: "init", IPFamily: configurator.IPv4})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(container.SecurityContext.Capabilities).To(Equal(&corev1.Capabilities{
+				Add:  []corev1.Capability{"NET_ADMIN", "NET_RAW"},
+				Drop: []corev1.Capability{"ALL"},
+			}))
+			Expect(*container.SecurityContext.AllowPrivilegeEscalation).To(BeFalse())
+			Expect(container.SecurityContext.Privileged).To(BeNil())
+		})
+	})
+})
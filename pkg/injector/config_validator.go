@@ -0,0 +1,62 @@
+package injector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+const (
+	osmConfigValidatorWebhookName = "osm-config-validator.k8s.io"
+	osmWebhookValidateConfigPath  = "/validate-config"
+
+	// osmConfigMeshLabel is set by the chart to the mesh name on the OSM ConfigMap, the only ConfigMap sent to the webhook
+	osmConfigMeshLabel = "openservicemesh.io/osm-config"
+
+	// osmConfigValidatorTimeoutSeconds bounds the latency the webhook adds to ConfigMap updates
+	osmConfigValidatorTimeoutSeconds = 5
+)
+
+func (wh *webhook) validateConfigHandler(w http.ResponseWriter, req *http.Request) {
+	wh.serveAdmission(w, req, wh.validateConfig)
+}
+
+// validateConfig denies creating or updating the OSM ConfigMap with invalid values, which the controller would
+// otherwise ignore. Other ConfigMaps are allowed.
+func (wh *webhook) validateConfig(req *v1beta1.AdmissionRequest) *v1beta1.AdmissionResponse {
+	resp := &v1beta1.AdmissionResponse{
+		Allowed: true,
+		UID:     req.UID,
+	}
+	// Only ConfigMaps labeled with the mesh name are sent to the webhook, which may be set in any namespace
+	if (req.Operation != v1beta1.Create && req.Operation != v1beta1.Update) || req.Namespace != wh.osmNamespace {
+		return resp
+	}
+
+	var configMap corev1.ConfigMap
+	if err := json.Unmarshal(req.Object.Raw, &configMap); err != nil {
+		log.Error().Err(err).Msg("Error unmarshaling request to ConfigMap")
+		return toAdmissionError(err)
+	}
+	if configMap.Name != wh.osmConfigMapName {
+		return resp
+	}
+
+	if reasons := configurator.ValidateConfigMap(configMap.Data); len(reasons) > 0 {
+		message := fmt.Sprintf("Invalid ConfigMap %s/%s: %s", req.Namespace, configMap.Name, strings.Join(reasons, "; "))
+		log.Warn().Msgf("Denied %s of ConfigMap %s/%s: %s", req.Operation, req.Namespace, configMap.Name, strings.Join(reasons, "; "))
+		resp.Allowed = false
+		resp.Result = &metav1.Status{
+			Reason:  metav1.StatusReasonInvalid,
+			Message: message,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,72 @@
+package injector
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("Test OSM ConfigMap validating webhook", func() {
+	newConfigMapRaw := func(name string, data map[string]string) runtime.RawExtension {
+		configMap := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "osm-system",
+			},
+			Data: data,
+		}
+		raw, err := json.Marshal(configMap)
+		Expect(err).ToNot(HaveOccurred())
+		return runtime.RawExtension{Raw: raw}
+	}
+
+	wh := &webhook{
+		osmNamespace:     "osm-system",
+		osmConfigMapName: "osm-config",
+	}
+
+	Context("Test validateConfig()", func() {
+		It("denies invalid values in the OSM ConfigMap", func() {
+			resp := wh.validateConfig(&v1beta1.AdmissionRequest{
+				Operation: v1beta1.Update,
+				Namespace: "osm-system",
+				Object:    newConfigMapRaw("osm-config", map[string]string{"egress": "yes"}),
+			})
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Reason).To(Equal(metav1.StatusReasonInvalid))
+			Expect(resp.Result.Message).To(Equal(`Invalid ConfigMap osm-system/osm-config: egress: must be "true" or "false", got "yes"`))
+		})
+
+		It("allows valid values in the OSM ConfigMap", func() {
+			resp := wh.validateConfig(&v1beta1.AdmissionRequest{
+				Operation: v1beta1.Create,
+				Namespace: "osm-system",
+				Object:    newConfigMapRaw("osm-config", map[string]string{"egress": "false"}),
+			})
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("allows other ConfigMaps", func() {
+			resp := wh.validateConfig(&v1beta1.AdmissionRequest{
+				Operation: v1beta1.Update,
+				Namespace: "osm-system",
+				Object:    newConfigMapRaw("other", map[string]string{"egress": "yes"}),
+			})
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("allows ConfigMaps named like the OSM ConfigMap in other namespaces", func() {
+			resp := wh.validateConfig(&v1beta1.AdmissionRequest{
+				Operation: v1beta1.Update,
+				Namespace: "default",
+				Object:    newConfigMapRaw("osm-config", map[string]string{"egress": "yes"}),
+			})
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+})
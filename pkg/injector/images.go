@@ -0,0 +1,74 @@
+package injector
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// osLabel and betaOSLabel are the node labels holding the operating system of the node
+	osLabel     = "kubernetes.io/os"
+	betaOSLabel = "beta.kubernetes.io/os"
+
+	// archLabel and betaArchLabel are the node labels holding the architecture of the node
+	archLabel     = "kubernetes.io/arch"
+	betaArchLabel = "beta.kubernetes.io/arch"
+)
+
+// getImages returns the init container and sidecar images of the given pod.
+// Pods which can only be scheduled on nodes of a given architecture get the images overridden for it in the ConfigMap,
+// other pods get the default images, which should be multi-architecture images.
+func (wh *webhook) getImages(pod *corev1.Pod) (initContainerImage, sidecarImage string) {
+	if isWindowsPod(pod) {
+		return wh.config.InitContainerWindowsImage, wh.config.SidecarWindowsImage
+	}
+
+	initContainerImage, sidecarImage = wh.config.InitContainerImage, wh.config.SidecarImage
+	arch, ok := getRequiredNodeLabel(pod, archLabel, betaArchLabel)
+	if !ok {
+		return initContainerImage, sidecarImage
+	}
+	if image := wh.configurator.GetInitContainerImage(arch); image != "" {
+		initContainerImage = image
+	}
+	if image := wh.configurator.GetSidecarImage(arch); image != "" {
+		sidecarImage = image
+	}
+	return initContainerImage, sidecarImage
+}
+
+// getRequiredNodeLabel returns the value the nodes the given pod can be scheduled on must have for the first of the given labels,
+// as required by its node selector or the node affinity required during scheduling, and whether there is one.
+func getRequiredNodeLabel(pod *corev1.Pod, labels ...string) (string, bool) {
+	for _, label := range labels {
+		if value, ok := pod.Spec.NodeSelector[label]; ok {
+			return value, true
+		}
+	}
+
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil || pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return "", false
+	}
+
+	// The pod is scheduled on a node matching any of the terms, so each of them must require the same value
+	var required string
+	for _, term := range pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		value, ok := getRequiredTermLabel(term, labels)
+		if !ok || (required != "" && value != required) {
+			return "", false
+		}
+		required = value
+	}
+	return required, required != ""
+}
+
+// getRequiredTermLabel returns the single value the given node selector term requires for one of the given labels, and whether there is one.
+func getRequiredTermLabel(term corev1.NodeSelectorTerm, labels []string) (string, bool) {
+	for _, requirement := range term.MatchExpressions {
+		for _, label := range labels {
+			if requirement.Key == label && requirement.Operator == corev1.NodeSelectorOpIn && len(requirement.Values) == 1 {
+				return requirement.Values[0], true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,95 @@
+package injector
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+var _ = Describe("Test image selection", func() {
+	wh := &webhook{
+		config: Config{
+			InitContainerImage:        "init",
+			SidecarImage:              "envoy",
+			InitContainerWindowsImage: "init-windows",
+			SidecarWindowsImage:       "envoy-windows",
+		},
+		configurator: configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{
+			SidecarImages:       map[string]string{"arm64": "envoy-arm64"},
+			InitContainerImages: map[string]string{"arm64": "init-arm64"},
+		}),
+	}
+
+	newPodWithAffinity := func(terms ...corev1.NodeSelectorTerm) *corev1.Pod {
+		return &corev1.Pod{
+			Spec: corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: terms},
+					},
+				},
+			},
+		}
+	}
+
+	newArchTerm := func(arch string) corev1.NodeSelectorTerm {
+		return corev1.NodeSelectorTerm{
+			MatchExpressions: []corev1.NodeSelectorRequirement{{
+				Key:      archLabel,
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{arch},
+			}},
+		}
+	}
+
+	Context("Test getImages()", func() {
+		It("returns the default images of pods without a required architecture", func() {
+			initContainerImage, sidecarImage := wh.getImages(&corev1.Pod{})
+			Expect(initContainerImage).To(Equal("init"))
+			Expect(sidecarImage).To(Equal("envoy"))
+		})
+
+		It("returns the images overridden for the architecture required by the node selector", func() {
+			pod := &corev1.Pod{Spec: corev1.PodSpec{NodeSelector: map[string]string{betaArchLabel: "arm64"}}}
+			initContainerImage, sidecarImage := wh.getImages(pod)
+			Expect(initContainerImage).To(Equal("init-arm64"))
+			Expect(sidecarImage).To(Equal("envoy-arm64"))
+		})
+
+		It("returns the default images of architectures without overrides", func() {
+			pod := &corev1.Pod{Spec: corev1.PodSpec{NodeSelector: map[string]string{archLabel: "amd64"}}}
+			initContainerImage, sidecarImage := wh.getImages(pod)
+			Expect(initContainerImage).To(Equal("init"))
+			Expect(sidecarImage).To(Equal("envoy"))
+		})
+
+		It("returns the Windows images of Windows pods", func() {
+			pod := &corev1.Pod{Spec: corev1.PodSpec{NodeSelector: map[string]string{osLabel: windowsOS}}}
+			initContainerImage, sidecarImage := wh.getImages(pod)
+			Expect(initContainerImage).To(Equal("init-windows"))
+			Expect(sidecarImage).To(Equal("envoy-windows"))
+		})
+	})
+
+	Context("Test getRequiredNodeLabel()", func() {
+		It("returns the value required by every node affinity term", func() {
+			arch, ok := getRequiredNodeLabel(newPodWithAffinity(newArchTerm("arm64"), newArchTerm("arm64")), archLabel, betaArchLabel)
+			Expect(ok).To(BeTrue())
+			Expect(arch).To(Equal("arm64"))
+		})
+
+		It("returns no value when the node affinity terms allow different values", func() {
+			_, ok := getRequiredNodeLabel(newPodWithAffinity(newArchTerm("arm64"), newArchTerm("amd64")), archLabel, betaArchLabel)
+			Expect(ok).To(BeFalse())
+
+			_, ok = getRequiredNodeLabel(newPodWithAffinity(newArchTerm("arm64"), corev1.NodeSelectorTerm{}), archLabel, betaArchLabel)
+			Expect(ok).To(BeFalse())
+
+			_, ok = getRequiredNodeLabel(newPodWithAffinity(), archLabel, betaArchLabel)
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -11,17 +11,30 @@ import (
 const (
 	// InitContainerName is the name of the init container
 	InitContainerName = "osm-init"
+
+	// capabilityAll stands for all capabilities of a container
+	capabilityAll corev1.Capability = "ALL"
 )
 
 func getInitContainerSpec(pod *corev1.Pod, data *InitContainerData) (corev1.Container, error) {
-	return corev1.Container{
+	var rootUID int64
+	runAsNonRoot := false
+	allowPrivilegeEscalation := false
+
+	container := corev1.Container{
 		Name:  data.Name,
 		Image: data.Image,
+		// iptables must run as root, but only needs the capabilities to program the network namespace of the pod
 		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:                &rootUID,
+			RunAsNonRoot:             &runAsNonRoot,
+			AllowPrivilegeEscalation: &allowPrivilegeEscalation,
 			Capabilities: &corev1.Capabilities{
 				Add: []corev1.Capability{
 					"NET_ADMIN",
+					"NET_RAW",
 				},
+				Drop: []corev1.Capability{capabilityAll},
 			},
 		},
 		Env: []corev1.EnvVar{
@@ -37,6 +50,27 @@ func getInitContainerSpec(pod *corev1.Pod, data *InitContainerData) (corev1.Cont
 				Name:  "OSM_ENVOY_OUTBOUND_PORT",
 				Value: fmt.Sprintf("%d", constants.EnvoyOutboundListenerPort),
 			},
+			{
+				// Selects whether the traffic is redirected with iptables, ip6tables or both
+				Name:  "IP_FAMILY",
+				Value: string(data.IPFamily),
+			},
 		},
-	}, nil
+	}
+
+	if data.EnableDNSProxy {
+		// Redirect DNS queries of the application to the sidecar's DNS listener
+		container.Env = append(container.Env,
+			corev1.EnvVar{
+				Name:  "ENABLE_DNS_PROXY",
+				Value: "true",
+			},
+			corev1.EnvVar{
+				Name:  "PROXY_DNS_PORT",
+				Value: fmt.Sprintf("%d", constants.EnvoyDNSListenerPort),
+			},
+		)
+	}
+
+	return container, nil
 }
@@ -0,0 +1,123 @@
+package injector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"k8s.io/api/admission/v1beta1"
+	admissionv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/namespace"
+)
+
+const (
+	osmNamespaceValidatorWebhookName = "osm-namespace-validator.k8s.io"
+	osmWebhookValidateNamespacePath  = "/validate-namespace"
+)
+
+func (wh *webhook) validateNamespaceHandler(w http.ResponseWriter, req *http.Request) {
+	wh.serveAdmission(w, req, wh.validateNamespace)
+}
+
+// validateNamespace denies namespace updates which move a namespace between meshes, or hand the claim of an installed
+// OSM controller on a namespace over to another one. Updates not involving this mesh are left to the webhooks of other meshes.
+func (wh *webhook) validateNamespace(req *v1beta1.AdmissionRequest) *v1beta1.AdmissionResponse {
+	resp := &v1beta1.AdmissionResponse{
+		Allowed: true,
+		UID:     req.UID,
+	}
+	if req.Operation != v1beta1.Update {
+		return resp
+	}
+
+	var oldNs, newNs corev1.Namespace
+	if err := json.Unmarshal(req.OldObject.Raw, &oldNs); err != nil {
+		log.Error().Err(err).Msg("Error unmarshaling request to Namespace")
+		return toAdmissionError(err)
+	}
+	if err := json.Unmarshal(req.Object.Raw, &newNs); err != nil {
+		log.Error().Err(err).Msg("Error unmarshaling request to Namespace")
+		return toAdmissionError(err)
+	}
+
+	if oldNs.Labels[namespace.MonitorLabel] != wh.meshName && newNs.Labels[namespace.MonitorLabel] != wh.meshName {
+		return resp
+	}
+
+	if err := namespace.ValidateOwnershipChange(&oldNs, &newNs, namespace.NewOwnerChecker(wh.kubeClient)); err != nil {
+		log.Warn().Err(err).Msgf("Denied update of namespace %s", newNs.Name)
+		resp.Allowed = false
+		resp.Result = &metav1.Status{
+			Reason:  metav1.StatusReasonForbidden,
+			Message: err.Error(),
+		}
+	}
+	return resp
+}
+
+func patchValidatingWebhookConfiguration(cert certificate.Certificater, meshName, webhookName string, clientSet kubernetes.Interface) error {
+	configTimeoutSeconds := int32(osmConfigValidatorTimeoutSeconds)
+	updatedWH := admissionv1beta1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: webhookName,
+		},
+		Webhooks: []admissionv1beta1.ValidatingWebhook{
+			{
+				Name: osmNamespaceValidatorWebhookName,
+				ClientConfig: admissionv1beta1.WebhookClientConfig{
+					CABundle: cert.GetIssuingCA(),
+				},
+				Rules: []admissionv1beta1.RuleWithOperations{
+					{
+						Operations: []admissionv1beta1.OperationType{admissionv1beta1.Update},
+						Rule: admissionv1beta1.Rule{
+							APIGroups:   []string{""},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"namespaces"},
+						},
+					},
+				},
+			},
+			{
+				Name: osmConfigValidatorWebhookName,
+				ClientConfig: admissionv1beta1.WebhookClientConfig{
+					CABundle: cert.GetIssuingCA(),
+				},
+				ObjectSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{osmConfigMeshLabel: meshName},
+				},
+				TimeoutSeconds: &configTimeoutSeconds,
+				Rules: []admissionv1beta1.RuleWithOperations{
+					{
+						Operations: []admissionv1beta1.OperationType{admissionv1beta1.Create, admissionv1beta1.Update},
+						Rule: admissionv1beta1.Rule{
+							APIGroups:   []string{""},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"configmaps"},
+						},
+					},
+				},
+			},
+		},
+	}
+	data, err := json.Marshal(updatedWH)
+	if err != nil {
+		return err
+	}
+
+	_, err = clientSet.AdmissionregistrationV1beta1().ValidatingWebhookConfigurations().Patch(
+		context.Background(), webhookName, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error configuring webhook %s", webhookName)
+		return err
+	}
+
+	log.Info().Msgf("Configured ValidatingWebhookConfiguration %s", webhookName)
+	return nil
+}
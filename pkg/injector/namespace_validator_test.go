@@ -0,0 +1,107 @@
+package injector
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/api/admission/v1beta1"
+	admissionv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/namespace"
+)
+
+var _ = Describe("Test namespace validating webhook", func() {
+	newNamespaceRaw := func(meshName string) runtime.RawExtension {
+		ns := corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "bookstore",
+				Labels: map[string]string{},
+			},
+		}
+		if meshName != "" {
+			ns.Labels[namespace.MonitorLabel] = meshName
+		}
+		raw, err := json.Marshal(ns)
+		Expect(err).ToNot(HaveOccurred())
+		return runtime.RawExtension{Raw: raw}
+	}
+
+	wh := &webhook{
+		meshName: "osm",
+	}
+
+	Context("Test validateNamespace()", func() {
+		It("denies moving a namespace of this mesh to another mesh", func() {
+			resp := wh.validateNamespace(&v1beta1.AdmissionRequest{
+				Operation: v1beta1.Update,
+				OldObject: newNamespaceRaw("osm"),
+				Object:    newNamespaceRaw("other"),
+			})
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Reason).To(Equal(metav1.StatusReasonForbidden))
+		})
+
+		It("denies moving a namespace of another mesh to this mesh", func() {
+			resp := wh.validateNamespace(&v1beta1.AdmissionRequest{
+				Operation: v1beta1.Update,
+				OldObject: newNamespaceRaw("other"),
+				Object:    newNamespaceRaw("osm"),
+			})
+			Expect(resp.Allowed).To(BeFalse())
+		})
+
+		It("allows adding a namespace to this mesh", func() {
+			resp := wh.validateNamespace(&v1beta1.AdmissionRequest{
+				Operation: v1beta1.Update,
+				OldObject: newNamespaceRaw(""),
+				Object:    newNamespaceRaw("osm"),
+			})
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("leaves namespaces of other meshes to their webhooks", func() {
+			resp := wh.validateNamespace(&v1beta1.AdmissionRequest{
+				Operation: v1beta1.Update,
+				OldObject: newNamespaceRaw("other"),
+				Object:    newNamespaceRaw("another"),
+			})
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+
+	Context("Test patchValidatingWebhookConfiguration()", func() {
+		webhookName := "--validatingWebhookName--"
+		kubeClient := fake.NewSimpleClientset(&admissionv1beta1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: webhookName,
+			},
+			Webhooks: []admissionv1beta1.ValidatingWebhook{
+				{
+					Name: osmNamespaceValidatorWebhookName,
+				},
+			},
+		})
+
+		It("patches the CA bundle of the webhook", func() {
+			err := patchValidatingWebhookConfiguration(mockCertificate{}, "osm", webhookName, kubeClient)
+			Expect(err).ToNot(HaveOccurred())
+
+			webhook, err := kubeClient.AdmissionregistrationV1beta1().ValidatingWebhookConfigurations().Get(context.TODO(), webhookName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(webhook.Webhooks)).To(Equal(2))
+			Expect(webhook.Webhooks[0].ClientConfig.CABundle).To(Equal([]byte("ca")))
+			Expect(webhook.Webhooks[0].Rules[0].Rule.Resources).To(Equal([]string{"namespaces"}))
+			Expect(webhook.Webhooks[1].Name).To(Equal(osmConfigValidatorWebhookName))
+			Expect(webhook.Webhooks[1].ClientConfig.CABundle).To(Equal([]byte("ca")))
+			Expect(webhook.Webhooks[1].Rules[0].Rule.Resources).To(Equal([]string{"configmaps"}))
+			Expect(webhook.Webhooks[1].ObjectSelector.MatchLabels).To(Equal(map[string]string{osmConfigMeshLabel: "osm"}))
+			Expect(*webhook.Webhooks[1].TimeoutSeconds).To(Equal(int32(osmConfigValidatorTimeoutSeconds)))
+		})
+	})
+})
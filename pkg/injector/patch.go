@@ -8,9 +8,9 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 
-	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/constants"
 )
 
@@ -19,6 +19,11 @@ const (
 	prometheusPortAnnotation   = "prometheus.io/port"
 	prometheusPathAnnotation   = "prometheus.io/path"
 
+	// seccompContainerAnnotationPrefix prefixes the name of a container in the annotation setting its seccomp profile.
+	// The seccompProfile field of the security context is not available in this version of the Kubernetes API.
+	seccompContainerAnnotationPrefix = "container.seccomp.security.alpha.kubernetes.io/"
+	seccompRuntimeDefault            = "runtime/default"
+
 	volumesBasePath        = "/spec/volumes"
 	initContainersBasePath = "/spec/initContainers"
 	labelsPath             = "/metadata/labels"
@@ -28,48 +33,52 @@ func (wh *webhook) createPatch(pod *corev1.Pod, namespace string) ([]byte, error
 	// This string uniquely identifies the pod. Ideally this would be the pod.UID, but this is not available at this point.
 	proxyUUID := uuid.New().String()
 
+	// Windows pods get images built for Windows, and have their traffic redirected by HNS instead of iptables
+	isWindows := isWindowsPod(pod)
+	initContainerImage, sidecarImage := wh.getImages(pod)
+	if isWindows && (initContainerImage == "" || sidecarImage == "") {
+		return nil, errors.Errorf("Cannot inject a sidecar in a Windows pod in namespace %s: the Windows init container and sidecar images are not configured", namespace)
+	}
+
 	// Start patching the spec
 	var patches []JSONPatchOperation
-
-	// Issue a certificate for the proxy sidecar - used for Envoy to connect to XDS (not Envoy-to-Envoy connections)
-	cn := catalog.NewCertCommonNameWithProxyID(proxyUUID, pod.Spec.ServiceAccountName, namespace)
-	log.Info().Msgf("Patching POD spec: service-account=%s, namespace=%s with certificate CN=%s", pod.Spec.ServiceAccountName, namespace, cn)
-	validityPeriod := constants.XDSCertificateValidityPeriod
-	bootstrapCertificate, err := wh.certManager.IssueCertificate(cn, &validityPeriod)
-	if err != nil {
-		log.Error().Err(err).Msgf("Error issuing bootstrap certificate for Envoy with CN=%s", cn)
-		return nil, err
+	if isWindows {
+		windowsUserPatch, err := getWindowsUserPatch(pod)
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, windowsUserPatch...)
+		patches = append(patches, getHNSRedirectedAnnotationPatch(pod)...)
 	}
 
-	wh.meshCatalog.ExpectProxy(cn)
-
-	// Create kube secret for Envoy bootstrap config
-	envoyBootstrapConfigName := fmt.Sprintf("envoy-bootstrap-config-%s", proxyUUID)
-	_, err = wh.createEnvoyBootstrapConfig(envoyBootstrapConfigName, namespace, wh.osmNamespace, bootstrapCertificate)
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to create bootstrap config for Envoy sidecar")
-		return nil, err
-	}
+	log.Info().Msgf("Patching POD spec: service-account=%s, namespace=%s with proxy UUID %s", pod.Spec.ServiceAccountName, namespace, proxyUUID)
 
-	// Create volume for envoy TLS secret
+	// Add the volumes of the projected bootstrap token and of the Envoy bootstrap config.
+	// No certificate is issued until the pod exchanges its bootstrap token for it.
 	patches = append(patches, addVolume(
 		pod.Spec.Volumes,
-		getVolumeSpec(envoyBootstrapConfigName),
+		getVolumeSpec(isWindows),
 		volumesBasePath)...,
 	)
 
 	// Add the Init Container
 	initContainerData := InitContainerData{
-		Name:  InitContainerName,
-		Image: wh.config.InitContainerImage,
+		Name:           InitContainerName,
+		Image:          initContainerImage,
+		EnableDNSProxy: wh.configurator.IsDNSProxyEnabled(),
+		IPFamily:       wh.configurator.GetIPFamily(),
 	}
-	initContainerSpec, err := getInitContainerSpec(pod, &initContainerData)
-	if err != nil {
+	var initContainerSpec corev1.Container
+	var err error
+	if isWindows {
+		initContainerSpec = getWindowsInitContainerSpec(&initContainerData)
+	} else if initContainerSpec, err = getInitContainerSpec(pod, &initContainerData); err != nil {
 		return nil, err
 	}
+	// The bootstrap token is exchanged for the bootstrap config before the traffic of the pod is redirected
 	patches = append(patches, addContainer(
 		pod.Spec.InitContainers,
-		[]corev1.Container{initContainerSpec},
+		[]corev1.Container{wh.getBootstrapContainerSpec(initContainerImage, isWindows), initContainerSpec},
 		initContainersBasePath)...,
 	)
 
@@ -79,21 +88,32 @@ func (wh *webhook) createPatch(pod *corev1.Pod, namespace string) ([]byte, error
 	// envoyCluster ID will be used as an identifier to the tracing sink (will be used in Zipkin for example).
 	envoyClusterID := fmt.Sprintf("%s.%s", pod.Spec.ServiceAccountName, namespace)
 
+	sidecarContainers := getEnvoySidecarContainerSpec(envoyContainerName, sidecarImage, envoyNodeID, envoyClusterID)
+	if isWindows {
+		sidecarContainers = getWindowsEnvoySidecarContainerSpec(envoyContainerName, sidecarImage, envoyNodeID, envoyClusterID)
+	}
+	// The certificate Envoy connects to XDS with is short-lived, and refreshed for the lifetime of the pod
+	sidecarContainers = append(sidecarContainers, wh.getCertRotatorContainerSpec(initContainerImage, isWindows))
 	patches = append(patches, addContainer(
 		pod.Spec.Containers,
-		getEnvoySidecarContainerSpec(envoyContainerName, wh.config.SidecarImage, envoyNodeID, envoyClusterID),
+		sidecarContainers,
 		"/spec/containers")...,
 	)
 
 	// Patch annotations
-	prometheusAnnotations := map[string]string{
+	annotations := map[string]string{
 		prometheusScrapeAnnotation: strconv.FormatBool(true),
 		prometheusPortAnnotation:   strconv.Itoa(constants.EnvoyPrometheusInboundListenerPort),
 		prometheusPathAnnotation:   constants.PrometheusScrapePath,
 	}
+	if !isWindows {
+		for key, value := range getSeccompAnnotations(pod) {
+			annotations[key] = value
+		}
+	}
 	patches = append(patches, updateAnnotation(
 		pod.Annotations,
-		prometheusAnnotations,
+		annotations,
 		"/metadata/annotations")...,
 	)
 
@@ -102,6 +122,19 @@ func (wh *webhook) createPatch(pod *corev1.Pod, namespace string) ([]byte, error
 	return json.Marshal(patches)
 }
 
+// getSeccompAnnotations returns the annotations running the Envoy sidecar and the bootstrap containers with the
+// default seccomp profile of the container runtime, unless the pod sets their profile.
+func getSeccompAnnotations(pod *corev1.Pod) map[string]string {
+	annotations := make(map[string]string)
+	for _, containerName := range []string{envoyContainerName, bootstrapContainerName, certRotatorContainerName} {
+		key := seccompContainerAnnotationPrefix + containerName
+		if _, ok := pod.Annotations[key]; !ok {
+			annotations[key] = seccompRuntimeDefault
+		}
+	}
+	return annotations
+}
+
 func addVolume(target, add []corev1.Volume, basePath string) (patch []JSONPatchOperation) {
 	isFirst := len(target) == 0 // target is empty, use this to create the first item
 	var value interface{}
@@ -33,4 +33,24 @@ var _ = Describe("Test patch functions", func() {
 			Expect(actual).To(Equal(expected))
 		})
 	})
+
+	Context("Test getSeccompAnnotations", func() {
+		It("runs the sidecar and the bootstrap containers with the default seccomp profile", func() {
+			pod := tests.NewPodTestFixture("ns", "pod-name")
+			Expect(getSeccompAnnotations(&pod)).To(Equal(map[string]string{
+				"container.seccomp.security.alpha.kubernetes.io/envoy":            "runtime/default",
+				"container.seccomp.security.alpha.kubernetes.io/osm-bootstrap":    "runtime/default",
+				"container.seccomp.security.alpha.kubernetes.io/osm-cert-rotator": "runtime/default",
+			}))
+		})
+
+		It("keeps the seccomp profiles set by the pod", func() {
+			pod := tests.NewPodTestFixture("ns", "pod-name")
+			pod.Annotations = map[string]string{"container.seccomp.security.alpha.kubernetes.io/envoy": "localhost/envoy.json"}
+			Expect(getSeccompAnnotations(&pod)).To(Equal(map[string]string{
+				"container.seccomp.security.alpha.kubernetes.io/osm-bootstrap":    "runtime/default",
+				"container.seccomp.security.alpha.kubernetes.io/osm-cert-rotator": "runtime/default",
+			}))
+		})
+	})
 })
@@ -10,20 +10,33 @@ import (
 
 const (
 	envoyBootstrapConfigFile = "bootstrap.yaml"
+	envoyXDSCertificateFile  = "xds_certificate.yaml"
 	envoyProxyConfigPath     = "/etc/envoy"
 	envoyContainerName       = "envoy"
+
+	// xdsCertificateSecretName is the name of the SDS secret holding the certificate Envoy connects to XDS with
+	xdsCertificateSecretName = "xds_certificate"
 )
 
 func getEnvoySidecarContainerSpec(containerName, envoyImage, nodeID, clusterID string) []corev1.Container {
+	uid := constants.EnvoyUID
+	runAsNonRoot := true
+	allowPrivilegeEscalation := false
+	readOnlyRootFilesystem := true
+
 	container := corev1.Container{
 		Name:            containerName,
 		Image:           envoyImage,
 		ImagePullPolicy: corev1.PullAlways,
+		// Envoy needs no privileges: it runs as a non-root user without capabilities, and only writes to its standard output
 		SecurityContext: &corev1.SecurityContext{
-			RunAsUser: func() *int64 {
-				uid := constants.EnvoyUID
-				return &uid
-			}(),
+			RunAsUser:                &uid,
+			RunAsNonRoot:             &runAsNonRoot,
+			AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+			ReadOnlyRootFilesystem:   &readOnlyRootFilesystem,
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{capabilityAll},
+			},
 		},
 		Ports: []corev1.ContainerPort{{
 			Name:          constants.EnvoyAdminPortName,
@@ -26,9 +26,29 @@ type webhook struct {
 	certManager         certificate.Manager
 	meshCatalog         catalog.MeshCataloger
 	namespaceController namespace.Controller
+	meshName            string
 	osmNamespace        string
+	osmConfigMapName    string
 	cert                certificate.Certificater
 	configurator        configurator.Configurator
+
+	// listening is closed once the web server listens
+	listening chan struct{}
+
+	// synced is closed by Start, once meshCatalog is set
+	synced chan struct{}
+}
+
+// Webhook is the web server of the sidecar injector, the validating webhooks and the proxy bootstrap
+type Webhook interface {
+	// Start serves the proxy bootstrap requests with the given catalog, once its caches are synced
+	Start(catalog.MeshCataloger)
+
+	// Liveness is the Kubernetes liveness probe handler
+	Liveness() bool
+
+	// Readiness is the Kubernetes readiness probe handler, ready once the web server listens
+	Readiness() bool
 }
 
 // Config is the type used to represent the config options for the sidecar injection
@@ -42,6 +62,12 @@ type Config struct {
 	InitContainerImage string
 
 	SidecarImage string
+
+	// InitContainerWindowsImage is the init container image of Windows pods, Windows pods are not injected when empty
+	InitContainerWindowsImage string
+
+	// SidecarWindowsImage is the sidecar proxy image of Windows pods, Windows pods are not injected when empty
+	SidecarWindowsImage string
 }
 
 // JSONPatchOperation is the type used to represenet a JSON Patch operation
@@ -53,8 +79,10 @@ type JSONPatchOperation struct {
 
 // InitContainerData is the type used to represent information about the init container
 type InitContainerData struct {
-	Name  string
-	Image string
+	Name           string
+	Image          string
+	EnableDNSProxy bool
+	IPFamily       configurator.IPFamily
 }
 
 // EnvoySidecarData is the type used to represent information about the Envoy sidecar
@@ -73,6 +101,9 @@ type envoyBootstrapConfigMeta struct {
 	Cert           string
 	Key            string
 
+	// CertSDSPath is the path of the SDS file holding the certificate and key, used instead of Cert and Key when set
+	CertSDSPath string
+
 	// Host and port of the Envoy xDS server
 	XDSHost string
 	XDSPort int
@@ -5,15 +5,52 @@ import (
 )
 
 // getVolumeSpec returns a list of volumes to add to the POD
-func getVolumeSpec(envoyBootstrapConfigName string) []corev1.Volume {
-	return []corev1.Volume{
+func getVolumeSpec(isWindows bool) []corev1.Volume {
+	expirationSeconds := int64(bootstrapTokenExpirationSeconds)
+
+	// The volume holds the private key of the short-lived certificate the proxy connects to XDS with, kept in memory.
+	// Windows does not support memory-backed volumes.
+	bootstrapConfigVolumeSource := &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}
+	if isWindows {
+		bootstrapConfigVolumeSource = &corev1.EmptyDirVolumeSource{}
+	}
+
+	volumes := []corev1.Volume{
 		{
 			Name: envoyBootstrapConfigVolume,
 			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: envoyBootstrapConfigName,
+				EmptyDir: bootstrapConfigVolumeSource,
+			},
+		},
+		{
+			Name: bootstrapTokenVolume,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          BootstrapTokenAudience,
+							ExpirationSeconds: &expirationSeconds,
+							Path:              bootstrapTokenFile,
+						},
+					}},
 				},
 			},
 		},
 	}
+
+	// The init container of Windows pods waits for the HNS agent of the node to annotate the pod
+	if isWindows {
+		volumes = append(volumes, corev1.Volume{
+			Name: podInfoVolume,
+			VolumeSource: corev1.VolumeSource{
+				DownwardAPI: &corev1.DownwardAPIVolumeSource{
+					Items: []corev1.DownwardAPIVolumeFile{{
+						Path:     podInfoAnnotationsFile,
+						FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations"},
+					}},
+				},
+			},
+		})
+	}
+	return volumes
 }
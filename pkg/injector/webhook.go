@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"strings"
 
@@ -42,30 +43,70 @@ const (
 )
 
 // NewWebhook starts a new web server handling requests from the injector MutatingWebhookConfiguration
-func NewWebhook(config Config, kubeClient kubernetes.Interface, certManager certificate.Manager, meshCatalog catalog.MeshCataloger, namespaceController namespace.Controller, meshName, osmNamespace, webhookName string, stop <-chan struct{}, cfg configurator.Configurator) error {
+// and the namespace and OSM ConfigMap ValidatingWebhookConfiguration.
+func NewWebhook(config Config, kubeClient kubernetes.Interface, certManager certificate.Manager, namespaceController namespace.Controller, meshName, osmNamespace, osmConfigMapName, webhookName, validatingWebhookName string, stop <-chan struct{}, cfg configurator.Configurator) (Webhook, error) {
 	cn := certificate.CommonName(fmt.Sprintf("%s.%s.svc", constants.OSMControllerName, osmNamespace))
 	validityPeriod := constants.XDSCertificateValidityPeriod
 	cert, err := certManager.IssueCertificate(cn, &validityPeriod)
 	if err != nil {
-		return errors.Errorf("Error issuing certificate for the mutating webhook: %+v", err)
+		return nil, errors.Errorf("Error issuing certificate for the mutating webhook: %+v", err)
 	}
 
 	wh := webhook{
 		config:              config,
 		kubeClient:          kubeClient,
 		certManager:         certManager,
-		meshCatalog:         meshCatalog,
 		namespaceController: namespaceController,
+		meshName:            meshName,
 		osmNamespace:        osmNamespace,
+		osmConfigMapName:    osmConfigMapName,
 		cert:                cert,
 		configurator:        cfg,
+		listening:           make(chan struct{}),
+		synced:              make(chan struct{}),
 	}
 
 	go wh.run(stop)
 	if err = patchMutatingWebhookConfiguration(cert, meshName, osmNamespace, webhookName, wh.kubeClient); err != nil {
-		return errors.Errorf("Error configuring MutatingWebhookConfiguration: %+v", err)
+		return nil, errors.Errorf("Error configuring MutatingWebhookConfiguration: %+v", err)
+	}
+	if err = patchValidatingWebhookConfiguration(cert, meshName, validatingWebhookName, wh.kubeClient); err != nil {
+		return nil, errors.Errorf("Error configuring ValidatingWebhookConfiguration: %+v", err)
+	}
+	return &wh, nil
+}
+
+// Start serves the proxy bootstrap requests with the given catalog, once its caches are synced.
+// Until then, the proxies are refused their certificate and retry.
+func (wh *webhook) Start(meshCatalog catalog.MeshCataloger) {
+	wh.meshCatalog = meshCatalog
+	close(wh.synced)
+}
+
+// isSynced returns true once the webhook is started
+func (wh *webhook) isSynced() bool {
+	select {
+	case <-wh.synced:
+		return true
+	default:
+		return false
+	}
+}
+
+// Liveness is the Kubernetes liveness probe handler.
+func (wh *webhook) Liveness() bool {
+	return true
+}
+
+// Readiness is the Kubernetes readiness probe handler.
+// The webhook is ready once its web server listens, for the API server and the bootstrapping proxies to reach it.
+func (wh *webhook) Readiness() bool {
+	select {
+	case <-wh.listening:
+		return true
+	default:
+		return false
 	}
-	return nil
 }
 
 func (wh *webhook) run(stop <-chan struct{}) {
@@ -76,6 +117,10 @@ func (wh *webhook) run(stop <-chan struct{}) {
 	// HTTP handlers
 	mux.HandleFunc("/health/ready", wh.healthReadyHandler)
 	mux.HandleFunc(osmWebhookMutatePath, wh.mutateHandler)
+	mux.HandleFunc(osmWebhookValidateNamespacePath, wh.validateNamespaceHandler)
+	mux.HandleFunc(osmWebhookValidateConfigPath, wh.validateConfigHandler)
+	mux.HandleFunc(BootstrapPath, wh.bootstrapHandler)
+	mux.HandleFunc(BootstrapCertificatePath, wh.certificateHandler)
 
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%d", wh.config.ListenPort),
@@ -95,10 +140,17 @@ func (wh *webhook) run(stop <-chan struct{}) {
 			Certificates: []tls.Certificate{cert},
 		}
 
-		if err := server.ListenAndServeTLS("", ""); err != nil {
+		lis, err := net.Listen("tcp", server.Addr)
+		if err != nil {
 			log.Error().Err(err).Msgf("Sidecar-injection webhook HTTP server failed to start: %+v", err)
 			return
 		}
+		close(wh.listening)
+
+		if err := server.ServeTLS(lis, "", ""); err != nil && err != http.ErrServerClosed {
+			log.Error().Err(err).Msgf("Sidecar-injection webhook HTTP server failed: %+v", err)
+			return
+		}
 	}()
 
 	// Wait on exit signals
@@ -122,6 +174,11 @@ func (wh *webhook) healthReadyHandler(w http.ResponseWriter, req *http.Request)
 }
 
 func (wh *webhook) mutateHandler(w http.ResponseWriter, req *http.Request) {
+	wh.serveAdmission(w, req, wh.mutate)
+}
+
+// serveAdmission decodes the AdmissionReview in the given request, and responds with the result of admit.
+func (wh *webhook) serveAdmission(w http.ResponseWriter, req *http.Request, admit func(*v1beta1.AdmissionRequest) *v1beta1.AdmissionResponse) {
 	log.Info().Msgf("Request received: Method=%v, URL=%v", req.Method, req.URL)
 
 	if contentType := req.Header.Get("Content-Type"); contentType != "application/json" {
@@ -155,7 +212,7 @@ func (wh *webhook) mutateHandler(w http.ResponseWriter, req *http.Request) {
 		log.Error().Err(err).Msg("Error decoding admission request")
 		admissionResp.Response = toAdmissionError(err)
 	} else {
-		admissionResp.Response = wh.mutate(admissionReq.Request)
+		admissionResp.Response = admit(admissionReq.Request)
 	}
 
 	resp, err := json.Marshal(&admissionResp)
@@ -288,7 +345,7 @@ func patchMutatingWebhookConfiguration(cert certificate.Certificater, meshName,
 			{
 				Name: osmWebhookName,
 				ClientConfig: admissionv1beta1.WebhookClientConfig{
-					CABundle: cert.GetCertificateChain(),
+					CABundle: cert.GetIssuingCA(),
 				},
 				Rules: []admissionv1beta1.RuleWithOperations{
 					{
@@ -13,6 +13,17 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 )
 
+var _ = Describe("Test webhook readiness", func() {
+	Context("Test Readiness()", func() {
+		It("is ready once the web server listens", func() {
+			wh := &webhook{listening: make(chan struct{})}
+			Expect(wh.Readiness()).To(BeFalse())
+			close(wh.listening)
+			Expect(wh.Readiness()).To(BeTrue())
+		})
+	})
+})
+
 var _ = Describe("Test MutatingWebhookConfiguration patch", func() {
 	Context("find and patches webhook", func() {
 		//cert := tresor.Certificate{}
@@ -74,7 +85,7 @@ var _ = Describe("Test MutatingWebhookConfiguration patch", func() {
 			Expect(webhook.Webhooks[0].ClientConfig.Service.Namespace).To(Equal(testWebhookServiceNamespace))
 			Expect(webhook.Webhooks[0].ClientConfig.Service.Name).To(Equal(testWebhookServiceName))
 			Expect(webhook.Webhooks[0].ClientConfig.Service.Path).To(Equal(&testWebhookServicePath))
-			Expect(webhook.Webhooks[0].ClientConfig.CABundle).To(Equal([]byte("chain")))
+			Expect(webhook.Webhooks[0].ClientConfig.CABundle).To(Equal([]byte("ca")))
 			Expect(len(webhook.Webhooks[0].Rules)).To(Equal(1))
 			rule := webhook.Webhooks[0].Rules[0]
 			Expect(len(rule.Operations)).To(Equal(1))
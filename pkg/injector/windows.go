@@ -0,0 +1,137 @@
+package injector
+
+import (
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	windowsOS = "windows"
+
+	// envoyWindowsUserName is the user the Envoy sidecar runs as on Windows, which no other container of the pod may run as
+	envoyWindowsUserName = "ContainerUser"
+
+	// windowsApplicationUserName is the user the containers of a Windows pod run as when the pod does not set one
+	windowsApplicationUserName = "ContainerAdministrator"
+
+	// hnsRedirectedAnnotation is set by the HNS agent of the node once the traffic of the pod is redirected to Envoy.
+	// The agent computes the proxy policy of the pod itself, rather than trusting annotations the pod can set.
+	hnsRedirectedAnnotation = "openservicemesh.io/hns-redirected"
+
+	podInfoVolume          = "osm-pod-info"
+	podInfoPath            = "/etc/podinfo"
+	podInfoAnnotationsFile = "annotations"
+)
+
+// isWindowsPod returns whether the given pod can only be scheduled on Windows nodes.
+func isWindowsPod(pod *corev1.Pod) bool {
+	nodeOS, ok := getRequiredNodeLabel(pod, osLabel, betaOSLabel)
+	return ok && nodeOS == windowsOS
+}
+
+// getHNSRedirectedAnnotationPatch returns the patch removing the hnsRedirectedAnnotation of a Windows pod being
+// injected, which only the HNS agent of the node may set once it has redirected the traffic of the pod.
+func getHNSRedirectedAnnotationPatch(pod *corev1.Pod) []JSONPatchOperation {
+	if _, ok := pod.Annotations[hnsRedirectedAnnotation]; !ok {
+		return nil
+	}
+	return []JSONPatchOperation{{
+		Op:   "remove",
+		Path: path.Join("/metadata/annotations", escapeJSONPointerValue(hnsRedirectedAnnotation)),
+	}}
+}
+
+// getWindowsInitContainerSpec returns the init container waiting for the traffic of a Windows pod to be redirected to Envoy.
+// Windows has no iptables, and the HNS endpoint of the pod can only be modified on the host: the HNS agent of the
+// node, a host process container, adds the proxy policy of the pod and sets its hnsRedirectedAnnotation.
+func getWindowsInitContainerSpec(data *InitContainerData) corev1.Container {
+	return corev1.Container{
+		Name:    data.Name,
+		Image:   data.Image,
+		Command: []string{"powershell", "-NoProfile", "-ExecutionPolicy", "Bypass", "-File", "wait-hns.ps1"},
+		Env: []corev1.EnvVar{
+			{
+				Name:  "POD_ANNOTATIONS_FILE",
+				Value: strings.Join([]string{podInfoPath, podInfoAnnotationsFile}, "/"),
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      podInfoVolume,
+				ReadOnly:  true,
+				MountPath: podInfoPath,
+			},
+		},
+	}
+}
+
+// getWindowsUserPatch returns the patch making the containers of a Windows pod which do not set a user run as
+// windowsApplicationUserName, so that only Envoy runs as envoyWindowsUserName, whose traffic is not redirected.
+// Pods with containers explicitly running as envoyWindowsUserName are rejected.
+func getWindowsUserPatch(pod *corev1.Pod) ([]JSONPatchOperation, error) {
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if container.SecurityContext != nil && container.SecurityContext.WindowsOptions != nil && isEnvoyWindowsUser(container.SecurityContext.WindowsOptions.RunAsUserName) {
+			return nil, errors.Errorf("Container %s of a Windows pod cannot run as %s, the user of the sidecar", container.Name, envoyWindowsUserName)
+		}
+	}
+
+	userName := windowsApplicationUserName
+	securityContext := pod.Spec.SecurityContext
+	switch {
+	case securityContext == nil:
+		return []JSONPatchOperation{{
+			Op:   "add",
+			Path: "/spec/securityContext",
+			Value: corev1.PodSecurityContext{
+				WindowsOptions: &corev1.WindowsSecurityContextOptions{RunAsUserName: &userName},
+			},
+		}}, nil
+	case securityContext.WindowsOptions == nil:
+		return []JSONPatchOperation{{
+			Op:    "add",
+			Path:  "/spec/securityContext/windowsOptions",
+			Value: corev1.WindowsSecurityContextOptions{RunAsUserName: &userName},
+		}}, nil
+	case securityContext.WindowsOptions.RunAsUserName == nil:
+		return []JSONPatchOperation{{
+			Op:    "add",
+			Path:  "/spec/securityContext/windowsOptions/runAsUserName",
+			Value: userName,
+		}}, nil
+	case isEnvoyWindowsUser(securityContext.WindowsOptions.RunAsUserName):
+		return nil, errors.Errorf("A Windows pod cannot run as %s, the user of the sidecar", envoyWindowsUserName)
+	default:
+		return nil, nil
+	}
+}
+
+// isEnvoyWindowsUser returns whether the given Windows user name, optionally qualified by its domain, is envoyWindowsUserName.
+// Windows user names are case-insensitive.
+func isEnvoyWindowsUser(userName *string) bool {
+	if userName == nil {
+		return false
+	}
+	name := *userName
+	if idx := strings.LastIndex(name, `\`); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return strings.EqualFold(name, envoyWindowsUserName)
+}
+
+// getWindowsEnvoySidecarContainerSpec returns the Envoy sidecar of a Windows pod.
+// Windows containers run as a user name instead of a UID.
+func getWindowsEnvoySidecarContainerSpec(containerName, envoyImage, nodeID, clusterID string) []corev1.Container {
+	containers := getEnvoySidecarContainerSpec(containerName, envoyImage, nodeID, clusterID)
+	userName := envoyWindowsUserName
+	for idx := range containers {
+		containers[idx].SecurityContext = &corev1.SecurityContext{
+			WindowsOptions: &corev1.WindowsSecurityContextOptions{
+				RunAsUserName: &userName,
+			},
+		}
+	}
+	return containers
+}
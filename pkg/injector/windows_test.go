@@ -0,0 +1,141 @@
+package injector
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Test Windows pod injection", func() {
+	Context("Test isWindowsPod()", func() {
+		It("detects Windows pods from their node selector", func() {
+			pod := &corev1.Pod{Spec: corev1.PodSpec{NodeSelector: map[string]string{osLabel: windowsOS}}}
+			Expect(isWindowsPod(pod)).To(BeTrue())
+
+			pod.Spec.NodeSelector = map[string]string{betaOSLabel: windowsOS}
+			Expect(isWindowsPod(pod)).To(BeTrue())
+
+			pod.Spec.NodeSelector = map[string]string{osLabel: "linux"}
+			Expect(isWindowsPod(pod)).To(BeFalse())
+
+			Expect(isWindowsPod(&corev1.Pod{})).To(BeFalse())
+		})
+
+		It("detects Windows pods from the node affinity required during scheduling", func() {
+			windowsTerm := corev1.NodeSelectorTerm{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      osLabel,
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{windowsOS},
+				}},
+			}
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Affinity: &corev1.Affinity{
+						NodeAffinity: &corev1.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+								NodeSelectorTerms: []corev1.NodeSelectorTerm{windowsTerm},
+							},
+						},
+					},
+				},
+			}
+			Expect(isWindowsPod(pod)).To(BeTrue())
+
+			// A pod which can also be scheduled on nodes of another OS is not a Windows pod
+			terms := &pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+			*terms = append(*terms, corev1.NodeSelectorTerm{})
+			Expect(isWindowsPod(pod)).To(BeFalse())
+		})
+	})
+
+	Context("Test getWindowsEnvoySidecarContainerSpec()", func() {
+		It("runs Envoy as the Windows user whose traffic is not redirected", func() {
+			containers := getWindowsEnvoySidecarContainerSpec("envoy", "envoy-windows", "node", "cluster")
+			Expect(len(containers)).To(Equal(1))
+			Expect(containers[0].Image).To(Equal("envoy-windows"))
+			Expect(containers[0].SecurityContext.RunAsUser).To(BeNil())
+			Expect(*containers[0].SecurityContext.WindowsOptions.RunAsUserName).To(Equal(envoyWindowsUserName))
+		})
+	})
+
+	Context("Test getHNSRedirectedAnnotationPatch()", func() {
+		It("removes the annotation of the HNS agent set by the pod", func() {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{hnsRedirectedAnnotation: "true"}}}
+			Expect(getHNSRedirectedAnnotationPatch(pod)).To(Equal([]JSONPatchOperation{{
+				Op:   "remove",
+				Path: "/metadata/annotations/openservicemesh.io~1hns-redirected",
+			}}))
+		})
+
+		It("does not patch pods without the annotation", func() {
+			Expect(getHNSRedirectedAnnotationPatch(&corev1.Pod{})).To(BeEmpty())
+		})
+	})
+
+	Context("Test getWindowsInitContainerSpec()", func() {
+		It("waits for the annotation of the HNS agent in the projected annotations of the pod", func() {
+			container := getWindowsInitContainerSpec(&InitContainerData{Name: InitContainerName, Image: "init-windows"})
+			Expect(container.Command).To(ContainElement("wait-hns.ps1"))
+			Expect(container.VolumeMounts).To(Equal([]corev1.VolumeMount{{Name: podInfoVolume, ReadOnly: true, MountPath: podInfoPath}}))
+
+			volumes := getVolumeSpec(true)
+			podInfo := volumes[len(volumes)-1]
+			Expect(podInfo.Name).To(Equal(podInfoVolume))
+			Expect(podInfo.DownwardAPI.Items[0].FieldRef.FieldPath).To(Equal("metadata.annotations"))
+		})
+	})
+
+	Context("Test getWindowsUserPatch()", func() {
+		It("runs the containers which do not set a user as another user than Envoy's", func() {
+			patches, err := getWindowsUserPatch(&corev1.Pod{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(patches)).To(Equal(1))
+			Expect(patches[0].Path).To(Equal("/spec/securityContext"))
+			Expect(*patches[0].Value.(corev1.PodSecurityContext).WindowsOptions.RunAsUserName).To(Equal(windowsApplicationUserName))
+
+			patches, err = getWindowsUserPatch(&corev1.Pod{Spec: corev1.PodSpec{SecurityContext: &corev1.PodSecurityContext{}}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(patches[0].Path).To(Equal("/spec/securityContext/windowsOptions"))
+		})
+
+		It("keeps the user set by the pod", func() {
+			userName := "app"
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{
+					SecurityContext: &corev1.PodSecurityContext{
+						WindowsOptions: &corev1.WindowsSecurityContextOptions{RunAsUserName: &userName},
+					},
+				},
+			}
+			patches, err := getWindowsUserPatch(pod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(patches).To(BeEmpty())
+		})
+
+		It("rejects pods with containers running as Envoy's user", func() {
+			userName := `User Manager\containeruser`
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: "app",
+						SecurityContext: &corev1.SecurityContext{
+							WindowsOptions: &corev1.WindowsSecurityContextOptions{RunAsUserName: &userName},
+						},
+					}},
+				},
+			}
+			_, err := getWindowsUserPatch(pod)
+			Expect(err).To(HaveOccurred())
+
+			pod.Spec.Containers[0].SecurityContext = nil
+			pod.Spec.SecurityContext = &corev1.PodSecurityContext{
+				WindowsOptions: &corev1.WindowsSecurityContextOptions{RunAsUserName: &userName},
+			}
+			_, err = getWindowsUserPatch(pod)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,134 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// proxyIDIndex is the name of the index of pods by <namespace>/<Envoy proxy ID>
+const proxyIDIndex = "proxyID"
+
+// NewKubernetesController returns a Controller serving reads of pods and services from shared informer caches.
+// When watchedNamespaces is not empty, only the pods and services of these namespaces are watched.
+func NewKubernetesController(kubeClient kubernetes.Interface, watchedNamespaces []string, stop <-chan struct{}) (Controller, error) {
+	podInformer := NewInformer(watchedNamespaces, func(namespace string) cache.SharedIndexInformer {
+		return informers.NewSharedInformerFactoryWithOptions(kubeClient, DefaultKubeEventResyncInterval, informers.WithNamespace(namespace)).Core().V1().Pods().Informer()
+	})
+	if err := podInformer.AddIndexers(cache.Indexers{proxyIDIndex: proxyIDIndexFunc}); err != nil {
+		return nil, err
+	}
+
+	serviceInformer := NewInformer(watchedNamespaces, func(namespace string) cache.SharedIndexInformer {
+		return informers.NewSharedInformerFactoryWithOptions(kubeClient, DefaultKubeEventResyncInterval, informers.WithNamespace(namespace)).Core().V1().Services().Informer()
+	})
+	if err := serviceInformer.AddIndexers(cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}); err != nil {
+		return nil, err
+	}
+
+	go podInformer.Run(stop)
+	go serviceInformer.Run(stop)
+	log.Info().Msg("Waiting for Pods and Services caches to sync")
+	if !cache.WaitForCacheSync(stop, podInformer.HasSynced, serviceInformer.HasSynced) {
+		return nil, errSyncingCaches
+	}
+	log.Info().Msg("Cache sync finished for Pods and Services")
+
+	return client{
+		kubeClient: kubeClient,
+		pods:       podInformer.GetIndexer(),
+		services:   serviceInformer.GetIndexer(),
+	}, nil
+}
+
+// ListPodsForProxyID returns the pods in the given namespace labeled with the given Envoy proxy ID.
+// A proxy may connect before its pod is observed by the cache, in which case the pods are listed from the API server.
+func (c client) ListPodsForProxyID(namespace, proxyID string) ([]*corev1.Pod, error) {
+	objects, err := c.pods.ByIndex(proxyIDIndex, getProxyIDIndexKey(namespace, proxyID))
+	if err != nil {
+		return nil, err
+	}
+
+	var pods []*corev1.Pod
+	for _, obj := range objects {
+		pods = append(pods, obj.(*corev1.Pod))
+	}
+	if len(pods) > 0 {
+		return pods, nil
+	}
+
+	log.Trace().Msgf("No pod with label %s=%s in namespace %s in cache; listing from the API server", constants.EnvoyUniqueIDLabelName, proxyID, namespace)
+	return listPodsForProxyID(c.kubeClient, namespace, proxyID)
+}
+
+// ListServices returns the services in the given namespace, sorted by name like the API server lists them.
+func (c client) ListServices(namespace string) ([]*corev1.Service, error) {
+	objects, err := c.services.ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var services []*corev1.Service
+	for _, obj := range objects {
+		services = append(services, obj.(*corev1.Service))
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Name < services[j].Name
+	})
+	return services, nil
+}
+
+// listPodsForProxyID lists the pods labeled with the given Envoy proxy ID from the API server.
+func listPodsForProxyID(kubeClient kubernetes.Interface, namespace, proxyID string) ([]*corev1.Pod, error) {
+	selector := fmt.Sprintf("%s=%s", constants.EnvoyUniqueIDLabelName, proxyID)
+	podList, err := kubeClient.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing pods with label %s in namespace %s", selector, namespace)
+		return nil, err
+	}
+
+	var pods []*corev1.Pod
+	for idx := range podList.Items {
+		pods = append(pods, &podList.Items[idx])
+	}
+	return pods, nil
+}
+
+// listServices lists the services in the given namespace from the API server.
+func listServices(kubeClient kubernetes.Interface, namespace string) ([]*corev1.Service, error) {
+	serviceList, err := kubeClient.CoreV1().Services(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing services in namespace %s", namespace)
+		return nil, err
+	}
+
+	var services []*corev1.Service
+	for idx := range serviceList.Items {
+		services = append(services, &serviceList.Items[idx])
+	}
+	return services, nil
+}
+
+func proxyIDIndexFunc(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil, nil
+	}
+	proxyID, ok := pod.Labels[constants.EnvoyUniqueIDLabelName]
+	if !ok {
+		return nil, nil
+	}
+	return []string{getProxyIDIndexKey(pod.Namespace, proxyID)}, nil
+}
+
+func getProxyIDIndexKey(namespace, proxyID string) string {
+	return fmt.Sprintf("%s/%s", namespace, proxyID)
+}
@@ -0,0 +1,106 @@
+package kubernetes
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("Test Kubernetes Controller", func() {
+	Context("Testing ListPodsForProxyID", func() {
+		It("returns the pods labeled with the proxy ID from the cache", func() {
+			kubeClient := testclient.NewSimpleClientset()
+
+			pod := tests.NewPodTestFixture(tests.Namespace, "pod-with-proxy")
+			pod.Labels[constants.EnvoyUniqueIDLabelName] = "proxy-1"
+			_, err := kubeClient.CoreV1().Pods(tests.Namespace).Create(context.TODO(), &pod, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			otherPod := tests.NewPodTestFixture(tests.Namespace, "pod-with-other-proxy")
+			otherPod.Labels[constants.EnvoyUniqueIDLabelName] = "proxy-2"
+			_, err = kubeClient.CoreV1().Pods(tests.Namespace).Create(context.TODO(), &otherPod, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			c, err := NewKubernetesController(kubeClient, nil, make(chan struct{}))
+			Expect(err).ToNot(HaveOccurred())
+
+			pods, err := c.ListPodsForProxyID(tests.Namespace, "proxy-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(pods)).To(Equal(1))
+			Expect(pods[0].Name).To(Equal(pod.Name))
+
+			pods, err = c.ListPodsForProxyID("some-other-namespace", "proxy-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pods).To(BeEmpty())
+		})
+
+		It("falls back to the API server for pods not yet in the cache", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			c, err := NewKubernetesController(kubeClient, nil, make(chan struct{}))
+			Expect(err).ToNot(HaveOccurred())
+
+			pod := tests.NewPodTestFixture(tests.Namespace, "new-pod")
+			pod.Labels[constants.EnvoyUniqueIDLabelName] = "proxy-3"
+			_, err = kubeClient.CoreV1().Pods(tests.Namespace).Create(context.TODO(), &pod, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			pods, err := c.ListPodsForProxyID(tests.Namespace, "proxy-3")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(pods)).To(Equal(1))
+			Expect(pods[0].Name).To(Equal(pod.Name))
+		})
+	})
+
+	Context("Testing ListServices", func() {
+		It("returns the services in the namespace", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			selectors := map[string]string{tests.SelectorKey: tests.SelectorValue}
+
+			svc := tests.NewServiceFixture(tests.BookstoreServiceName, tests.Namespace, selectors)
+			_, err := kubeClient.CoreV1().Services(tests.Namespace).Create(context.TODO(), svc, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			otherSvc := tests.NewServiceFixture(tests.BookbuyerServiceName, "some-other-namespace", selectors)
+			_, err = kubeClient.CoreV1().Services(otherSvc.Namespace).Create(context.TODO(), otherSvc, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			c, err := NewKubernetesController(kubeClient, nil, make(chan struct{}))
+			Expect(err).ToNot(HaveOccurred())
+
+			services, err := c.ListServices(tests.Namespace)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(services)).To(Equal(1))
+			Expect(services[0].Name).To(Equal(tests.BookstoreServiceName))
+		})
+
+		It("returns no services for an empty namespace without listing them from the API server", func() {
+			kubeClient := testclient.NewSimpleClientset()
+
+			c, err := NewKubernetesController(kubeClient, nil, make(chan struct{}))
+			Expect(err).ToNot(HaveOccurred())
+			listedServices := countServiceLists(kubeClient)
+
+			services, err := c.ListServices(tests.Namespace)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(services).To(BeEmpty())
+			Expect(countServiceLists(kubeClient)).To(Equal(listedServices))
+		})
+	})
+})
+
+// countServiceLists returns the number of times the services were listed from the fake API server
+func countServiceLists(kubeClient *testclient.Clientset) int {
+	count := 0
+	for _, action := range kubeClient.Actions() {
+		if action.Matches("list", "services") {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,8 @@
+package kubernetes
+
+import "github.com/pkg/errors"
+
+var (
+	errSyncingCaches       = errors.New("failed initial sync of Kubernetes resources")
+	errNamespaceNotWatched = errors.New("namespace is not watched")
+)
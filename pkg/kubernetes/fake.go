@@ -0,0 +1,27 @@
+package kubernetes
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type fakeController struct {
+	kubeClient kubernetes.Interface
+}
+
+// NewFakeController returns a Controller reading directly from the given Kubernetes client, used for testing.
+func NewFakeController(kubeClient kubernetes.Interface) Controller {
+	return fakeController{
+		kubeClient: kubeClient,
+	}
+}
+
+// ListPodsForProxyID returns the pods in the given namespace labeled with the given Envoy proxy ID.
+func (f fakeController) ListPodsForProxyID(namespace, proxyID string) ([]*corev1.Pod, error) {
+	return listPodsForProxyID(f.kubeClient, namespace, proxyID)
+}
+
+// ListServices returns the services in the given namespace.
+func (f fakeController) ListServices(namespace string) ([]*corev1.Service, error) {
+	return listServices(f.kubeClient, namespace)
+}
@@ -0,0 +1,299 @@
+package kubernetes
+
+import (
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NewInformer returns the informer created with newInformer for all namespaces when no namespaces are given.
+// Otherwise it returns an informer combining the informers created for each of the given namespaces, such that
+// the resources are listed and watched in these namespaces only.
+func NewInformer(namespaces []string, newInformer func(namespace string) cache.SharedIndexInformer) cache.SharedIndexInformer {
+	switch len(namespaces) {
+	case 0:
+		return newInformer(metav1.NamespaceAll)
+	case 1:
+		return newInformer(namespaces[0])
+	}
+
+	i := &multiNamespaceInformer{
+		informers: make(map[string]cache.SharedIndexInformer),
+	}
+	for _, ns := range namespaces {
+		if _, ok := i.informers[ns]; ok {
+			continue
+		}
+		i.informers[ns] = newInformer(ns)
+		i.namespaces = append(i.namespaces, ns)
+	}
+	sort.Strings(i.namespaces)
+	return i
+}
+
+// GetListedNamespaces returns the namespaces to list resources in: the given watched namespaces, or all namespaces
+// when none are given.
+func GetListedNamespaces(watchedNamespaces []string) []string {
+	if len(watchedNamespaces) == 0 {
+		return []string{metav1.NamespaceAll}
+	}
+	return watchedNamespaces
+}
+
+// multiNamespaceInformer is a cache.SharedIndexInformer combining the informers of a resource in multiple namespaces
+type multiNamespaceInformer struct {
+	namespaces []string
+	informers  map[string]cache.SharedIndexInformer
+}
+
+func (i *multiNamespaceInformer) AddEventHandler(handler cache.ResourceEventHandler) {
+	for _, ns := range i.namespaces {
+		i.informers[ns].AddEventHandler(handler)
+	}
+}
+
+func (i *multiNamespaceInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, resyncPeriod time.Duration) {
+	for _, ns := range i.namespaces {
+		i.informers[ns].AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	}
+}
+
+func (i *multiNamespaceInformer) GetStore() cache.Store {
+	return i.GetIndexer()
+}
+
+// GetController returns the informer itself, which runs the informers of all the namespaces
+func (i *multiNamespaceInformer) GetController() cache.Controller {
+	return i
+}
+
+// Run runs the informers of all the namespaces until stop is closed
+func (i *multiNamespaceInformer) Run(stop <-chan struct{}) {
+	for _, ns := range i.namespaces {
+		go i.informers[ns].Run(stop)
+	}
+	<-stop
+}
+
+func (i *multiNamespaceInformer) HasSynced() bool {
+	for _, ns := range i.namespaces {
+		if !i.informers[ns].HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+// LastSyncResourceVersion returns an empty string, as the resource versions of the namespaces are synced independently
+func (i *multiNamespaceInformer) LastSyncResourceVersion() string {
+	return ""
+}
+
+func (i *multiNamespaceInformer) AddIndexers(indexers cache.Indexers) error {
+	for _, ns := range i.namespaces {
+		if err := i.informers[ns].AddIndexers(indexers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *multiNamespaceInformer) GetIndexer() cache.Indexer {
+	indexer := multiNamespaceIndexer{
+		namespaces: i.namespaces,
+		indexers:   make(map[string]cache.Indexer),
+	}
+	for _, ns := range i.namespaces {
+		indexer.indexers[ns] = i.informers[ns].GetIndexer()
+	}
+	return indexer
+}
+
+// multiNamespaceIndexer is a cache.Indexer reading from the indexers of multiple namespaces.
+// Objects are read from and written to the indexer of their namespace.
+type multiNamespaceIndexer struct {
+	namespaces []string
+	indexers   map[string]cache.Indexer
+}
+
+// getIndexer returns the indexer of the namespace of the object with the given key
+func (m multiNamespaceIndexer) getIndexer(key string) (cache.Indexer, error) {
+	ns, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, err
+	}
+	indexer, ok := m.indexers[ns]
+	if !ok {
+		return nil, errNamespaceNotWatched
+	}
+	return indexer, nil
+}
+
+func (m multiNamespaceIndexer) getObjectIndexer(obj interface{}) (cache.Indexer, error) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return nil, err
+	}
+	return m.getIndexer(key)
+}
+
+func (m multiNamespaceIndexer) Add(obj interface{}) error {
+	indexer, err := m.getObjectIndexer(obj)
+	if err != nil {
+		return err
+	}
+	return indexer.Add(obj)
+}
+
+func (m multiNamespaceIndexer) Update(obj interface{}) error {
+	indexer, err := m.getObjectIndexer(obj)
+	if err != nil {
+		return err
+	}
+	return indexer.Update(obj)
+}
+
+func (m multiNamespaceIndexer) Delete(obj interface{}) error {
+	indexer, err := m.getObjectIndexer(obj)
+	if err != nil {
+		return err
+	}
+	return indexer.Delete(obj)
+}
+
+func (m multiNamespaceIndexer) List() []interface{} {
+	var objects []interface{}
+	for _, ns := range m.namespaces {
+		objects = append(objects, m.indexers[ns].List()...)
+	}
+	return objects
+}
+
+func (m multiNamespaceIndexer) ListKeys() []string {
+	var keys []string
+	for _, ns := range m.namespaces {
+		keys = append(keys, m.indexers[ns].ListKeys()...)
+	}
+	return keys
+}
+
+func (m multiNamespaceIndexer) Get(obj interface{}) (interface{}, bool, error) {
+	indexer, err := m.getObjectIndexer(obj)
+	if err == errNamespaceNotWatched {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return indexer.Get(obj)
+}
+
+// GetByKey returns the object with the given key, which does not exist when its namespace is not watched.
+func (m multiNamespaceIndexer) GetByKey(key string) (interface{}, bool, error) {
+	indexer, err := m.getIndexer(key)
+	if err == errNamespaceNotWatched {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return indexer.GetByKey(key)
+}
+
+// Replace replaces the objects of each namespace with the given objects of the namespace
+func (m multiNamespaceIndexer) Replace(objects []interface{}, resourceVersion string) error {
+	objectsByNamespace := make(map[string][]interface{})
+	for _, obj := range objects {
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+		if err != nil {
+			return err
+		}
+		ns, _, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			return err
+		}
+		objectsByNamespace[ns] = append(objectsByNamespace[ns], obj)
+	}
+	for _, ns := range m.namespaces {
+		if err := m.indexers[ns].Replace(objectsByNamespace[ns], resourceVersion); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiNamespaceIndexer) Resync() error {
+	for _, ns := range m.namespaces {
+		if err := m.indexers[ns].Resync(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiNamespaceIndexer) Index(indexName string, obj interface{}) ([]interface{}, error) {
+	var objects []interface{}
+	for _, ns := range m.namespaces {
+		indexed, err := m.indexers[ns].Index(indexName, obj)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, indexed...)
+	}
+	return objects, nil
+}
+
+func (m multiNamespaceIndexer) IndexKeys(indexName, indexedValue string) ([]string, error) {
+	var keys []string
+	for _, ns := range m.namespaces {
+		indexed, err := m.indexers[ns].IndexKeys(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, indexed...)
+	}
+	return keys, nil
+}
+
+func (m multiNamespaceIndexer) ListIndexFuncValues(indexName string) []string {
+	values := make(map[string]struct{})
+	var uniqueValues []string
+	for _, ns := range m.namespaces {
+		for _, value := range m.indexers[ns].ListIndexFuncValues(indexName) {
+			if _, ok := values[value]; ok {
+				continue
+			}
+			values[value] = struct{}{}
+			uniqueValues = append(uniqueValues, value)
+		}
+	}
+	return uniqueValues
+}
+
+func (m multiNamespaceIndexer) ByIndex(indexName, indexedValue string) ([]interface{}, error) {
+	var objects []interface{}
+	for _, ns := range m.namespaces {
+		indexed, err := m.indexers[ns].ByIndex(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, indexed...)
+	}
+	return objects, nil
+}
+
+// GetIndexers returns the indexers of the namespaces, which all have the same indexers
+func (m multiNamespaceIndexer) GetIndexers() cache.Indexers {
+	return m.indexers[m.namespaces[0]].GetIndexers()
+}
+
+func (m multiNamespaceIndexer) AddIndexers(indexers cache.Indexers) error {
+	for _, ns := range m.namespaces {
+		if err := m.indexers[ns].AddIndexers(indexers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package kubernetes
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("Test multi-namespace informer", func() {
+	Context("Testing NewInformer", func() {
+		It("lists and watches the resources of the given namespaces only", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			for _, ns := range []string{"bookstore", "bookbuyer", "bookthief"} {
+				svc := tests.NewServiceFixture(tests.BookstoreServiceName, ns, nil)
+				_, err := kubeClient.CoreV1().Services(ns).Create(context.TODO(), svc, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			informer := NewInformer([]string{"bookstore", "bookbuyer"}, func(namespace string) cache.SharedIndexInformer {
+				return informers.NewSharedInformerFactoryWithOptions(kubeClient, DefaultKubeEventResyncInterval, informers.WithNamespace(namespace)).Core().V1().Services().Informer()
+			})
+			Expect(informer.AddIndexers(cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})).To(Succeed())
+
+			stop := make(chan struct{})
+			defer close(stop)
+			go informer.Run(stop)
+			Expect(cache.WaitForCacheSync(stop, informer.HasSynced)).To(BeTrue())
+
+			indexer := informer.GetIndexer()
+			Expect(len(indexer.List())).To(Equal(2))
+
+			_, exists, err := indexer.GetByKey("bookbuyer/" + tests.BookstoreServiceName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue())
+
+			_, exists, err = indexer.GetByKey("bookthief/" + tests.BookstoreServiceName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeFalse())
+
+			services, err := indexer.ByIndex(cache.NamespaceIndex, "bookstore")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(services)).To(Equal(1))
+			Expect(services[0].(*corev1.Service).Namespace).To(Equal("bookstore"))
+		})
+	})
+})
@@ -3,6 +3,10 @@ package kubernetes
 import (
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
 	"github.com/openservicemesh/osm/pkg/logger"
 )
 
@@ -34,3 +38,21 @@ type Event struct {
 	Type  EventType
 	Value interface{}
 }
+
+// Controller is the interface for the indexed caches of the Kubernetes resources read in hot paths
+type Controller interface {
+	// ListPodsForProxyID returns the pods in the given namespace labeled with the given Envoy proxy ID.
+	// The returned pods are shared with the cache and must not be modified.
+	ListPodsForProxyID(namespace, proxyID string) ([]*corev1.Pod, error)
+
+	// ListServices returns the services in the given namespace, sorted by name.
+	// The returned services are shared with the cache and must not be modified.
+	ListServices(namespace string) ([]*corev1.Service, error)
+}
+
+// client is the type implementing the Controller interface with shared informer caches
+type client struct {
+	kubeClient kubernetes.Interface
+	pods       cache.Indexer
+	services   cache.Indexer
+}
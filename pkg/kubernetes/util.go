@@ -36,3 +36,8 @@ func GetDomainsForService(service *corev1.Service) []string {
 	}
 	return domains
 }
+
+// GetFQDNForService returns the fully qualified domain name of the service within the local cluster.
+func GetFQDNForService(service *corev1.Service) string {
+	return fmt.Sprintf("%s.%s.svc.%s", service.Name, service.Namespace, clusterDomain)
+}
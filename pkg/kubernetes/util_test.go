@@ -39,4 +39,11 @@ var _ = Describe("Domains for a kubernetes service", func() {
 			Expect(contains(domains, fmt.Sprintf("%s.%s.svc.cluster.local:%d", tests.BookbuyerServiceName, tests.Namespace, tests.ServicePort))).To(BeTrue())
 		})
 	})
+
+	Context("Testing GetFQDNForService", func() {
+		It("Returns the fully qualified domain name of the service", func() {
+			service := tests.NewServiceFixture(tests.BookbuyerServiceName, tests.Namespace, nil)
+			Expect(GetFQDNForService(service)).To(Equal(fmt.Sprintf("%s.%s.svc.cluster.local", tests.BookbuyerServiceName, tests.Namespace)))
+		})
+	})
 })
@@ -0,0 +1,74 @@
+package leader
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+const (
+	// LeaseName is the name of the lease held by the osm-controller replica performing the singleton duties
+	LeaseName = "osm-controller-leader"
+
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
+var (
+	log = logger.New("leader")
+)
+
+// Run starts the election of the osm-controller leader among the replicas in the given namespace.
+// While this replica leads, onStartedLeading runs with a channel closed when the leadership is lost.
+// The replica keeps taking part in the election after losing the leadership, until stop is closed.
+func Run(kubeClient kubernetes.Interface, osmNamespace, identity string, stop <-chan struct{}, onStartedLeading func(<-chan struct{})) {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      LeaseName,
+			Namespace: osmNamespace,
+		},
+		Client: kubeClient.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	go func() {
+		for ctx.Err() == nil {
+			leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+				Lock:            lock,
+				LeaseDuration:   leaseDuration,
+				RenewDeadline:   renewDeadline,
+				RetryPeriod:     retryPeriod,
+				ReleaseOnCancel: true,
+				Callbacks: leaderelection.LeaderCallbacks{
+					OnStartedLeading: func(leaderCtx context.Context) {
+						log.Info().Msgf("%s started leading", identity)
+						onStartedLeading(leaderCtx.Done())
+					},
+					OnStoppedLeading: func() {
+						log.Info().Msgf("%s stopped leading", identity)
+					},
+					OnNewLeader: func(leader string) {
+						if leader != identity {
+							log.Info().Msgf("%s is the leader", leader)
+						}
+					},
+				},
+			})
+		}
+	}()
+}
@@ -0,0 +1,33 @@
+package leader
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Test leader election", func() {
+	const osmNamespace = "osm-system"
+
+	Context("Test Run()", func() {
+		It("runs the singleton duties of the leader", func() {
+			kubeClient := fake.NewSimpleClientset()
+			stop := make(chan struct{})
+			defer close(stop)
+
+			leading := make(chan struct{})
+			Run(kubeClient, osmNamespace, "osm-controller-a", stop, func(<-chan struct{}) {
+				close(leading)
+			})
+			Eventually(leading, 5*time.Second).Should(BeClosed())
+
+			lease, err := kubeClient.CoordinationV1().Leases(osmNamespace).Get(context.Background(), LeaseName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*lease.Spec.HolderIdentity).To(Equal("osm-controller-a"))
+		})
+	})
+})
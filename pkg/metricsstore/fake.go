@@ -40,3 +40,7 @@ func (ms *fakeMetricStore) IncArmAPIUpdateCallSuccessCounter() {}
 func (ms *fakeMetricStore) IncArmAPICallCounter() {}
 
 func (ms *fakeMetricStore) IncK8sAPIEventCounter() {}
+
+func (ms *fakeMetricStore) ObserveBootstrapQueueWait(priority string, wait time.Duration) {}
+
+func (ms *fakeMetricStore) SetBootstrapQueueLength(priority string, length int) {}
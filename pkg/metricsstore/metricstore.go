@@ -21,6 +21,8 @@ type MetricStore interface {
 	Handler() http.Handler
 	SetUpdateLatencySec(time.Duration)
 	IncK8sAPIEventCounter()
+	ObserveBootstrapQueueWait(priority string, wait time.Duration)
+	SetBootstrapQueueLength(priority string, length int)
 }
 
 // OSMMetricsStore is store
@@ -29,6 +31,14 @@ type OSMMetricsStore struct {
 	updateLatency      prometheus.Gauge
 	k8sAPIEventCounter prometheus.Counter
 
+	// bootstrapQueueWait and bootstrapQueueLength describe the proxies waiting to be sent their initial configuration
+	bootstrapQueueWait   *prometheus.HistogramVec
+	bootstrapQueueLength *prometheus.GaugeVec
+
+	// processCollector and goCollector export the CPU, memory and runtime metrics of the process
+	processCollector prometheus.Collector
+	goCollector      prometheus.Collector
+
 	registry *prometheus.Registry
 }
 
@@ -53,7 +63,22 @@ func NewMetricStore(nameSpace string, podName string) MetricStore {
 			Name:        "k8s_api_event_counter",
 			Help:        "This counter represents the number of events received from Kubernetes API Server",
 		}),
-		registry: prometheus.NewRegistry(),
+		bootstrapQueueWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   PrometheusNamespace,
+			ConstLabels: constLabels,
+			Name:        "xds_bootstrap_queue_wait_seconds",
+			Help:        "The time Envoy proxies waited to be admitted to receive their initial configuration",
+			Buckets:     []float64{.01, .05, .1, .5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"priority"}),
+		bootstrapQueueLength: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   PrometheusNamespace,
+			ConstLabels: constLabels,
+			Name:        "xds_bootstrap_queue_length",
+			Help:        "The number of Envoy proxies waiting to be admitted to receive their initial configuration",
+		}, []string{"priority"}),
+		processCollector: prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		goCollector:      prometheus.NewGoCollector(),
+		registry:         prometheus.NewRegistry(),
 	}
 }
 
@@ -61,12 +86,20 @@ func NewMetricStore(nameSpace string, podName string) MetricStore {
 func (ms *OSMMetricsStore) Start() {
 	ms.registry.MustRegister(ms.updateLatency)
 	ms.registry.MustRegister(ms.k8sAPIEventCounter)
+	ms.registry.MustRegister(ms.bootstrapQueueWait)
+	ms.registry.MustRegister(ms.bootstrapQueueLength)
+	ms.registry.MustRegister(ms.processCollector)
+	ms.registry.MustRegister(ms.goCollector)
 }
 
 // Stop store
 func (ms *OSMMetricsStore) Stop() {
 	ms.registry.Unregister(ms.updateLatency)
 	ms.registry.Unregister(ms.k8sAPIEventCounter)
+	ms.registry.Unregister(ms.bootstrapQueueWait)
+	ms.registry.Unregister(ms.bootstrapQueueLength)
+	ms.registry.Unregister(ms.processCollector)
+	ms.registry.Unregister(ms.goCollector)
 }
 
 // SetUpdateLatencySec updates latency
@@ -79,6 +112,16 @@ func (ms *OSMMetricsStore) IncK8sAPIEventCounter() {
 	ms.k8sAPIEventCounter.Inc()
 }
 
+// ObserveBootstrapQueueWait records the time a proxy with the given priority waited to bootstrap
+func (ms *OSMMetricsStore) ObserveBootstrapQueueWait(priority string, wait time.Duration) {
+	ms.bootstrapQueueWait.WithLabelValues(priority).Observe(wait.Seconds())
+}
+
+// SetBootstrapQueueLength sets the number of proxies with the given priority waiting to bootstrap
+func (ms *OSMMetricsStore) SetBootstrapQueueLength(priority string, length int) {
+	ms.bootstrapQueueLength.WithLabelValues(priority).Set(float64(length))
+}
+
 // Handler return the registry
 func (ms *OSMMetricsStore) Handler() http.Handler {
 	return promhttp.InstrumentMetricHandler(
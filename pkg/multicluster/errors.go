@@ -0,0 +1,11 @@
+package multicluster
+
+import "github.com/pkg/errors"
+
+var (
+	errSyncingCaches      = errors.New("Failed initial cache sync for remote Service informer")
+	errNoGateway          = errors.New("Remote cluster has no multicluster gateway")
+	errNoGatewayAddress   = errors.New("Remote multicluster gateway has no load balancer IP address or hostname")
+	errNotMirrored        = errors.New("Local service or endpoints are not mirrored from the remote cluster")
+	errInvalidClusterName = errors.New("Remote cluster name must not be empty")
+)
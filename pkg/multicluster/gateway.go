@@ -0,0 +1,129 @@
+package multicluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/namespace"
+)
+
+// StartGatewayPortSync keeps the ports of the multicluster gateway service in the given OSM namespace in sync with
+// the ports of the exported services, which the gateway listens on, until the given stop channel is closed.
+func StartGatewayPortSync(kubeClient kubernetes.Interface, namespaceController namespace.Controller, watchedNamespaces []string, osmNamespace string, stop <-chan struct{}) error {
+	informer := k8s.NewInformer(watchedNamespaces, func(ns string) cache.SharedIndexInformer {
+		return informers.NewSharedInformerFactoryWithOptions(kubeClient, k8s.DefaultKubeEventResyncInterval, informers.WithNamespace(ns)).Core().V1().Services().Informer()
+	})
+
+	sync := func() {
+		if err := syncGatewayPorts(kubeClient, namespaceController, informer.GetStore().List(), osmNamespace); err != nil {
+			log.Error().Err(err).Msgf("Error updating the ports of multicluster gateway service %s/%s", osmNamespace, GatewayName)
+		}
+	}
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(_ interface{}) {
+			sync()
+		},
+		UpdateFunc: func(_, _ interface{}) {
+			sync()
+		},
+		DeleteFunc: func(_ interface{}) {
+			sync()
+		},
+	})
+
+	go informer.Run(stop)
+	if !cache.WaitForCacheSync(stop, informer.HasSynced) {
+		return errSyncingCaches
+	}
+	sync()
+	return nil
+}
+
+// syncGatewayPorts updates the ports of the multicluster gateway service to the ports of the given exported services
+// of monitored namespaces. A service must have a port, so the gateway port is kept while no service is exported.
+func syncGatewayPorts(kubeClient kubernetes.Interface, namespaceController namespace.Controller, services []interface{}, osmNamespace string) error {
+	ports := make(map[int32]corev1.ServicePort)
+	for _, obj := range services {
+		svc := obj.(*corev1.Service)
+		if !IsExported(svc) || !namespaceController.IsMonitoredNamespace(svc.Namespace) {
+			continue
+		}
+		for _, port := range svc.Spec.Ports {
+			if port.Protocol != "" && port.Protocol != corev1.ProtocolTCP {
+				continue
+			}
+			ports[port.Port] = getGatewayServicePort(port.Port)
+		}
+	}
+	if len(ports) == 0 {
+		ports[constants.MulticlusterGatewayPort] = getGatewayServicePort(constants.MulticlusterGatewayPort)
+	}
+
+	var gatewayPorts []corev1.ServicePort
+	for _, port := range ports {
+		gatewayPorts = append(gatewayPorts, port)
+	}
+	sort.Slice(gatewayPorts, func(i, j int) bool {
+		return gatewayPorts[i].Port < gatewayPorts[j].Port
+	})
+
+	gateway, err := kubeClient.CoreV1().Services(osmNamespace).Get(context.Background(), GatewayName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if samePorts(gateway.Spec.Ports, gatewayPorts) {
+		return nil
+	}
+
+	// The node ports allocated to the ports which are kept are preserved
+	for i := range gatewayPorts {
+		for _, existing := range gateway.Spec.Ports {
+			if existing.Port == gatewayPorts[i].Port {
+				gatewayPorts[i].NodePort = existing.NodePort
+			}
+		}
+	}
+	gateway.Spec.Ports = gatewayPorts
+	if _, err := kubeClient.CoreV1().Services(osmNamespace).Update(context.Background(), gateway, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	log.Info().Msgf("Updated the ports of multicluster gateway service %s/%s", osmNamespace, GatewayName)
+	return nil
+}
+
+// getGatewayServicePort returns the port of the multicluster gateway service forwarding the given port to the gateway
+func getGatewayServicePort(port int32) corev1.ServicePort {
+	return corev1.ServicePort{
+		Name:       fmt.Sprintf("%s-%d", strings.ToLower(string(corev1.ProtocolTCP)), port),
+		Protocol:   corev1.ProtocolTCP,
+		Port:       port,
+		TargetPort: intstr.FromInt(int(port)),
+	}
+}
+
+// samePorts returns true if the given ports of the gateway service only differ by their node ports
+func samePorts(existing, desired []corev1.ServicePort) bool {
+	if len(existing) != len(desired) {
+		return false
+	}
+	for i := range existing {
+		port := existing[i]
+		port.NodePort = 0
+		if !reflect.DeepEqual(port, desired[i]) {
+			return false
+		}
+	}
+	return true
+}
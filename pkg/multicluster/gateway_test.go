@@ -0,0 +1,90 @@
+package multicluster
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/namespace"
+)
+
+var _ = Describe("Test multicluster gateway ports", func() {
+	const (
+		osmNamespace       = "osm-system"
+		monitoredNamespace = "bookstore"
+	)
+
+	newService := func(ns, name string, exported bool, ports ...int32) *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   ns,
+				Annotations: map[string]string{},
+			},
+		}
+		for _, port := range ports {
+			svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{Protocol: corev1.ProtocolTCP, Port: port})
+		}
+		if exported {
+			svc.Annotations[ExportAnnotation] = "true"
+		}
+		return svc
+	}
+
+	var kubeClient *fake.Clientset
+	namespaceController := namespace.NewFakeNamespaceController([]string{monitoredNamespace})
+
+	BeforeEach(func() {
+		gateway := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GatewayName,
+				Namespace: osmNamespace,
+			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: "gateway-port", Protocol: corev1.ProtocolTCP, Port: constants.MulticlusterGatewayPort}},
+			},
+		}
+		kubeClient = fake.NewSimpleClientset(gateway)
+	})
+
+	getGatewayPorts := func() []corev1.ServicePort {
+		gateway, err := kubeClient.CoreV1().Services(osmNamespace).Get(context.Background(), GatewayName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return gateway.Spec.Ports
+	}
+
+	Context("Test syncGatewayPorts()", func() {
+		It("exposes the sorted ports of the exported services of monitored namespaces", func() {
+			services := []interface{}{
+				newService(monitoredNamespace, "bookstore-v1", true, 9090, 8080),
+				newService(monitoredNamespace, "bookstore-v2", true, 8080),
+				newService(monitoredNamespace, "bookbuyer", false, 7070),
+				newService("other", "bookstore-v1", true, 6060),
+			}
+			Expect(syncGatewayPorts(kubeClient, namespaceController, services, osmNamespace)).To(Succeed())
+
+			Expect(getGatewayPorts()).To(Equal([]corev1.ServicePort{
+				{Name: "tcp-8080", Protocol: corev1.ProtocolTCP, Port: 8080, TargetPort: intstr.FromInt(8080)},
+				{Name: "tcp-9090", Protocol: corev1.ProtocolTCP, Port: 9090, TargetPort: intstr.FromInt(9090)},
+			}))
+		})
+
+		It("keeps the gateway port while no service is exported", func() {
+			Expect(syncGatewayPorts(kubeClient, namespaceController, nil, osmNamespace)).To(Succeed())
+
+			Expect(getGatewayPorts()).To(Equal([]corev1.ServicePort{{
+				Name:       "tcp-15443",
+				Protocol:   corev1.ProtocolTCP,
+				Port:       constants.MulticlusterGatewayPort,
+				TargetPort: intstr.FromInt(constants.MulticlusterGatewayPort),
+			}}))
+		})
+	})
+})
@@ -0,0 +1,327 @@
+package multicluster
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/namespace"
+)
+
+const (
+	// MirroredFromLabel is the label on a mirrored service and its endpoints, set to the name of the cluster it is mirrored from.
+	// Example: openservicemesh.io/multicluster-mirrored-from: "west"
+	MirroredFromLabel = "openservicemesh.io/multicluster-mirrored-from"
+)
+
+// NewMirror creates and starts a controller mirroring the services exported by the remote cluster with the given name.
+// Services are only mirrored to namespaces monitored by the local mesh. When watchedNamespaces is not empty,
+// only the services of these namespaces are watched in the remote cluster and mirrored in the local one.
+func NewMirror(localKubeClient, remoteKubeClient kubernetes.Interface, namespaceController namespace.Controller, watchedNamespaces []string, remoteClusterName, remoteOSMNamespace string, stop <-chan struct{}) (*Mirror, error) {
+	if remoteClusterName == "" {
+		return nil, errInvalidClusterName
+	}
+
+	m := &Mirror{
+		remoteClusterName:   remoteClusterName,
+		remoteOSMNamespace:  remoteOSMNamespace,
+		localKubeClient:     localKubeClient,
+		namespaceController: namespaceController,
+		watchedNamespaces:   watchedNamespaces,
+		informer: k8s.NewInformer(watchedNamespaces, func(ns string) cache.SharedIndexInformer {
+			return informers.NewSharedInformerFactoryWithOptions(remoteKubeClient, k8s.DefaultKubeEventResyncInterval, informers.WithNamespace(ns)).Core().V1().Services().Informer()
+		}),
+		gatewayInformer: newGatewayInformer(remoteKubeClient, remoteOSMNamespace),
+	}
+
+	// Every exported service is re-applied when the address of the remote gateway changes,
+	// and its mirror is deleted when the remote gateway is deleted
+	m.gatewayInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(_ interface{}) {
+			m.reconcileAll()
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if getGatewayAddress(oldObj.(*corev1.Service)) != getGatewayAddress(newObj.(*corev1.Service)) {
+				m.reconcileAll()
+			}
+		},
+		DeleteFunc: func(_ interface{}) {
+			m.reconcileAll()
+		},
+	})
+
+	// The periodic resync re-applies every exported service, which also picks up changes to the locally
+	// monitored namespaces, and to the addresses the hostname of the remote gateway resolves to.
+	m.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			m.reconcile(obj.(*corev1.Service))
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			m.reconcile(newObj.(*corev1.Service))
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if svc, ok := obj.(*corev1.Service); ok {
+				m.unmirrorService(svc.Namespace, svc.Name)
+			}
+		},
+	})
+
+	go m.gatewayInformer.Run(stop)
+	go m.informer.Run(stop)
+	log.Info().Msgf("Waiting for Service informer cache sync of remote cluster %s", remoteClusterName)
+	if !cache.WaitForCacheSync(stop, m.gatewayInformer.HasSynced, m.informer.HasSynced) {
+		return nil, errSyncingCaches
+	}
+
+	m.pruneMirroredServices()
+
+	log.Info().Msgf("Mirroring services exported by remote cluster %s", remoteClusterName)
+	return m, nil
+}
+
+// reconcile mirrors the given remote service if it is exported, and removes its mirror otherwise.
+func (m *Mirror) reconcile(remoteSvc *corev1.Service) {
+	if !IsExported(remoteSvc) || !m.namespaceController.IsMonitoredNamespace(remoteSvc.Namespace) {
+		m.unmirrorService(remoteSvc.Namespace, remoteSvc.Name)
+		return
+	}
+
+	gatewayIPs, err := m.getRemoteGatewayIPs()
+	if err != nil {
+		log.Error().Err(err).Msgf("Error mirroring service %s/%s from remote cluster %s", remoteSvc.Namespace, remoteSvc.Name, m.remoteClusterName)
+		// The traffic to a remote cluster without a gateway has nowhere to go
+		if err == errNoGateway || err == errNoGatewayAddress {
+			m.unmirrorService(remoteSvc.Namespace, remoteSvc.Name)
+		}
+		return
+	}
+
+	if err := m.mirrorService(remoteSvc, gatewayIPs); err != nil {
+		log.Error().Err(err).Msgf("Error mirroring service %s/%s from remote cluster %s", remoteSvc.Namespace, remoteSvc.Name, m.remoteClusterName)
+	}
+}
+
+// reconcileAll re-applies every remote service.
+func (m *Mirror) reconcileAll() {
+	for _, obj := range m.informer.GetStore().List() {
+		m.reconcile(obj.(*corev1.Service))
+	}
+}
+
+// newGatewayInformer returns an informer watching the multicluster gateway service of the remote cluster only.
+func newGatewayInformer(remoteKubeClient kubernetes.Interface, remoteOSMNamespace string) cache.SharedIndexInformer {
+	return informers.NewSharedInformerFactoryWithOptions(remoteKubeClient, k8s.DefaultKubeEventResyncInterval,
+		informers.WithNamespace(remoteOSMNamespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", GatewayName).String()
+		}),
+	).Core().V1().Services().Informer()
+}
+
+// getRemoteGatewayIPs returns the sorted load balancer IP addresses of the remote cluster's multicluster gateway,
+// as cached by the gateway informer. A load balancer with a hostname, such as an AWS ELB, is resolved to its addresses.
+func (m *Mirror) getRemoteGatewayIPs() ([]string, error) {
+	obj, exists, err := m.gatewayInformer.GetStore().GetByKey(m.remoteOSMNamespace + "/" + GatewayName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errNoGateway
+	}
+
+	address := getGatewayAddress(obj.(*corev1.Service))
+	if address == "" {
+		return nil, errNoGatewayAddress
+	}
+	if net.ParseIP(address) != nil {
+		return []string{address}, nil
+	}
+
+	ips, err := lookupHost(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error resolving hostname %s of remote multicluster gateway", address)
+	}
+	sort.Strings(ips)
+	return ips, nil
+}
+
+// getGatewayAddress returns the load balancer IP address of the given gateway service, or its hostname if it has no IP address.
+func getGatewayAddress(gateway *corev1.Service) string {
+	for _, ingress := range gateway.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			return ingress.IP
+		}
+	}
+	for _, ingress := range gateway.Status.LoadBalancer.Ingress {
+		if ingress.Hostname != "" {
+			return ingress.Hostname
+		}
+	}
+	return ""
+}
+
+// mirrorService creates or updates the local service and endpoints mirroring the given remote service.
+// The mirrored service has no selector; its endpoints point at the remote gateway, which listens on
+// the ports of the exported services and routes each connection by its SNI.
+func (m *Mirror) mirrorService(remoteSvc *corev1.Service, gatewayIPs []string) error {
+	name := GetMirroredServiceName(remoteSvc.Name, m.remoteClusterName)
+	objectMeta := metav1.ObjectMeta{
+		Name:      name,
+		Namespace: remoteSvc.Namespace,
+		Labels: map[string]string{
+			MirroredFromLabel: m.remoteClusterName,
+		},
+	}
+
+	var servicePorts []corev1.ServicePort
+	var endpointPorts []corev1.EndpointPort
+	for _, port := range remoteSvc.Spec.Ports {
+		servicePorts = append(servicePorts, corev1.ServicePort{
+			Name:     port.Name,
+			Protocol: port.Protocol,
+			Port:     port.Port,
+		})
+		endpointPorts = append(endpointPorts, corev1.EndpointPort{
+			Name:     port.Name,
+			Protocol: port.Protocol,
+			Port:     port.Port,
+		})
+	}
+
+	var addresses []corev1.EndpointAddress
+	for _, ip := range gatewayIPs {
+		addresses = append(addresses, corev1.EndpointAddress{IP: ip})
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: objectMeta,
+		Spec: corev1.ServiceSpec{
+			Ports: servicePorts,
+		},
+	}
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: objectMeta,
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: addresses,
+			Ports:     endpointPorts,
+		}},
+	}
+
+	services := m.localKubeClient.CoreV1().Services(remoteSvc.Namespace)
+	existingSvc, err := services.Get(context.Background(), name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		if _, err := services.Create(context.Background(), svc, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		log.Info().Msgf("Mirrored service %s/%s from remote cluster %s", remoteSvc.Namespace, remoteSvc.Name, m.remoteClusterName)
+	case err != nil:
+		return err
+	case existingSvc.Labels[MirroredFromLabel] != m.remoteClusterName:
+		// Never update a service this controller does not own
+		log.Error().Msgf("Not mirroring service %s/%s from remote cluster %s over local service %s/%s without label %s=%s",
+			remoteSvc.Namespace, remoteSvc.Name, m.remoteClusterName, remoteSvc.Namespace, name, MirroredFromLabel, m.remoteClusterName)
+		return errNotMirrored
+	case !reflect.DeepEqual(existingSvc.Spec.Ports, svc.Spec.Ports):
+		// Only the ports are copied; the cluster IP assigned to the existing service is kept
+		existingSvc.Spec.Ports = svc.Spec.Ports
+		if _, err := services.Update(context.Background(), existingSvc, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
+	endpointsClient := m.localKubeClient.CoreV1().Endpoints(remoteSvc.Namespace)
+	existingEndpoints, err := endpointsClient.Get(context.Background(), name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		_, err = endpointsClient.Create(context.Background(), endpoints, metav1.CreateOptions{})
+		return err
+	case err != nil:
+		return err
+	case existingEndpoints.Labels[MirroredFromLabel] != m.remoteClusterName:
+		log.Error().Msgf("Not mirroring service %s/%s from remote cluster %s over local endpoints %s/%s without label %s=%s",
+			remoteSvc.Namespace, remoteSvc.Name, m.remoteClusterName, remoteSvc.Namespace, name, MirroredFromLabel, m.remoteClusterName)
+		return errNotMirrored
+	case !reflect.DeepEqual(existingEndpoints.Subsets, endpoints.Subsets):
+		existingEndpoints.Subsets = endpoints.Subsets
+		_, err = endpointsClient.Update(context.Background(), existingEndpoints, metav1.UpdateOptions{})
+		return err
+	}
+	return nil
+}
+
+// unmirrorService deletes the local service mirroring the given remote service, if any.
+func (m *Mirror) unmirrorService(namespace, name string) {
+	mirroredName := GetMirroredServiceName(name, m.remoteClusterName)
+	existing, err := m.localKubeClient.CoreV1().Services(namespace).Get(context.Background(), mirroredName, metav1.GetOptions{})
+	if err != nil {
+		return
+	}
+	// Never delete a service this controller does not own
+	if existing.Labels[MirroredFromLabel] != m.remoteClusterName {
+		return
+	}
+	if err := m.deleteMirroredService(namespace, mirroredName); err != nil {
+		log.Error().Err(err).Msgf("Error deleting mirrored service %s/%s", namespace, mirroredName)
+		return
+	}
+	log.Info().Msgf("Deleted service %s/%s mirrored from remote cluster %s", namespace, mirroredName, m.remoteClusterName)
+}
+
+// deleteMirroredService deletes the mirrored service and the endpoints with the given name.
+func (m *Mirror) deleteMirroredService(namespace, name string) error {
+	if err := m.localKubeClient.CoreV1().Services(namespace).Delete(context.Background(), name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err := m.localKubeClient.CoreV1().Endpoints(namespace).Delete(context.Background(), name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// pruneMirroredServices deletes the local services mirrored from services the remote cluster no longer has,
+// such as services deleted while the controller was not running.
+func (m *Mirror) pruneMirroredServices() {
+	selector := labels.SelectorFromSet(map[string]string{MirroredFromLabel: m.remoteClusterName}).String()
+	var mirrored []corev1.Service
+	for _, ns := range k8s.GetListedNamespaces(m.watchedNamespaces) {
+		services, err := m.localKubeClient.CoreV1().Services(ns).List(context.Background(), metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			log.Error().Err(err).Msgf("Error listing services mirrored from remote cluster %s", m.remoteClusterName)
+			return
+		}
+		mirrored = append(mirrored, services.Items...)
+	}
+
+	remote := make(map[string]struct{})
+	for _, obj := range m.informer.GetStore().List() {
+		svc := obj.(*corev1.Service)
+		if IsExported(svc) {
+			remote[svc.Namespace+"/"+GetMirroredServiceName(svc.Name, m.remoteClusterName)] = struct{}{}
+		}
+	}
+
+	for _, svc := range mirrored {
+		if _, ok := remote[svc.Namespace+"/"+svc.Name]; ok {
+			continue
+		}
+		if err := m.deleteMirroredService(svc.Namespace, svc.Name); err != nil {
+			log.Error().Err(err).Msgf("Error deleting stale mirrored service %s/%s", svc.Namespace, svc.Name)
+		}
+	}
+}
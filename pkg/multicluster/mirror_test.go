@@ -0,0 +1,296 @@
+package multicluster
+
+import (
+	"context"
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/namespace"
+)
+
+var _ = Describe("Test service mirroring", func() {
+	const (
+		remoteClusterName  = "west"
+		remoteOSMNamespace = "osm-system"
+		monitoredNamespace = "bookstore"
+		gatewayIP          = "20.0.0.1"
+	)
+
+	newRemoteService := func(ns, name string, exported bool) *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   ns,
+				Annotations: map[string]string{},
+			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{
+					Name:     "http",
+					Protocol: corev1.ProtocolTCP,
+					Port:     8080,
+				}},
+			},
+		}
+		if exported {
+			svc.Annotations[ExportAnnotation] = "true"
+		}
+		return svc
+	}
+
+	remoteGateway := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GatewayName,
+			Namespace: remoteOSMNamespace,
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{IP: gatewayIP}},
+			},
+		},
+	}
+
+	var (
+		localKubeClient  *fake.Clientset
+		remoteKubeClient *fake.Clientset
+		m                *Mirror
+	)
+
+	BeforeEach(func() {
+		localKubeClient = fake.NewSimpleClientset()
+		remoteKubeClient = fake.NewSimpleClientset(remoteGateway)
+		m = &Mirror{
+			remoteClusterName:   remoteClusterName,
+			remoteOSMNamespace:  remoteOSMNamespace,
+			localKubeClient:     localKubeClient,
+			namespaceController: namespace.NewFakeNamespaceController([]string{monitoredNamespace}),
+			gatewayInformer:     newGatewayInformer(remoteKubeClient, remoteOSMNamespace),
+		}
+		Expect(m.gatewayInformer.GetStore().Add(remoteGateway)).To(Succeed())
+	})
+
+	Context("Test reconcile()", func() {
+		It("mirrors an exported service to the remote gateway", func() {
+			m.reconcile(newRemoteService(monitoredNamespace, "bookstore-v1", true))
+
+			svc, err := localKubeClient.CoreV1().Services(monitoredNamespace).Get(context.Background(), "bookstore-v1-west", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(svc.Labels[MirroredFromLabel]).To(Equal(remoteClusterName))
+			Expect(svc.Spec.Selector).To(BeEmpty())
+			Expect(svc.Spec.Ports).To(Equal([]corev1.ServicePort{{Name: "http", Protocol: corev1.ProtocolTCP, Port: 8080}}))
+
+			endpoints, err := localKubeClient.CoreV1().Endpoints(monitoredNamespace).Get(context.Background(), "bookstore-v1-west", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(endpoints.Subsets).To(Equal([]corev1.EndpointSubset{{
+				Addresses: []corev1.EndpointAddress{{IP: gatewayIP}},
+				Ports:     []corev1.EndpointPort{{Name: "http", Protocol: corev1.ProtocolTCP, Port: 8080}},
+			}}))
+		})
+
+		It("keeps the name and number of each port of a multi-port service", func() {
+			remoteSvc := newRemoteService(monitoredNamespace, "bookstore-v1", true)
+			remoteSvc.Spec.Ports = append(remoteSvc.Spec.Ports, corev1.ServicePort{Name: "grpc", Protocol: corev1.ProtocolTCP, Port: 9090})
+			m.reconcile(remoteSvc)
+
+			endpoints, err := localKubeClient.CoreV1().Endpoints(monitoredNamespace).Get(context.Background(), "bookstore-v1-west", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(endpoints.Subsets[0].Ports).To(Equal([]corev1.EndpointPort{
+				{Name: "http", Protocol: corev1.ProtocolTCP, Port: 8080},
+				{Name: "grpc", Protocol: corev1.ProtocolTCP, Port: 9090},
+			}))
+		})
+
+		It("mirrors an exported service to the addresses of a remote gateway with a hostname", func() {
+			gateway := remoteGateway.DeepCopy()
+			gateway.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: "gateway.west.example.com"}}
+			Expect(m.gatewayInformer.GetStore().Update(gateway)).To(Succeed())
+			defer func() {
+				lookupHost = net.LookupHost
+			}()
+			lookupHost = func(host string) ([]string, error) {
+				Expect(host).To(Equal("gateway.west.example.com"))
+				return []string{"20.0.0.3", "20.0.0.2"}, nil
+			}
+
+			m.reconcile(newRemoteService(monitoredNamespace, "bookstore-v1", true))
+
+			endpoints, err := localKubeClient.CoreV1().Endpoints(monitoredNamespace).Get(context.Background(), "bookstore-v1-west", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(endpoints.Subsets[0].Addresses).To(Equal([]corev1.EndpointAddress{{IP: "20.0.0.2"}, {IP: "20.0.0.3"}}))
+		})
+
+		It("does not mirror a service that is not exported", func() {
+			m.reconcile(newRemoteService(monitoredNamespace, "bookstore-v1", false))
+
+			_, err := localKubeClient.CoreV1().Services(monitoredNamespace).Get(context.Background(), "bookstore-v1-west", metav1.GetOptions{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("does not mirror a service to a namespace not monitored by the mesh", func() {
+			m.reconcile(newRemoteService("other", "bookstore-v1", true))
+
+			_, err := localKubeClient.CoreV1().Services("other").Get(context.Background(), "bookstore-v1-west", metav1.GetOptions{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("deletes the mirror of a service that is no longer exported", func() {
+			m.reconcile(newRemoteService(monitoredNamespace, "bookstore-v1", true))
+			m.reconcile(newRemoteService(monitoredNamespace, "bookstore-v1", false))
+
+			_, err := localKubeClient.CoreV1().Services(monitoredNamespace).Get(context.Background(), "bookstore-v1-west", metav1.GetOptions{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			_, err = localKubeClient.CoreV1().Endpoints(monitoredNamespace).Get(context.Background(), "bookstore-v1-west", metav1.GetOptions{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("does not mirror a service when the remote cluster has no gateway", func() {
+			m.gatewayInformer = newGatewayInformer(fake.NewSimpleClientset(), remoteOSMNamespace)
+			m.reconcile(newRemoteService(monitoredNamespace, "bookstore-v1", true))
+
+			_, err := localKubeClient.CoreV1().Services(monitoredNamespace).Get(context.Background(), "bookstore-v1-west", metav1.GetOptions{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("Test mirrorService()", func() {
+		It("does not update a local service it does not own", func() {
+			local := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bookstore-v1-west",
+					Namespace: monitoredNamespace,
+				},
+				Spec: corev1.ServiceSpec{
+					Selector: map[string]string{"app": "bookstore-v1-west"},
+				},
+			}
+			_, err := localKubeClient.CoreV1().Services(monitoredNamespace).Create(context.Background(), local, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(m.mirrorService(newRemoteService(monitoredNamespace, "bookstore-v1", true), []string{gatewayIP})).To(Equal(errNotMirrored))
+
+			svc, err := localKubeClient.CoreV1().Services(monitoredNamespace).Get(context.Background(), "bookstore-v1-west", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(svc.Spec.Ports).To(BeEmpty())
+			_, err = localKubeClient.CoreV1().Endpoints(monitoredNamespace).Get(context.Background(), "bookstore-v1-west", metav1.GetOptions{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("does not update local endpoints it does not own", func() {
+			local := &corev1.Endpoints{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bookstore-v1-west",
+					Namespace: monitoredNamespace,
+				},
+			}
+			_, err := localKubeClient.CoreV1().Endpoints(monitoredNamespace).Create(context.Background(), local, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(m.mirrorService(newRemoteService(monitoredNamespace, "bookstore-v1", true), []string{gatewayIP})).To(Equal(errNotMirrored))
+
+			endpoints, err := localKubeClient.CoreV1().Endpoints(monitoredNamespace).Get(context.Background(), "bookstore-v1-west", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(endpoints.Subsets).To(BeEmpty())
+		})
+	})
+
+	Context("Test unmirrorService()", func() {
+		It("does not delete a local service it does not own", func() {
+			local := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bookstore-v1-west",
+					Namespace: monitoredNamespace,
+				},
+			}
+			_, err := localKubeClient.CoreV1().Services(monitoredNamespace).Create(context.Background(), local, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			m.unmirrorService(monitoredNamespace, "bookstore-v1")
+
+			_, err = localKubeClient.CoreV1().Services(monitoredNamespace).Get(context.Background(), "bookstore-v1-west", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("Test NewMirror()", func() {
+		It("returns an error when the remote cluster name is empty", func() {
+			_, err := NewMirror(localKubeClient, remoteKubeClient, m.namespaceController, nil, "", remoteOSMNamespace, make(chan struct{}))
+			Expect(err).To(Equal(errInvalidClusterName))
+		})
+
+		It("mirrors exported services and prunes stale mirrors at startup", func() {
+			stale := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deleted-west",
+					Namespace: monitoredNamespace,
+					Labels:    map[string]string{MirroredFromLabel: remoteClusterName},
+				},
+			}
+			_, err := localKubeClient.CoreV1().Services(monitoredNamespace).Create(context.Background(), stale, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = remoteKubeClient.CoreV1().Services(monitoredNamespace).Create(context.Background(), newRemoteService(monitoredNamespace, "bookstore-v1", true), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			stop := make(chan struct{})
+			defer close(stop)
+			_, err = NewMirror(localKubeClient, remoteKubeClient, m.namespaceController, nil, remoteClusterName, remoteOSMNamespace, stop)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = localKubeClient.CoreV1().Services(monitoredNamespace).Get(context.Background(), "deleted-west", metav1.GetOptions{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			Eventually(func() error {
+				_, err := localKubeClient.CoreV1().Services(monitoredNamespace).Get(context.Background(), "bookstore-v1-west", metav1.GetOptions{})
+				return err
+			}).ShouldNot(HaveOccurred())
+		})
+
+		It("updates the mirrored endpoints when the address of the remote gateway changes", func() {
+			_, err := remoteKubeClient.CoreV1().Services(monitoredNamespace).Create(context.Background(), newRemoteService(monitoredNamespace, "bookstore-v1", true), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			stop := make(chan struct{})
+			defer close(stop)
+			_, err = NewMirror(localKubeClient, remoteKubeClient, m.namespaceController, nil, remoteClusterName, remoteOSMNamespace, stop)
+			Expect(err).ToNot(HaveOccurred())
+
+			gateway := remoteGateway.DeepCopy()
+			gateway.Status.LoadBalancer.Ingress[0].IP = "20.0.0.2"
+			_, err = remoteKubeClient.CoreV1().Services(remoteOSMNamespace).UpdateStatus(context.Background(), gateway, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(func() []corev1.EndpointAddress {
+				endpoints, err := localKubeClient.CoreV1().Endpoints(monitoredNamespace).Get(context.Background(), "bookstore-v1-west", metav1.GetOptions{})
+				if err != nil || len(endpoints.Subsets) == 0 {
+					return nil
+				}
+				return endpoints.Subsets[0].Addresses
+			}).Should(Equal([]corev1.EndpointAddress{{IP: "20.0.0.2"}}))
+		})
+
+		It("deletes the mirrored services when the remote gateway is deleted", func() {
+			_, err := remoteKubeClient.CoreV1().Services(monitoredNamespace).Create(context.Background(), newRemoteService(monitoredNamespace, "bookstore-v1", true), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			stop := make(chan struct{})
+			defer close(stop)
+			_, err = NewMirror(localKubeClient, remoteKubeClient, m.namespaceController, nil, remoteClusterName, remoteOSMNamespace, stop)
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(func() error {
+				_, err := localKubeClient.CoreV1().Services(monitoredNamespace).Get(context.Background(), "bookstore-v1-west", metav1.GetOptions{})
+				return err
+			}).ShouldNot(HaveOccurred())
+
+			Expect(remoteKubeClient.CoreV1().Services(remoteOSMNamespace).Delete(context.Background(), GatewayName, metav1.DeleteOptions{})).To(Succeed())
+
+			Eventually(func() bool {
+				_, err := localKubeClient.CoreV1().Services(monitoredNamespace).Get(context.Background(), "bookstore-v1-west", metav1.GetOptions{})
+				return apierrors.IsNotFound(err)
+			}).Should(BeTrue())
+		})
+	})
+})
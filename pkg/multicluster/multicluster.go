@@ -0,0 +1,73 @@
+package multicluster
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// GatewayName is the name of the multicluster gateway's deployment, service and service account
+	GatewayName = "osm-multicluster-gateway"
+
+	// GatewayBootstrapSecretName is the name of the secret with the bootstrap config of the multicluster gateway
+	GatewayBootstrapSecretName = "osm-multicluster-gateway-bootstrap-config"
+
+	// gatewayProxyID is the proxy ID in the common name of the certificate the gateway connects to XDS with
+	gatewayProxyID = "multicluster-gateway"
+
+	// ExportAnnotation is the annotation on a Kubernetes service exporting it to other meshes through the gateway.
+	// Example: openservicemesh.io/multicluster-export: "true"
+	ExportAnnotation = "openservicemesh.io/multicluster-export"
+)
+
+// GetGatewayService returns the MeshService of the multicluster gateway running in the given OSM namespace.
+func GetGatewayService(osmNamespace string) service.MeshService {
+	return service.MeshService{
+		Namespace: osmNamespace,
+		Name:      GatewayName,
+	}
+}
+
+// GetGatewayCommonName returns the common name of the certificate the multicluster gateway connects to XDS with.
+// It follows the <proxy-UUID>.<service-account>.<namespace> form of the sidecar certificates.
+func GetGatewayCommonName(osmNamespace string) certificate.CommonName {
+	return certificate.CommonName(fmt.Sprintf("%s.%s.%s", gatewayProxyID, GatewayName, osmNamespace))
+}
+
+// IsGatewayProxy returns true if the given common name belongs to the multicluster gateway.
+func IsGatewayProxy(cn certificate.CommonName, osmNamespace string) bool {
+	return cn == GetGatewayCommonName(osmNamespace)
+}
+
+// IsExported returns true if the given Kubernetes service is exported to other meshes.
+func IsExported(svc *corev1.Service) bool {
+	if svc == nil {
+		return false
+	}
+	exported, err := strconv.ParseBool(svc.Annotations[ExportAnnotation])
+	return err == nil && exported
+}
+
+// GetMirroredServiceName returns the name by which other meshes address the given service of the given cluster.
+func GetMirroredServiceName(name, clusterName string) string {
+	return fmt.Sprintf("%s-%s", name, clusterName)
+}
+
+// GetGatewayClusterName returns the name of the cluster the multicluster gateway forwards the connections
+// received on the given port for the given exported service to.
+func GetGatewayClusterName(svc service.MeshService, port uint32) string {
+	return fmt.Sprintf("%s:%d", svc, port)
+}
+
+// GetMirroredService returns the MeshService by which other meshes address the given service of the given cluster.
+func GetMirroredService(svc service.MeshService, clusterName string) service.MeshService {
+	return service.MeshService{
+		Namespace: svc.Namespace,
+		Name:      GetMirroredServiceName(svc.Name, clusterName),
+	}
+}
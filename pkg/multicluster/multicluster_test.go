@@ -0,0 +1,64 @@
+package multicluster
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+var _ = Describe("Test multicluster gateway helpers", func() {
+	const osmNamespace = "osm-system"
+
+	Context("Test GetGatewayService()", func() {
+		It("returns the gateway service in the OSM namespace", func() {
+			Expect(GetGatewayService(osmNamespace)).To(Equal(service.MeshService{
+				Namespace: osmNamespace,
+				Name:      GatewayName,
+			}))
+		})
+	})
+
+	Context("Test IsGatewayProxy()", func() {
+		It("correctly identifies the gateway proxy", func() {
+			Expect(GetGatewayCommonName(osmNamespace)).To(Equal(certificate.CommonName("multicluster-gateway.osm-multicluster-gateway.osm-system")))
+			Expect(IsGatewayProxy(GetGatewayCommonName(osmNamespace), osmNamespace)).To(BeTrue())
+			Expect(IsGatewayProxy(GetGatewayCommonName("other"), osmNamespace)).To(BeFalse())
+			Expect(IsGatewayProxy("9a5ef73e-4fcb-4b26-a3c5-6a5bf5b8e3a6.bookstore.default", osmNamespace)).To(BeFalse())
+		})
+	})
+
+	Context("Test IsExported()", func() {
+		It("returns true only when the export annotation is set to true", func() {
+			newService := func(annotations map[string]string) *corev1.Service {
+				return &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "bookstore",
+						Namespace:   "default",
+						Annotations: annotations,
+					},
+				}
+			}
+
+			Expect(IsExported(nil)).To(BeFalse())
+			Expect(IsExported(newService(nil))).To(BeFalse())
+			Expect(IsExported(newService(map[string]string{ExportAnnotation: "false"}))).To(BeFalse())
+			Expect(IsExported(newService(map[string]string{ExportAnnotation: "invalid"}))).To(BeFalse())
+			Expect(IsExported(newService(map[string]string{ExportAnnotation: "true"}))).To(BeTrue())
+		})
+	})
+
+	Context("Test GetMirroredService()", func() {
+		It("suffixes the service name with the cluster name", func() {
+			svc := service.MeshService{Namespace: "default", Name: "bookstore"}
+			Expect(GetMirroredService(svc, "east")).To(Equal(service.MeshService{
+				Namespace: "default",
+				Name:      "bookstore-east",
+			}))
+		})
+	})
+})
@@ -0,0 +1,30 @@
+package multicluster
+
+import (
+	"net"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/namespace"
+)
+
+var (
+	log = logger.New("multicluster")
+
+	// lookupHost resolves the hostname of a remote gateway's load balancer
+	lookupHost = net.LookupHost
+)
+
+// Mirror creates local services and endpoints for the services exported by a remote cluster.
+// Traffic to a mirrored service is sent to the multicluster gateway of the remote cluster.
+type Mirror struct {
+	remoteClusterName   string
+	remoteOSMNamespace  string
+	localKubeClient     kubernetes.Interface
+	namespaceController namespace.Controller
+	watchedNamespaces   []string
+	informer            cache.SharedIndexInformer
+	gatewayInformer     cache.SharedIndexInformer
+}
@@ -1,14 +1,20 @@
 package namespace
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 )
@@ -28,7 +34,8 @@ func (c Client) GetAnnouncementsChannel() <-chan interface{} {
 }
 
 // NewNamespaceController implements namespace.Controller and creates the Kubernetes client to manage namespaces.
-func NewNamespaceController(kubeClient kubernetes.Interface, meshName string, stop chan struct{}) Controller {
+// The controller claims the namespaces it monitors on behalf of the OSM controller running in osmNamespace.
+func NewNamespaceController(kubeClient kubernetes.Interface, meshName, osmNamespace string, stop chan struct{}) Controller {
 	// Only monitor namespaces that are labeled with this OSM's mesh name
 	monitorNamespaceLabel := map[string]string{MonitorLabel: meshName}
 	labelSelector := fields.SelectorFromSet(monitorNamespaceLabel).String()
@@ -38,7 +45,14 @@ func NewNamespaceController(kubeClient kubernetes.Interface, meshName string, st
 	informerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod, option)
 	informer := informerFactory.Core().V1().Namespaces().Informer()
 
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+
 	client := Client{
+		kubeClient:    kubeClient,
+		owner:         GetOwner(meshName, osmNamespace),
+		ownerChecker:  NewOwnerChecker(kubeClient),
+		recorder:      eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "osm-controller"}),
 		informer:      informer,
 		cache:         informer.GetStore(),
 		cacheSynced:   make(chan interface{}),
@@ -50,6 +64,14 @@ func NewNamespaceController(kubeClient kubernetes.Interface, meshName string, st
 	}
 
 	informer.AddEventHandler(k8s.GetKubernetesEventHandlers("Namespace", "NamespaceClient", client.announcements, nil))
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			client.claim(nil, obj.(*corev1.Namespace))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			client.claim(oldObj.(*corev1.Namespace), newObj.(*corev1.Namespace))
+		},
+	})
 
 	log.Info().Msgf("Monitoring namespaces with the label: %s=%s", MonitorLabel, meshName)
 	return client
@@ -77,9 +99,14 @@ func (c *Client) run(stop <-chan struct{}) error {
 }
 
 // IsMonitoredNamespace returns a boolean indicating if the namespace is among the list of monitored namespaces
+// Namespaces claimed by another OSM controller of the same mesh are not monitored.
 func (c Client) IsMonitoredNamespace(namespace string) bool {
-	_, exists, _ := c.cache.GetByKey(namespace)
-	return exists
+	obj, exists, _ := c.cache.GetByKey(namespace)
+	if !exists {
+		return false
+	}
+	ns, ok := obj.(*corev1.Namespace)
+	return ok && !IsOwnedByOther(ns, c.owner)
 }
 
 // ListMonitoredNamespaces returns all namespaces that the mesh is monitoring.
@@ -92,7 +119,37 @@ func (c Client) ListMonitoredNamespaces() ([]string, error) {
 			log.Error().Err(errListingNamespaces).Msg("Failed to list monitored namespaces")
 			continue
 		}
+		if IsOwnedByOther(namespace, c.owner) {
+			continue
+		}
 		namespaces = append(namespaces, namespace.Name)
 	}
 	return namespaces, nil
 }
+
+// claim sets the OwnerAnnotation of the given namespace to this controller, unless the namespace is already
+// claimed by another installed OSM controller of the same mesh, in which case a warning event is recorded when
+// the namespace is first seen or changes hands, given its previous state, nil when the namespace was added.
+// The claim of an OSM controller which was uninstalled is replaced; claims are retried on every resync.
+func (c Client) claim(oldNs, ns *corev1.Namespace) {
+	if IsOwnedByOther(ns, c.owner) && c.ownerChecker(ns.Annotations[OwnerAnnotation]) {
+		if oldNs != nil && getLiveOwner(oldNs) == getLiveOwner(ns) {
+			return
+		}
+		msg := fmt.Sprintf("Namespace is monitored by mesh %s but owned by the OSM controller %s; ignoring it in the OSM controller %s",
+			ns.Labels[MonitorLabel], ns.Annotations[OwnerAnnotation], c.owner)
+		log.Error().Msgf("%s: %s", ns.Name, msg)
+		c.recorder.Event(ns, corev1.EventTypeWarning, OwnershipConflictReason, msg)
+		return
+	}
+	if ns.Annotations[OwnerAnnotation] == c.owner {
+		return
+	}
+
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{"%s":"%s"}}}`, OwnerAnnotation, c.owner)
+	if _, err := c.kubeClient.CoreV1().Namespaces().Patch(context.Background(), ns.Name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{}); err != nil {
+		log.Error().Err(err).Msgf("Error claiming namespace %s for the OSM controller %s", ns.Name, c.owner)
+		return
+	}
+	log.Info().Msgf("Claimed namespace %s for the OSM controller %s", ns.Name, c.owner)
+}
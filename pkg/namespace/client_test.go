@@ -0,0 +1,64 @@
+package namespace
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("Test namespace claims", func() {
+	owner := GetOwner("osm", "osm-system")
+	otherOwner := GetOwner("osm", "other-system")
+
+	var (
+		kubeClient *fake.Clientset
+		recorder   *record.FakeRecorder
+		client     Client
+	)
+
+	BeforeEach(func() {
+		kubeClient = fake.NewSimpleClientset()
+		recorder = record.NewFakeRecorder(10)
+		client = Client{
+			kubeClient:   kubeClient,
+			owner:        owner,
+			ownerChecker: func(string) bool { return true },
+			recorder:     recorder,
+		}
+	})
+
+	Context("Test claim()", func() {
+		It("claims an unclaimed namespace", func() {
+			ns := newNamespace("osm", "")
+			_, err := kubeClient.CoreV1().Namespaces().Create(context.Background(), ns, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			client.claim(nil, ns)
+
+			claimed, err := kubeClient.CoreV1().Namespaces().Get(context.Background(), ns.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed.Annotations[OwnerAnnotation]).To(Equal(owner))
+		})
+
+		It("records a warning when a namespace owned by another controller is added", func() {
+			client.claim(nil, newNamespace("osm", otherOwner))
+			Expect(recorder.Events).To(HaveLen(1))
+		})
+
+		It("records a warning when a namespace is claimed by another controller", func() {
+			client.claim(newNamespace("osm", ""), newNamespace("osm", otherOwner))
+			Expect(recorder.Events).To(HaveLen(1))
+		})
+
+		It("does not record a warning again when a namespace owned by another controller is resynced", func() {
+			ns := newNamespace("osm", otherOwner)
+			client.claim(ns, ns)
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+})
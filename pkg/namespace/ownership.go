@@ -0,0 +1,105 @@
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const (
+	// OwnerAnnotation is the annotation set by the OSM controller which claimed a namespace monitored by its mesh.
+	// The value has the form <osm-namespace>/<mesh-name>.
+	// Example: openservicemesh.io/owned-by: "osm-system/osm"
+	OwnerAnnotation = "openservicemesh.io/owned-by"
+
+	// OwnershipConflictReason is the reason of the event recorded on a namespace claimed by another OSM controller
+	OwnershipConflictReason = "NamespaceOwnershipConflict"
+
+	// controllerMeshNameLabel is the label of the osm-controller Deployment holding the name of its mesh
+	controllerMeshNameLabel = "meshName"
+)
+
+// OwnerChecker returns whether the OSM controller of the given OwnerAnnotation value is still installed
+type OwnerChecker func(owner string) bool
+
+// GetOwner returns the value of the OwnerAnnotation for the OSM controller with the given mesh name running in the given namespace.
+func GetOwner(meshName, osmNamespace string) string {
+	return fmt.Sprintf("%s/%s", osmNamespace, meshName)
+}
+
+// parseOwner returns the OSM namespace and the mesh name of the given OwnerAnnotation value.
+func parseOwner(owner string) (string, string) {
+	chunks := strings.SplitN(owner, "/", 2)
+	if len(chunks) != 2 {
+		return "", ""
+	}
+	return chunks[0], chunks[1]
+}
+
+// getOwnerMeshName returns the mesh name of the given OwnerAnnotation value.
+func getOwnerMeshName(owner string) string {
+	_, meshName := parseOwner(owner)
+	return meshName
+}
+
+// NewOwnerChecker returns an OwnerChecker looking up the osm-controller Deployment of the mesh of an owner in its
+// OSM namespace. A claim made by an OSM controller which was uninstalled is stale and may be replaced.
+// Errors other than the Deployment not being found leave the owner installed, so that a live claim is never replaced.
+func NewOwnerChecker(kubeClient kubernetes.Interface) OwnerChecker {
+	return func(owner string) bool {
+		osmNamespace, meshName := parseOwner(owner)
+		if osmNamespace == "" {
+			return false
+		}
+		deployment, err := kubeClient.AppsV1().Deployments(osmNamespace).Get(context.Background(), constants.OSMControllerName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting the %s Deployment of the OSM controller %s, assuming it is installed", constants.OSMControllerName, owner)
+			return true
+		}
+		return deployment.Labels[controllerMeshNameLabel] == meshName
+	}
+}
+
+// getLiveOwner returns the owner of the given namespace. A claim made for a mesh other than the one
+// the namespace is currently monitored by is stale, and the namespace has no owner.
+func getLiveOwner(ns *corev1.Namespace) string {
+	owner := ns.Annotations[OwnerAnnotation]
+	if owner == "" || getOwnerMeshName(owner) != ns.Labels[MonitorLabel] {
+		return ""
+	}
+	return owner
+}
+
+// IsOwnedByOther returns true if the given namespace is claimed by an OSM controller other than the given owner.
+// This happens when OSM controllers of two meshes with the same name monitor the same namespace.
+func IsOwnedByOther(ns *corev1.Namespace, owner string) bool {
+	liveOwner := getLiveOwner(ns)
+	return liveOwner != "" && liveOwner != owner
+}
+
+// ValidateOwnershipChange returns an error if the given namespace update moves a namespace claimed by one mesh
+// to another mesh, or hands a live claim over to another OSM controller while the owner is still installed.
+// A namespace must be removed from its mesh before it can be added to another one.
+func ValidateOwnershipChange(oldNs, newNs *corev1.Namespace, isOwnerInstalled OwnerChecker) error {
+	oldMesh, newMesh := oldNs.Labels[MonitorLabel], newNs.Labels[MonitorLabel]
+	if oldMesh != "" && newMesh != "" && oldMesh != newMesh {
+		return errors.Errorf("Namespace %s is monitored by mesh %s and cannot be added to mesh %s; remove the %s label first", newNs.Name, oldMesh, newMesh, MonitorLabel)
+	}
+
+	oldOwner, newOwner := getLiveOwner(oldNs), newNs.Annotations[OwnerAnnotation]
+	if oldOwner != "" && newOwner != "" && oldOwner != newOwner && oldMesh == newMesh && isOwnerInstalled(oldOwner) {
+		return errors.Errorf("Namespace %s is owned by the OSM controller %s and cannot be claimed by %s", newNs.Name, oldOwner, newOwner)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package namespace
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func newNamespace(meshName, owner string) *corev1.Namespace {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "bookstore",
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+	}
+	if meshName != "" {
+		ns.Labels[MonitorLabel] = meshName
+	}
+	if owner != "" {
+		ns.Annotations[OwnerAnnotation] = owner
+	}
+	return ns
+}
+
+var _ = Describe("Test namespace ownership", func() {
+	owner := GetOwner("osm", "osm-system")
+	otherOwner := GetOwner("osm", "other-system")
+	installed := func(string) bool { return true }
+	uninstalled := func(string) bool { return false }
+
+	Context("Test GetOwner()", func() {
+		It("returns the owner in the <osm-namespace>/<mesh-name> form", func() {
+			Expect(owner).To(Equal("osm-system/osm"))
+		})
+	})
+
+	Context("Test IsOwnedByOther()", func() {
+		It("returns false for an unclaimed namespace", func() {
+			Expect(IsOwnedByOther(newNamespace("osm", ""), owner)).To(BeFalse())
+		})
+
+		It("returns false for a namespace claimed by the same controller", func() {
+			Expect(IsOwnedByOther(newNamespace("osm", owner), owner)).To(BeFalse())
+		})
+
+		It("returns true for a namespace claimed by another controller of the same mesh", func() {
+			Expect(IsOwnedByOther(newNamespace("osm", otherOwner), owner)).To(BeTrue())
+		})
+
+		It("returns false for a stale claim made for another mesh", func() {
+			Expect(IsOwnedByOther(newNamespace("osm", GetOwner("other", "other-system")), owner)).To(BeFalse())
+		})
+
+		It("returns false for a malformed claim", func() {
+			Expect(IsOwnedByOther(newNamespace("osm", "osm"), owner)).To(BeFalse())
+		})
+	})
+
+	Context("Test ValidateOwnershipChange()", func() {
+		It("allows adding a namespace to a mesh", func() {
+			Expect(ValidateOwnershipChange(newNamespace("", ""), newNamespace("osm", ""), installed)).To(Succeed())
+		})
+
+		It("allows removing a namespace from a mesh", func() {
+			Expect(ValidateOwnershipChange(newNamespace("osm", owner), newNamespace("", owner), installed)).To(Succeed())
+		})
+
+		It("denies moving a namespace to another mesh", func() {
+			Expect(ValidateOwnershipChange(newNamespace("osm", ""), newNamespace("other", ""), installed)).ToNot(Succeed())
+		})
+
+		It("allows claiming an unclaimed namespace", func() {
+			Expect(ValidateOwnershipChange(newNamespace("osm", ""), newNamespace("osm", owner), installed)).To(Succeed())
+		})
+
+		It("allows replacing a stale claim", func() {
+			Expect(ValidateOwnershipChange(newNamespace("osm", GetOwner("other", "other-system")), newNamespace("osm", owner), installed)).To(Succeed())
+		})
+
+		It("denies taking over a live claim", func() {
+			Expect(ValidateOwnershipChange(newNamespace("osm", otherOwner), newNamespace("osm", owner), installed)).ToNot(Succeed())
+		})
+
+		It("allows replacing the claim of an uninstalled controller", func() {
+			Expect(ValidateOwnershipChange(newNamespace("osm", otherOwner), newNamespace("osm", owner), uninstalled)).To(Succeed())
+		})
+
+		It("allows releasing a claim", func() {
+			Expect(ValidateOwnershipChange(newNamespace("osm", owner), newNamespace("osm", ""), installed)).To(Succeed())
+		})
+	})
+	Context("Test NewOwnerChecker()", func() {
+		newDeployment := func(osmNamespace, meshName string) *appsv1.Deployment {
+			return &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.OSMControllerName,
+					Namespace: osmNamespace,
+					Labels:    map[string]string{controllerMeshNameLabel: meshName},
+				},
+			}
+		}
+
+		It("returns true when the osm-controller Deployment of the mesh is installed", func() {
+			isOwnerInstalled := NewOwnerChecker(fake.NewSimpleClientset(newDeployment("other-system", "osm")))
+			Expect(isOwnerInstalled(otherOwner)).To(BeTrue())
+		})
+
+		It("returns false when the osm-controller Deployment is gone", func() {
+			isOwnerInstalled := NewOwnerChecker(fake.NewSimpleClientset(newDeployment("osm-system", "osm")))
+			Expect(isOwnerInstalled(otherOwner)).To(BeFalse())
+		})
+
+		It("returns false when the osm-controller Deployment belongs to another mesh", func() {
+			isOwnerInstalled := NewOwnerChecker(fake.NewSimpleClientset(newDeployment("other-system", "other")))
+			Expect(isOwnerInstalled(otherOwner)).To(BeFalse())
+		})
+
+		It("returns false for a malformed claim", func() {
+			isOwnerInstalled := NewOwnerChecker(fake.NewSimpleClientset())
+			Expect(isOwnerInstalled("osm")).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,40 @@
+package namespace
+
+// NewStaticNamespaceController returns a namespace.Controller monitoring the given namespaces.
+// The namespaces are neither watched nor claimed, which requires no permission on cluster-scoped namespace resources.
+func NewStaticNamespaceController(namespaces []string) Controller {
+	monitoredNamespaces := make(map[string]struct{})
+	for _, ns := range namespaces {
+		monitoredNamespaces[ns] = struct{}{}
+	}
+	log.Info().Msgf("Monitoring namespaces %v", namespaces)
+	return staticController{
+		namespaces:          namespaces,
+		monitoredNamespaces: monitoredNamespaces,
+		announcements:       make(chan interface{}),
+	}
+}
+
+// staticController is a namespace.Controller monitoring a fixed list of namespaces
+type staticController struct {
+	namespaces          []string
+	monitoredNamespaces map[string]struct{}
+	announcements       chan interface{}
+}
+
+// IsMonitoredNamespace returns whether the namespace is among the namespaces of the controller
+func (c staticController) IsMonitoredNamespace(namespace string) bool {
+	_, ok := c.monitoredNamespaces[namespace]
+	return ok
+}
+
+// ListMonitoredNamespaces returns the namespaces of the controller
+func (c staticController) ListMonitoredNamespaces() ([]string, error) {
+	return append([]string(nil), c.namespaces...), nil
+}
+
+// GetAnnouncementsChannel returns the channel on which namespace makes announcements, on which nothing is
+// announced as the monitored namespaces do not change
+func (c staticController) GetAnnouncementsChannel() <-chan interface{} {
+	return c.announcements
+}
@@ -0,0 +1,20 @@
+package namespace
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test static namespace controller", func() {
+	Context("Test NewStaticNamespaceController()", func() {
+		It("monitors the given namespaces only", func() {
+			c := NewStaticNamespaceController([]string{"bookstore", "bookbuyer"})
+			Expect(c.IsMonitoredNamespace("bookstore")).To(BeTrue())
+			Expect(c.IsMonitoredNamespace("bookthief")).To(BeFalse())
+
+			namespaces, err := c.ListMonitoredNamespaces()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(namespaces).To(Equal([]string{"bookstore", "bookbuyer"}))
+		})
+	})
+})
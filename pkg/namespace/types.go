@@ -1,7 +1,9 @@
 package namespace
 
 import (
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/openservicemesh/osm/pkg/logger"
 )
@@ -12,6 +14,10 @@ var (
 
 // Client is a struct for all components necessary to connect to and maintain state of a Kubernetes cluster.
 type Client struct {
+	kubeClient    kubernetes.Interface
+	owner         string
+	ownerChecker  OwnerChecker
+	recorder      record.EventRecorder
 	informer      cache.SharedIndexInformer
 	cache         cache.Store
 	cacheSynced   chan interface{}
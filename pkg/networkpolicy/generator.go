@@ -0,0 +1,360 @@
+package networkpolicy
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// ManagedByLabel is the label set on the NetworkPolicies managed by the generator
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+
+	// MeshNameLabel is the label set on the generated NetworkPolicies to the name of the mesh they belong to.
+	// Example: kubectl delete networkpolicies --all-namespaces -l openservicemesh.io/mesh-name=osm
+	MeshNameLabel = "openservicemesh.io/mesh-name"
+
+	// namespaceNameLabel is the label Kubernetes sets on every namespace to its name
+	namespaceNameLabel = "kubernetes.io/metadata.name"
+
+	// networkPolicyNamePrefix prefixes the name of the service a NetworkPolicy is generated for
+	networkPolicyNamePrefix = "osm-"
+
+	// reconcileDelay is how long the changes announced by the catalog, or made by others to the generated
+	// NetworkPolicies, are accumulated before the NetworkPolicies are generated again
+	reconcileDelay = 10 * time.Second
+)
+
+// NewGenerator creates a generator of the NetworkPolicies of the mesh with the given name.
+// When watchedNamespaces is not empty, NetworkPolicies are only managed in these namespaces.
+func NewGenerator(kubeClient kubernetes.Interface, meshCatalog catalog.MeshCataloger, cfg configurator.Configurator, meshName string, watchedNamespaces []string) *Generator {
+	g := &Generator{
+		kubeClient:        kubeClient,
+		meshCatalog:       meshCatalog,
+		cfg:               cfg,
+		meshName:          meshName,
+		watchedNamespaces: watchedNamespaces,
+		changes:           make(chan struct{}, 1),
+		applied:           make(map[string]networkingv1.NetworkPolicySpec),
+	}
+
+	// Only the NetworkPolicies of the mesh are cached
+	selector := labels.SelectorFromSet(g.getLabels()).String()
+	g.informer = k8s.NewInformer(watchedNamespaces, func(ns string) cache.SharedIndexInformer {
+		return informers.NewSharedInformerFactoryWithOptions(kubeClient, k8s.DefaultKubeEventResyncInterval,
+			informers.WithNamespace(ns),
+			informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+				options.LabelSelector = selector
+			}),
+		).Networking().V1().NetworkPolicies().Informer()
+	})
+	return g
+}
+
+// Start generates the NetworkPolicies from the SMI policies, and applies them to the cluster when the catalog
+// announces a change, at most once every reconcileDelay. The changes made by others to the generated NetworkPolicies
+// are reverted likewise. The NetworkPolicies are also regenerated every resyncInterval.
+func (g *Generator) Start(resyncInterval time.Duration, stop <-chan struct{}) {
+	announcements := g.meshCatalog.SubscribeAnnouncements()
+	go g.informer.Run(stop)
+
+	go func() {
+		if !cache.WaitForCacheSync(stop, g.informer.HasSynced) {
+			log.Error().Msg("Failed initial cache sync for NetworkPolicy informer")
+			return
+		}
+		g.reconcileAndLog()
+
+		// The handlers are added once the NetworkPolicies are applied, so that the existing ones are not seen as changed
+		g.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				if policy, ok := obj.(*networkingv1.NetworkPolicy); ok && !g.isApplied(policy) {
+					g.notifyChange()
+				}
+			},
+			UpdateFunc: func(_, newObj interface{}) {
+				if policy, ok := newObj.(*networkingv1.NetworkPolicy); ok && !g.isApplied(policy) {
+					g.notifyChange()
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				if policy, ok := obj.(*networkingv1.NetworkPolicy); ok && g.isDesired(policy) {
+					g.notifyChange()
+				}
+			},
+		})
+
+		ticker := time.NewTicker(resyncInterval)
+		defer ticker.Stop()
+		var delayed <-chan time.Time
+		for {
+			select {
+			case <-stop:
+				return
+			case <-announcements:
+				if delayed == nil {
+					delayed = time.After(reconcileDelay)
+				}
+			case <-g.changes:
+				if delayed == nil {
+					delayed = time.After(reconcileDelay)
+				}
+			case <-delayed:
+				delayed = nil
+				g.reconcileAndLog()
+			case <-ticker.C:
+				delayed = nil
+				g.reconcileAndLog()
+			}
+		}
+	}()
+}
+
+// notifyChange signals that a generated NetworkPolicy was changed by others, without blocking the informer
+func (g *Generator) notifyChange() {
+	select {
+	case g.changes <- struct{}{}:
+	default:
+	}
+}
+
+// isApplied returns true if the given NetworkPolicy is as last applied by the generator
+func (g *Generator) isApplied(policy *networkingv1.NetworkPolicy) bool {
+	g.appliedMutex.Lock()
+	defer g.appliedMutex.Unlock()
+	spec, ok := g.applied[policy.Namespace+"/"+policy.Name]
+	return ok && reflect.DeepEqual(spec, policy.Spec)
+}
+
+// isDesired returns true if the given NetworkPolicy was last applied by the generator
+func (g *Generator) isDesired(policy *networkingv1.NetworkPolicy) bool {
+	g.appliedMutex.Lock()
+	defer g.appliedMutex.Unlock()
+	_, ok := g.applied[policy.Namespace+"/"+policy.Name]
+	return ok
+}
+
+func (g *Generator) reconcileAndLog() {
+	if err := g.reconcile(); err != nil {
+		log.Error().Err(err).Msg("Error generating NetworkPolicies")
+	}
+}
+
+// reconcile creates, updates and deletes the NetworkPolicies of the mesh so they match the SMI policies
+func (g *Generator) reconcile() error {
+	desired, err := g.getNetworkPolicies()
+	if err != nil {
+		return err
+	}
+	return g.apply(desired)
+}
+
+// getNetworkPolicies returns the NetworkPolicies allowing the traffic the SMI policies allow.
+// A NetworkPolicy is generated for each service selecting pods, including the services without allowed inbound
+// services, whose pods are only reachable by Prometheus.
+func (g *Generator) getNetworkPolicies() ([]*networkingv1.NetworkPolicy, error) {
+	// In permissive traffic policy mode every service can reach every other one
+	if g.cfg.IsPermissiveTrafficPolicyMode() {
+		return nil, nil
+	}
+
+	meshSpec := g.meshCatalog.GetSMISpec()
+	services, err := meshSpec.ListServices()
+	if err != nil {
+		log.Error().Err(err).Msg("Error listing services")
+		return nil, err
+	}
+
+	var policies []*networkingv1.NetworkPolicy
+	for _, svc := range services {
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+
+		meshSvc := service.MeshService{Namespace: svc.Namespace, Name: svc.Name}
+		allowedServices, err := g.meshCatalog.ListAllowedInboundServices(meshSvc)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error listing allowed inbound services of service %s", meshSvc)
+			return nil, err
+		}
+
+		var sources []*corev1.Service
+		for _, allowedSvc := range allowedServices {
+			source, err := meshSpec.GetService(allowedSvc)
+			if err != nil || source == nil || len(source.Spec.Selector) == 0 {
+				log.Debug().Msgf("Allowed inbound service %s of service %s selects no pods", allowedSvc, meshSvc)
+				continue
+			}
+			sources = append(sources, source)
+		}
+
+		policies = append(policies, g.newNetworkPolicy(svc, sources))
+	}
+	return policies, nil
+}
+
+// newNetworkPolicy returns the NetworkPolicy allowing the pods of the sources to the ports of the pods of the service.
+// The Prometheus port of the sidecars stays reachable for metrics to be scraped.
+func (g *Generator) newNetworkPolicy(svc *corev1.Service, sources []*corev1.Service) *networkingv1.NetworkPolicy {
+	ingress := []networkingv1.NetworkPolicyIngressRule{
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				newNetworkPolicyPort(corev1.ProtocolTCP, intstr.FromInt(constants.EnvoyPrometheusInboundListenerPort)),
+			},
+		},
+	}
+
+	// A rule without peers would allow the ports of the service from anywhere
+	if len(sources) > 0 {
+		ingress = append([]networkingv1.NetworkPolicyIngressRule{getSourcesIngressRule(svc, sources)}, ingress...)
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      networkPolicyNamePrefix + svc.Name,
+			Namespace: svc.Namespace,
+			Labels:    g.getLabels(),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: svc.Spec.Selector,
+			},
+			Ingress:     ingress,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+}
+
+// getSourcesIngressRule returns the rule allowing the pods of the sources to the target ports of the service
+func getSourcesIngressRule(svc *corev1.Service, sources []*corev1.Service) networkingv1.NetworkPolicyIngressRule {
+	sort.Slice(sources, func(i, j int) bool {
+		if sources[i].Namespace != sources[j].Namespace {
+			return sources[i].Namespace < sources[j].Namespace
+		}
+		return sources[i].Name < sources[j].Name
+	})
+
+	var peers []networkingv1.NetworkPolicyPeer
+	for _, source := range sources {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{namespaceNameLabel: source.Namespace},
+			},
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: source.Spec.Selector,
+			},
+		})
+	}
+
+	var ports []networkingv1.NetworkPolicyPort
+	for _, svcPort := range svc.Spec.Ports {
+		ports = append(ports, newNetworkPolicyPort(svcPort.Protocol, getTargetPort(svcPort)))
+	}
+
+	return networkingv1.NetworkPolicyIngressRule{
+		Ports: ports,
+		From:  peers,
+	}
+}
+
+// apply creates or updates the desired NetworkPolicies, and deletes the other NetworkPolicies of the mesh.
+// The existing NetworkPolicies are read from the informer cache.
+func (g *Generator) apply(desired []*networkingv1.NetworkPolicy) error {
+	existingPolicies := make(map[string]networkingv1.NetworkPolicy)
+	for _, obj := range g.informer.GetStore().List() {
+		policy := obj.(*networkingv1.NetworkPolicy)
+		existingPolicies[policy.Namespace+"/"+policy.Name] = *policy.DeepCopy()
+	}
+
+	// The desired NetworkPolicies are recorded before they are applied, for the informer events of the writes below
+	// not to be seen as changes made by others
+	applied := make(map[string]networkingv1.NetworkPolicySpec)
+	for _, policy := range desired {
+		applied[policy.Namespace+"/"+policy.Name] = policy.Spec
+	}
+	g.appliedMutex.Lock()
+	g.applied = applied
+	g.appliedMutex.Unlock()
+
+	var applyErr error
+	for _, policy := range desired {
+		key := policy.Namespace + "/" + policy.Name
+		existingPolicy, exists := existingPolicies[key]
+		delete(existingPolicies, key)
+
+		networkPolicies := g.kubeClient.NetworkingV1().NetworkPolicies(policy.Namespace)
+		switch {
+		case !exists:
+			if _, err := networkPolicies.Create(context.Background(), policy, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+				log.Error().Err(err).Msgf("Error creating NetworkPolicy %s", key)
+				applyErr = err
+				continue
+			}
+			log.Info().Msgf("Created NetworkPolicy %s", key)
+		case !reflect.DeepEqual(existingPolicy.Spec, policy.Spec):
+			existingPolicy.Spec = policy.Spec
+			if _, err := networkPolicies.Update(context.Background(), &existingPolicy, metav1.UpdateOptions{}); err != nil {
+				log.Error().Err(err).Msgf("Error updating NetworkPolicy %s", key)
+				applyErr = err
+				continue
+			}
+			log.Info().Msgf("Updated NetworkPolicy %s", key)
+		}
+	}
+
+	for key, policy := range existingPolicies {
+		if err := g.kubeClient.NetworkingV1().NetworkPolicies(policy.Namespace).Delete(context.Background(), policy.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			log.Error().Err(err).Msgf("Error deleting NetworkPolicy %s", key)
+			applyErr = err
+			continue
+		}
+		log.Info().Msgf("Deleted NetworkPolicy %s", key)
+	}
+	return applyErr
+}
+
+func (g *Generator) getLabels() map[string]string {
+	return map[string]string{
+		ManagedByLabel: constants.OSMControllerName,
+		MeshNameLabel:  g.meshName,
+	}
+}
+
+// getTargetPort returns the port of the pods the given service port is forwarded to
+func getTargetPort(svcPort corev1.ServicePort) intstr.IntOrString {
+	if svcPort.TargetPort.Type == intstr.String || svcPort.TargetPort.IntValue() != 0 {
+		return svcPort.TargetPort
+	}
+	return intstr.FromInt(int(svcPort.Port))
+}
+
+func newNetworkPolicyPort(protocol corev1.Protocol, port intstr.IntOrString) networkingv1.NetworkPolicyPort {
+	// The protocol is defaulted by the API server, which would otherwise make the policy differ from the generated one
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+	return networkingv1.NetworkPolicyPort{
+		Protocol: &protocol,
+		Port:     &port,
+	}
+}
@@ -0,0 +1,179 @@
+package networkpolicy
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+var _ = Describe("Test NetworkPolicy generation", func() {
+	const meshName = "osm"
+
+	newService := func(ns, name string, targetPort intstr.IntOrString) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+			},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": name},
+				Ports: []corev1.ServicePort{{
+					Name:       "http",
+					Port:       80,
+					TargetPort: targetPort,
+				}},
+			},
+		}
+	}
+
+	var (
+		kubeClient *fake.Clientset
+		g          *Generator
+		stop       chan struct{}
+	)
+
+	BeforeEach(func() {
+		kubeClient = fake.NewSimpleClientset()
+		g = NewGenerator(kubeClient, nil, configurator.NewFakeConfigurator(), meshName, nil)
+		stop = make(chan struct{})
+		go g.informer.Run(stop)
+		Expect(cache.WaitForCacheSync(stop, g.informer.HasSynced)).To(BeTrue())
+	})
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	// waitForCache waits for the informer to cache the given number of NetworkPolicies of the mesh
+	waitForCache := func(count int) {
+		Eventually(func() int { return len(g.informer.GetStore().List()) }).Should(Equal(count))
+	}
+
+	listPolicies := func() []networkingv1.NetworkPolicy {
+		policies, err := kubeClient.NetworkingV1().NetworkPolicies(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return policies.Items
+	}
+
+	Context("Test newNetworkPolicy()", func() {
+		It("allows the pods of the sources to the target ports of the pods of the service", func() {
+			bookstore := newService("bookstore", "bookstore", intstr.FromInt(8080))
+			sources := []*corev1.Service{
+				newService("bookthief", "bookthief", intstr.IntOrString{}),
+				newService("bookbuyer", "bookbuyer", intstr.IntOrString{}),
+			}
+
+			policy := g.newNetworkPolicy(bookstore, sources)
+			Expect(policy.Name).To(Equal("osm-bookstore"))
+			Expect(policy.Namespace).To(Equal("bookstore"))
+			Expect(policy.Labels).To(Equal(map[string]string{
+				ManagedByLabel: constants.OSMControllerName,
+				MeshNameLabel:  meshName,
+			}))
+			Expect(policy.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{"app": "bookstore"}))
+			Expect(policy.Spec.PolicyTypes).To(Equal([]networkingv1.PolicyType{networkingv1.PolicyTypeIngress}))
+
+			Expect(len(policy.Spec.Ingress)).To(Equal(2))
+			Expect(len(policy.Spec.Ingress[0].Ports)).To(Equal(1))
+			Expect(*policy.Spec.Ingress[0].Ports[0].Port).To(Equal(intstr.FromInt(8080)))
+			Expect(*policy.Spec.Ingress[0].Ports[0].Protocol).To(Equal(corev1.ProtocolTCP))
+
+			// Peers are sorted by namespace and name
+			Expect(len(policy.Spec.Ingress[0].From)).To(Equal(2))
+			Expect(policy.Spec.Ingress[0].From[0].NamespaceSelector.MatchLabels).To(Equal(map[string]string{namespaceNameLabel: "bookbuyer"}))
+			Expect(policy.Spec.Ingress[0].From[0].PodSelector.MatchLabels).To(Equal(map[string]string{"app": "bookbuyer"}))
+			Expect(policy.Spec.Ingress[0].From[1].NamespaceSelector.MatchLabels).To(Equal(map[string]string{namespaceNameLabel: "bookthief"}))
+
+			// The Prometheus port of the sidecars is reachable from anywhere
+			Expect(policy.Spec.Ingress[1].From).To(BeEmpty())
+			Expect(*policy.Spec.Ingress[1].Ports[0].Port).To(Equal(intstr.FromInt(constants.EnvoyPrometheusInboundListenerPort)))
+		})
+
+		It("only allows Prometheus to the pods of a service without sources", func() {
+			policy := g.newNetworkPolicy(newService("bookstore", "bookstore", intstr.FromInt(8080)), nil)
+			Expect(policy.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{"app": "bookstore"}))
+			Expect(len(policy.Spec.Ingress)).To(Equal(1))
+			Expect(policy.Spec.Ingress[0].From).To(BeEmpty())
+			Expect(len(policy.Spec.Ingress[0].Ports)).To(Equal(1))
+			Expect(*policy.Spec.Ingress[0].Ports[0].Port).To(Equal(intstr.FromInt(constants.EnvoyPrometheusInboundListenerPort)))
+		})
+
+		It("uses the port of the service when it has no target port", func() {
+			policy := g.newNetworkPolicy(newService("bookstore", "bookstore", intstr.IntOrString{}), []*corev1.Service{newService("bookbuyer", "bookbuyer", intstr.IntOrString{})})
+			Expect(*policy.Spec.Ingress[0].Ports[0].Port).To(Equal(intstr.FromInt(80)))
+		})
+	})
+
+	Context("Test getNetworkPolicies()", func() {
+		It("generates no NetworkPolicies in permissive traffic policy mode", func() {
+			g.cfg = configurator.NewFakeConfiguratorWithOptions(configurator.FakeConfigurator{PermissiveTrafficPolicyMode: true})
+			policies, err := g.getNetworkPolicies()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policies).To(BeEmpty())
+		})
+	})
+
+	Context("Test apply()", func() {
+		bookbuyer := newService("bookbuyer", "bookbuyer", intstr.IntOrString{})
+
+		It("creates and updates the desired NetworkPolicies", func() {
+			Expect(g.apply([]*networkingv1.NetworkPolicy{g.newNetworkPolicy(newService("bookstore", "bookstore", intstr.FromInt(8080)), []*corev1.Service{bookbuyer})})).To(Succeed())
+			policies := listPolicies()
+			Expect(len(policies)).To(Equal(1))
+			Expect(*policies[0].Spec.Ingress[0].Ports[0].Port).To(Equal(intstr.FromInt(8080)))
+			waitForCache(1)
+
+			Expect(g.apply([]*networkingv1.NetworkPolicy{g.newNetworkPolicy(newService("bookstore", "bookstore", intstr.FromInt(9090)), []*corev1.Service{bookbuyer})})).To(Succeed())
+			policies = listPolicies()
+			Expect(len(policies)).To(Equal(1))
+			Expect(*policies[0].Spec.Ingress[0].Ports[0].Port).To(Equal(intstr.FromInt(9090)))
+		})
+
+		It("deletes the NetworkPolicies of the mesh which are no longer desired", func() {
+			unmanaged := &networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deny-all",
+					Namespace: "bookstore",
+				},
+			}
+			_, err := kubeClient.NetworkingV1().NetworkPolicies("bookstore").Create(context.Background(), unmanaged, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(g.apply([]*networkingv1.NetworkPolicy{g.newNetworkPolicy(newService("bookstore", "bookstore", intstr.FromInt(8080)), []*corev1.Service{bookbuyer})})).To(Succeed())
+			Expect(len(listPolicies())).To(Equal(2))
+			waitForCache(1)
+
+			Expect(g.apply(nil)).To(Succeed())
+			policies := listPolicies()
+			Expect(len(policies)).To(Equal(1))
+			Expect(policies[0].Name).To(Equal("deny-all"))
+		})
+	})
+
+	Context("Test isApplied()", func() {
+		It("tells the NetworkPolicies changed by others from the ones it applied", func() {
+			policy := g.newNetworkPolicy(newService("bookstore", "bookstore", intstr.FromInt(8080)), nil)
+			Expect(g.apply([]*networkingv1.NetworkPolicy{policy})).To(Succeed())
+			Expect(g.isApplied(policy)).To(BeTrue())
+			Expect(g.isDesired(policy)).To(BeTrue())
+
+			changed := policy.DeepCopy()
+			changed.Spec.Ingress = nil
+			Expect(g.isApplied(changed)).To(BeFalse())
+
+			Expect(g.apply(nil)).To(Succeed())
+			Expect(g.isDesired(policy)).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,39 @@
+package networkpolicy
+
+import (
+	"sync"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var (
+	log = logger.New("network-policy")
+)
+
+// Generator keeps Kubernetes NetworkPolicies in sync with the traffic allowed by the SMI policies of the mesh,
+// so that traffic the mesh denies is also dropped at L3/L4 by the network plugin of the cluster.
+type Generator struct {
+	kubeClient  kubernetes.Interface
+	meshCatalog catalog.MeshCataloger
+	cfg         configurator.Configurator
+	meshName    string
+
+	// watchedNamespaces are the namespaces the NetworkPolicies are managed in, all namespaces when empty
+	watchedNamespaces []string
+
+	// informer caches the NetworkPolicies of the mesh
+	informer cache.SharedIndexInformer
+
+	// changes receives a signal when a generated NetworkPolicy is changed by others
+	changes chan struct{}
+
+	// applied are the specs of the NetworkPolicies last applied, keyed by <namespace>/<name>
+	applied      map[string]networkingv1.NetworkPolicySpec
+	appliedMutex sync.Mutex
+}
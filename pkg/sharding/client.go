@@ -0,0 +1,103 @@
+package sharding
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+)
+
+// NewSharder implements sharding.Sharder. The replicas of the ring are the pods backing the ready endpoints
+// of the given service; self is the name of the pod this replica runs in.
+func NewSharder(kubeClient kubernetes.Interface, osmNamespace, serviceName, self string, stop <-chan struct{}) (Sharder, error) {
+	if self == "" {
+		return nil, errEmptyIdentity
+	}
+
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, k8s.DefaultKubeEventResyncInterval,
+		informers.WithNamespace(osmNamespace),
+		informers.WithTweakListOptions(func(opt *metav1.ListOptions) {
+			opt.FieldSelector = fields.OneTermEqualSelector("metadata.name", serviceName).String()
+		}))
+
+	c := &Client{
+		self:     self,
+		informer: informerFactory.Core().V1().Endpoints().Informer(),
+		ring:     newRing(nil),
+	}
+
+	c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.updateRing(obj)
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			c.updateRing(newObj)
+		},
+		DeleteFunc: func(_ interface{}) {
+			c.updateRing(nil)
+		},
+	})
+
+	go c.informer.Run(stop)
+	if !cache.WaitForCacheSync(stop, c.informer.HasSynced) {
+		return nil, errSyncingCaches
+	}
+
+	log.Info().Msgf("Sharding proxies across the replicas of service %s/%s as %s", osmNamespace, serviceName, self)
+	return c, nil
+}
+
+// IsOwner returns true if the proxy with the given key is served by this replica.
+// All proxies are served while this replica is not a member of its ring, such as before it becomes ready, or while
+// its view of the ready replicas is stale since the service only routes proxies to ready replicas. Serving a proxy
+// on a replica which does not own it is safe, whereas a proxy refused by every replica is never configured.
+func (c *Client) IsOwner(key string) bool {
+	c.ringMutex.RLock()
+	defer c.ringMutex.RUnlock()
+	if !c.ring.has(c.self) {
+		return true
+	}
+	return c.ring.get(key) == c.self
+}
+
+// updateRing rebuilds the ring from the given osm-controller endpoints
+func (c *Client) updateRing(obj interface{}) {
+	members := getReadyPodNames(obj)
+
+	c.ringMutex.Lock()
+	defer c.ringMutex.Unlock()
+	c.ring = newRing(members)
+	log.Info().Msgf("Updated sharding ring with replicas %v", members)
+}
+
+// getReadyPodNames returns the sorted names of the pods backing the ready addresses of the given endpoints
+func getReadyPodNames(obj interface{}) []string {
+	endpoints, ok := obj.(*corev1.Endpoints)
+	if !ok {
+		return nil
+	}
+
+	// A pod is listed in one subset per distinct set of ports
+	podNameSet := make(map[string]struct{})
+	for _, subset := range endpoints.Subsets {
+		for _, address := range subset.Addresses {
+			if address.TargetRef == nil || address.TargetRef.Kind != "Pod" {
+				continue
+			}
+			podNameSet[address.TargetRef.Name] = struct{}{}
+		}
+	}
+
+	var podNames []string
+	for podName := range podNameSet {
+		podNames = append(podNames, podName)
+	}
+	sort.Strings(podNames)
+	return podNames
+}
@@ -0,0 +1,104 @@
+package sharding
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Test sharder", func() {
+	const (
+		osmNamespace = "osm-system"
+		serviceName  = "osm-controller"
+	)
+
+	newEndpoints := func(podNames ...string) *corev1.Endpoints {
+		var addresses []corev1.EndpointAddress
+		for _, podName := range podNames {
+			addresses = append(addresses, corev1.EndpointAddress{
+				IP:        "10.0.0.1",
+				TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: podName},
+			})
+		}
+		return &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      serviceName,
+				Namespace: osmNamespace,
+			},
+			Subsets: []corev1.EndpointSubset{
+				{Addresses: addresses},
+				{Addresses: addresses},
+			},
+		}
+	}
+
+	Context("Test getReadyPodNames()", func() {
+		It("returns the sorted and deduplicated pod names", func() {
+			Expect(getReadyPodNames(newEndpoints("osm-controller-b", "osm-controller-a"))).To(Equal([]string{"osm-controller-a", "osm-controller-b"}))
+		})
+
+		It("returns no pod names for other objects", func() {
+			Expect(getReadyPodNames(nil)).To(BeEmpty())
+		})
+	})
+
+	Context("Test NewSharder()", func() {
+		It("returns an error when the identity is empty", func() {
+			_, err := NewSharder(fake.NewSimpleClientset(), osmNamespace, serviceName, "", make(chan struct{}))
+			Expect(err).To(Equal(errEmptyIdentity))
+		})
+
+		It("assigns each proxy to exactly one replica", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			kubeClient := fake.NewSimpleClientset(newEndpoints("osm-controller-a", "osm-controller-b"))
+			a, err := NewSharder(kubeClient, osmNamespace, serviceName, "osm-controller-a", stop)
+			Expect(err).ToNot(HaveOccurred())
+			b, err := NewSharder(kubeClient, osmNamespace, serviceName, "osm-controller-b", stop)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, key := range []string{"proxy-1.bookstore.default", "proxy-2.bookstore.default", "proxy-3.bookstore.default"} {
+				Expect(a.IsOwner(key)).ToNot(Equal(b.IsOwner(key)))
+			}
+		})
+
+		It("serves all proxies while it is not a ready replica", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			kubeClient := fake.NewSimpleClientset(newEndpoints("osm-controller-a", "osm-controller-b"))
+			c, err := NewSharder(kubeClient, osmNamespace, serviceName, "osm-controller-c", stop)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, key := range []string{"proxy-1.bookstore.default", "proxy-2.bookstore.default", "proxy-3.bookstore.default"} {
+				Expect(c.IsOwner(key)).To(BeTrue())
+			}
+		})
+
+		It("serves all proxies once it is the only replica", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			kubeClient := fake.NewSimpleClientset(newEndpoints("osm-controller-a", "osm-controller-b"))
+			a, err := NewSharder(kubeClient, osmNamespace, serviceName, "osm-controller-a", stop)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = kubeClient.CoreV1().Endpoints(osmNamespace).Update(context.Background(), newEndpoints("osm-controller-a"), metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(func() bool {
+				for _, key := range []string{"proxy-1.bookstore.default", "proxy-2.bookstore.default", "proxy-3.bookstore.default"} {
+					if !a.IsOwner(key) {
+						return false
+					}
+				}
+				return true
+			}).Should(BeTrue())
+		})
+	})
+})
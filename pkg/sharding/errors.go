@@ -0,0 +1,8 @@
+package sharding
+
+import "github.com/pkg/errors"
+
+var (
+	errSyncingCaches = errors.New("Failed initial cache sync for osm-controller Endpoints informer")
+	errEmptyIdentity = errors.New("Replica identity must not be empty")
+)
@@ -0,0 +1,54 @@
+package sharding
+
+import (
+	"fmt"
+	"hash/crc32"
+	"sort"
+)
+
+// virtualNodesPerMember is the number of points each member has on the ring.
+// More points spread the keys more evenly across the members.
+const virtualNodesPerMember = 100
+
+// ring is a consistent hash ring. Adding or removing a member only moves the keys
+// owned by that member, the other keys keep their owner.
+type ring struct {
+	hashes  []uint32
+	owners  map[uint32]string
+	members map[string]bool
+}
+
+func newRing(members []string) *ring {
+	r := &ring{
+		owners:  make(map[uint32]string),
+		members: make(map[string]bool),
+	}
+	for _, member := range members {
+		r.members[member] = true
+		for i := 0; i < virtualNodesPerMember; i++ {
+			hash := crc32.ChecksumIEEE([]byte(fmt.Sprintf("%s#%d", member, i)))
+			r.hashes = append(r.hashes, hash)
+			r.owners[hash] = member
+		}
+	}
+	sort.Slice(r.hashes, func(i, j int) bool { return r.hashes[i] < r.hashes[j] })
+	return r
+}
+
+// get returns the member owning the given key, empty if the ring has no members.
+func (r *ring) get(key string) string {
+	if len(r.hashes) == 0 {
+		return ""
+	}
+	hash := crc32.ChecksumIEEE([]byte(key))
+	idx := sort.Search(len(r.hashes), func(i int) bool { return r.hashes[i] >= hash })
+	if idx == len(r.hashes) {
+		idx = 0
+	}
+	return r.owners[r.hashes[idx]]
+}
+
+// has returns true if the given member is on the ring
+func (r *ring) has(member string) bool {
+	return r.members[member]
+}
@@ -0,0 +1,43 @@
+package sharding
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test consistent hash ring", func() {
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("proxy-%d.bookstore.default", i)
+	}
+
+	Context("Test get()", func() {
+		It("returns no owner for an empty ring", func() {
+			Expect(newRing(nil).get(keys[0])).To(BeEmpty())
+		})
+
+		It("assigns every key to a member", func() {
+			r := newRing([]string{"osm-controller-a", "osm-controller-b", "osm-controller-c"})
+			counts := make(map[string]int)
+			for _, key := range keys {
+				counts[r.get(key)]++
+			}
+			Expect(counts).To(HaveLen(3))
+			for _, count := range counts {
+				Expect(count).To(BeNumerically(">", len(keys)/10))
+			}
+		})
+
+		It("only moves the keys of a removed member", func() {
+			before := newRing([]string{"osm-controller-a", "osm-controller-b", "osm-controller-c"})
+			after := newRing([]string{"osm-controller-a", "osm-controller-b"})
+			for _, key := range keys {
+				if owner := before.get(key); owner != "osm-controller-c" {
+					Expect(after.get(key)).To(Equal(owner))
+				}
+			}
+		})
+	})
+})
@@ -0,0 +1,28 @@
+package sharding
+
+import (
+	"sync"
+
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var (
+	log = logger.New("sharding")
+)
+
+// Sharder assigns the proxies connected to the control plane to the osm-controller replicas
+type Sharder interface {
+	// IsOwner returns true if the proxy with the given key is served by this replica
+	IsOwner(key string) bool
+}
+
+// Client is the Sharder building its consistent hash ring from the ready endpoints of the osm-controller service.
+type Client struct {
+	self     string
+	informer cache.SharedIndexInformer
+
+	ringMutex sync.RWMutex
+	ring      *ring
+}
@@ -33,13 +33,14 @@ import (
 const kubernetesClientName = "MeshSpec"
 
 // NewMeshSpecClient implements mesh.MeshSpec and creates the Kubernetes client, which retrieves SMI specific CRDs.
-func NewMeshSpecClient(smiKubeConfig *rest.Config, kubeClient kubernetes.Interface, osmNamespace string, namespaceController namespace.Controller, stop chan struct{}) (MeshSpec, error) {
+// When watchedNamespaces is not empty, only the resources of these namespaces are watched.
+func NewMeshSpecClient(smiKubeConfig *rest.Config, kubeClient kubernetes.Interface, osmNamespace string, namespaceController namespace.Controller, watchedNamespaces []string, stop chan struct{}) (MeshSpec, error) {
 	smiTrafficSplitClientSet := smiTrafficSplitClient.NewForConfigOrDie(smiKubeConfig)
 	smiTrafficSpecClientSet := smiTrafficSpecClient.NewForConfigOrDie(smiKubeConfig)
 	smiTrafficTargetClientSet := smiTrafficTargetClient.NewForConfigOrDie(smiKubeConfig)
 
 	var backpressureClientSet *backpressureClient.Clientset
-	if featureflags.IsBackpressureEnabled() {
+	if featureflags.IsBackpressureEnabled() || featureflags.IsTLSOriginationEnabled() || featureflags.IsSidecarScopeEnabled() {
 		backpressureClientSet = backpressureClient.NewForConfigOrDie(smiKubeConfig)
 	}
 
@@ -51,6 +52,7 @@ func NewMeshSpecClient(smiKubeConfig *rest.Config, kubeClient kubernetes.Interfa
 		backpressureClientSet,
 		osmNamespace,
 		namespaceController,
+		watchedNamespaces,
 		kubernetesClientName,
 	)
 
@@ -80,6 +82,14 @@ func (c *Client) run(stop <-chan struct{}) error {
 		sharedInformers["Backpressure"] = c.informers.Backpressure
 	}
 
+	if featureflags.IsTLSOriginationEnabled() {
+		sharedInformers["TLSOrigination"] = c.informers.TLSOrigination
+	}
+
+	if featureflags.IsSidecarScopeEnabled() {
+		sharedInformers["SidecarScope"] = c.informers.SidecarScope
+	}
+
 	var names []string
 	for name, informer := range sharedInformers {
 		// Depending on the use-case, some Informers from the collection may not have been initialized.
@@ -115,17 +125,20 @@ func (c *Client) GetAnnouncementsChannel() <-chan interface{} {
 }
 
 // newClient creates a provider based on a Kubernetes client instance.
-func newSMIClient(kubeClient kubernetes.Interface, smiTrafficSplitClient *smiTrafficSplitClient.Clientset, smiTrafficSpecClient *smiTrafficSpecClient.Clientset, smiTrafficTargetClient *smiTrafficTargetClient.Clientset, backpressureClient *backpressureClient.Clientset, osmNamespace string, namespaceController namespace.Controller, providerIdent string) *Client {
-	informerFactory := informers.NewSharedInformerFactory(kubeClient, k8s.DefaultKubeEventResyncInterval)
-	smiTrafficSplitInformerFactory := smiTrafficSplitInformers.NewSharedInformerFactory(smiTrafficSplitClient, k8s.DefaultKubeEventResyncInterval)
-	smiTrafficSpecInformerFactory := smiTrafficSpecInformers.NewSharedInformerFactory(smiTrafficSpecClient, k8s.DefaultKubeEventResyncInterval)
-	smiTrafficTargetInformerFactory := smiTrafficTargetInformers.NewSharedInformerFactory(smiTrafficTargetClient, k8s.DefaultKubeEventResyncInterval)
-
+func newSMIClient(kubeClient kubernetes.Interface, smiTrafficSplitClient *smiTrafficSplitClient.Clientset, smiTrafficSpecClient *smiTrafficSpecClient.Clientset, smiTrafficTargetClient *smiTrafficTargetClient.Clientset, backpressureClient *backpressureClient.Clientset, osmNamespace string, namespaceController namespace.Controller, watchedNamespaces []string, providerIdent string) *Client {
 	informerCollection := InformerCollection{
-		Services:      informerFactory.Core().V1().Services().Informer(),
-		TrafficSplit:  smiTrafficSplitInformerFactory.Split().V1alpha2().TrafficSplits().Informer(),
-		TrafficSpec:   smiTrafficSpecInformerFactory.Specs().V1alpha3().HTTPRouteGroups().Informer(),
-		TrafficTarget: smiTrafficTargetInformerFactory.Access().V1alpha2().TrafficTargets().Informer(),
+		Services: k8s.NewInformer(watchedNamespaces, func(ns string) cache.SharedIndexInformer {
+			return informers.NewSharedInformerFactoryWithOptions(kubeClient, k8s.DefaultKubeEventResyncInterval, informers.WithNamespace(ns)).Core().V1().Services().Informer()
+		}),
+		TrafficSplit: k8s.NewInformer(watchedNamespaces, func(ns string) cache.SharedIndexInformer {
+			return smiTrafficSplitInformers.NewSharedInformerFactoryWithOptions(smiTrafficSplitClient, k8s.DefaultKubeEventResyncInterval, smiTrafficSplitInformers.WithNamespace(ns)).Split().V1alpha2().TrafficSplits().Informer()
+		}),
+		TrafficSpec: k8s.NewInformer(watchedNamespaces, func(ns string) cache.SharedIndexInformer {
+			return smiTrafficSpecInformers.NewSharedInformerFactoryWithOptions(smiTrafficSpecClient, k8s.DefaultKubeEventResyncInterval, smiTrafficSpecInformers.WithNamespace(ns)).Specs().V1alpha3().HTTPRouteGroups().Informer()
+		}),
+		TrafficTarget: k8s.NewInformer(watchedNamespaces, func(ns string) cache.SharedIndexInformer {
+			return smiTrafficTargetInformers.NewSharedInformerFactoryWithOptions(smiTrafficTargetClient, k8s.DefaultKubeEventResyncInterval, smiTrafficTargetInformers.WithNamespace(ns)).Access().V1alpha2().TrafficTargets().Informer()
+		}),
 	}
 
 	cacheCollection := CacheCollection{
@@ -136,11 +149,26 @@ func newSMIClient(kubeClient kubernetes.Interface, smiTrafficSplitClient *smiTra
 	}
 
 	if featureflags.IsBackpressureEnabled() {
-		backPressureInformerFactory := backpressureInformers.NewSharedInformerFactoryWithOptions(backpressureClient, k8s.DefaultKubeEventResyncInterval)
-		informerCollection.Backpressure = backPressureInformerFactory.Policy().V1alpha1().Backpressures().Informer()
+		informerCollection.Backpressure = k8s.NewInformer(watchedNamespaces, func(ns string) cache.SharedIndexInformer {
+			return backpressureInformers.NewSharedInformerFactoryWithOptions(backpressureClient, k8s.DefaultKubeEventResyncInterval, backpressureInformers.WithNamespace(ns)).Policy().V1alpha1().Backpressures().Informer()
+		})
 		cacheCollection.Backpressure = informerCollection.Backpressure.GetStore()
 	}
 
+	if featureflags.IsTLSOriginationEnabled() {
+		informerCollection.TLSOrigination = k8s.NewInformer(watchedNamespaces, func(ns string) cache.SharedIndexInformer {
+			return backpressureInformers.NewSharedInformerFactoryWithOptions(backpressureClient, k8s.DefaultKubeEventResyncInterval, backpressureInformers.WithNamespace(ns)).Policy().V1alpha1().TLSOriginations().Informer()
+		})
+		cacheCollection.TLSOrigination = informerCollection.TLSOrigination.GetStore()
+	}
+
+	if featureflags.IsSidecarScopeEnabled() {
+		informerCollection.SidecarScope = k8s.NewInformer(watchedNamespaces, func(ns string) cache.SharedIndexInformer {
+			return backpressureInformers.NewSharedInformerFactoryWithOptions(backpressureClient, k8s.DefaultKubeEventResyncInterval, backpressureInformers.WithNamespace(ns)).Policy().V1alpha1().SidecarScopes().Informer()
+		})
+		cacheCollection.SidecarScope = informerCollection.SidecarScope.GetStore()
+	}
+
 	client := Client{
 		providerIdent:       providerIdent,
 		informers:           &informerCollection,
@@ -164,6 +192,14 @@ func newSMIClient(kubeClient kubernetes.Interface, smiTrafficSplitClient *smiTra
 		informerCollection.Backpressure.AddEventHandler(k8s.GetKubernetesEventHandlers("Backpressure", "SMI", client.announcements, shouldObserve))
 	}
 
+	if featureflags.IsTLSOriginationEnabled() {
+		informerCollection.TLSOrigination.AddEventHandler(k8s.GetKubernetesEventHandlers("TLSOrigination", "SMI", client.announcements, shouldObserve))
+	}
+
+	if featureflags.IsSidecarScopeEnabled() {
+		informerCollection.SidecarScope.AddEventHandler(k8s.GetKubernetesEventHandlers("SidecarScope", "SMI", client.announcements, shouldObserve))
+	}
+
 	return &client
 }
 
@@ -335,3 +371,51 @@ func (c Client) ListServices() ([]*corev1.Service, error) {
 	}
 	return services, nil
 }
+
+// ListTLSOriginations implements smi.MeshSpec and returns a list of egress TLS origination policies.
+func (c *Client) ListTLSOriginations() []*backpressure.TLSOrigination {
+	var tlsOriginationList []*backpressure.TLSOrigination
+
+	if !featureflags.IsTLSOriginationEnabled() {
+		return tlsOriginationList
+	}
+
+	for _, tlsOriginationIface := range c.caches.TLSOrigination.List() {
+		tlsOrigination, ok := tlsOriginationIface.(*backpressure.TLSOrigination)
+		if !ok {
+			log.Error().Err(errInvalidObjectType).Msgf("Object obtained from cache is not *TLSOrigination")
+			continue
+		}
+
+		if !c.namespaceController.IsMonitoredNamespace(tlsOrigination.Namespace) {
+			continue
+		}
+		tlsOriginationList = append(tlsOriginationList, tlsOrigination)
+	}
+
+	return tlsOriginationList
+}
+
+// ListSidecarScopes implements smi.MeshSpec and returns a list of sidecar scopes.
+func (c *Client) ListSidecarScopes() []*backpressure.SidecarScope {
+	var sidecarScopeList []*backpressure.SidecarScope
+
+	if !featureflags.IsSidecarScopeEnabled() {
+		return sidecarScopeList
+	}
+
+	for _, sidecarScopeIface := range c.caches.SidecarScope.List() {
+		sidecarScope, ok := sidecarScopeIface.(*backpressure.SidecarScope)
+		if !ok {
+			log.Error().Err(errInvalidObjectType).Msgf("Object obtained from cache is not *SidecarScope")
+			continue
+		}
+
+		if !c.namespaceController.IsMonitoredNamespace(sidecarScope.Namespace) {
+			continue
+		}
+		sidecarScopeList = append(sidecarScopeList, sidecarScope)
+	}
+
+	return sidecarScopeList
+}
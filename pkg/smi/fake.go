@@ -17,6 +17,8 @@ type fakeMeshSpec struct {
 	routeGroups      []*spec.HTTPRouteGroup
 	trafficTargets   []*target.TrafficTarget
 	backpressures    []*backpressure.Backpressure
+	tlsOriginations  []*backpressure.TLSOrigination
+	sidecarScopes    []*backpressure.SidecarScope
 	weightedServices []service.WeightedService
 	serviceAccounts  []service.K8sServiceAccount
 	services         []*corev1.Service
@@ -38,7 +40,8 @@ func NewFakeMeshSpecClient() MeshSpec {
 			tests.NewServiceFixture(tests.BookbuyerService.Name, tests.BookbuyerService.Namespace, nil),
 		},
 
-		backpressures: []*backpressure.Backpressure{&tests.Backpressure},
+		backpressures:   []*backpressure.Backpressure{&tests.Backpressure},
+		tlsOriginations: []*backpressure.TLSOrigination{&tests.TLSOrigination},
 	}
 }
 
@@ -82,6 +85,16 @@ func (f fakeMeshSpec) ListBackpressures() []*backpressure.Backpressure {
 	return f.backpressures
 }
 
+// ListTLSOriginations lists TLSOrigination resources for the fake Mesh Spec.
+func (f fakeMeshSpec) ListTLSOriginations() []*backpressure.TLSOrigination {
+	return f.tlsOriginations
+}
+
+// ListSidecarScopes lists SidecarScope resources for the fake Mesh Spec.
+func (f fakeMeshSpec) ListSidecarScopes() []*backpressure.SidecarScope {
+	return f.sidecarScopes
+}
+
 // GetAnnouncementsChannel returns the channel on which SMI makes announcements for the fake Mesh Spec.
 func (f fakeMeshSpec) GetAnnouncementsChannel() <-chan interface{} {
 	return make(chan interface{})
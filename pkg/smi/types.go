@@ -25,6 +25,9 @@ type InformerCollection struct {
 	TrafficSpec   cache.SharedIndexInformer
 	TrafficTarget cache.SharedIndexInformer
 	Backpressure  cache.SharedIndexInformer
+
+	TLSOrigination cache.SharedIndexInformer
+	SidecarScope   cache.SharedIndexInformer
 }
 
 // CacheCollection is a struct of the Kubernetes caches used in OSM
@@ -34,6 +37,9 @@ type CacheCollection struct {
 	TrafficSpec   cache.Store
 	TrafficTarget cache.Store
 	Backpressure  cache.Store
+
+	TLSOrigination cache.Store
+	SidecarScope   cache.Store
 }
 
 // Client is a struct for all components necessary to connect to and maintain state of a Kubernetes cluster.
@@ -75,6 +81,14 @@ type MeshSpec interface {
 	// in some shape or form make its way into SMI Spec.
 	ListBackpressures() []*backpressure.Backpressure
 
+	// ListTLSOriginations lists TLSOrigination CRD resources.
+	// This is an experimental feature.
+	ListTLSOriginations() []*backpressure.TLSOrigination
+
+	// ListSidecarScopes lists SidecarScope CRD resources.
+	// This is an experimental feature.
+	ListSidecarScopes() []*backpressure.SidecarScope
+
 	// GetAnnouncementsChannel returns the channel on which SMI makes announcements
 	GetAnnouncementsChannel() <-chan interface{}
 
@@ -248,6 +248,17 @@ var (
 			MaxConnections: 123,
 		},
 	}
+
+	// TLSOrigination is an experimental egress TLS origination policy.
+	TLSOrigination = backpressure.TLSOrigination{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "tls-origination",
+			Namespace: Namespace,
+		},
+		Spec: backpressure.TLSOriginationSpec{
+			Hosts: []string{"httpbin.org"},
+		},
+	}
 )
 
 // NewPodTestFixture creates a new Pod struct for testing.
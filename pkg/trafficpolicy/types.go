@@ -1,6 +1,8 @@
 package trafficpolicy
 
 import (
+	"fmt"
+
 	set "github.com/deckarep/golang-set"
 	"github.com/openservicemesh/osm/pkg/service"
 )
@@ -31,3 +33,23 @@ type RouteWeightedClusters struct {
 	Route            Route   `json:"route:omitempty"`
 	WeightedClusters set.Set `json:"weighted_clusters:omitempty"`
 }
+
+// TLSOrigination is a struct of an external host plaintext HTTP requests to which are upgraded to TLS
+type TLSOrigination struct {
+	// Host is the external host
+	Host string `json:"host,omitempty"`
+
+	// Port is the port of the external host TLS connections are originated to
+	Port uint32 `json:"port,omitempty"`
+
+	// SNI is the server name sent in the TLS handshake
+	SNI string `json:"sni,omitempty"`
+
+	// CABundle is the PEM encoded CA bundle used to validate the certificate of the external host, empty for the default CA bundle
+	CABundle string `json:"ca_bundle,omitempty"`
+}
+
+// GetClusterName returns the name of the cluster TLS connections to the external host are originated with
+func (t TLSOrigination) GetClusterName() service.ClusterName {
+	return service.ClusterName(fmt.Sprintf("tls-origination|%s:%d", t.Host, t.Port))
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"net"
+	"sync"
+)
+
+// ConnectionTracker is a net.Listener keeping track of the connections it accepted, so that a gRPC server
+// can close the transport of a client instead of only one of its streams.
+type ConnectionTracker struct {
+	net.Listener
+
+	connections map[string]net.Conn
+	mutex       sync.Mutex
+}
+
+// trackedConn forgets the connection once it is closed
+type trackedConn struct {
+	net.Conn
+
+	tracker   *ConnectionTracker
+	closeOnce sync.Once
+}
+
+// NewConnectionTracker returns a ConnectionTracker accepting the connections of the given listener.
+func NewConnectionTracker(lis net.Listener) *ConnectionTracker {
+	return &ConnectionTracker{
+		Listener:    lis,
+		connections: make(map[string]net.Conn),
+	}
+}
+
+// Accept waits for and returns the next connection to the listener, which is tracked until it is closed.
+func (t *ConnectionTracker) Accept() (net.Conn, error) {
+	conn, err := t.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := &trackedConn{Conn: conn, tracker: t}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.connections[conn.RemoteAddr().String()] = tracked
+	return tracked, nil
+}
+
+// CloseConnection closes the connection accepted from the given remote address, if any.
+// It returns whether the connection was found.
+func (t *ConnectionTracker) CloseConnection(remoteAddr net.Addr) bool {
+	if remoteAddr == nil {
+		return false
+	}
+
+	t.mutex.Lock()
+	conn, ok := t.connections[remoteAddr.String()]
+	t.mutex.Unlock()
+	if !ok {
+		return false
+	}
+	if err := conn.Close(); err != nil {
+		log.Error().Err(err).Msgf("Error closing connection from %s", remoteAddr)
+	}
+	return true
+}
+
+// Close closes the connection and stops tracking it
+func (c *trackedConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.tracker.mutex.Lock()
+		defer c.tracker.mutex.Unlock()
+		if c.tracker.connections[c.RemoteAddr().String()] == c {
+			delete(c.tracker.connections, c.RemoteAddr().String())
+		}
+	})
+	return c.Conn.Close()
+}
@@ -0,0 +1,21 @@
+# Waits for the traffic of the pod to be redirected to the Proxy by the HNS agent of the node, which sets the
+# openservicemesh.io/hns-redirected annotation of the pod. The annotations of the pod are projected to a file
+# by the downward API. The application must not start before its traffic is redirected, so the container fails
+# when the traffic is not redirected in time, and is restarted by the kubelet.
+
+$ErrorActionPreference = "Stop"
+
+$AnnotationsFile = if ($env:POD_ANNOTATIONS_FILE) { $env:POD_ANNOTATIONS_FILE } else { "/etc/podinfo/annotations" }
+$TimeoutSeconds = if ($env:TIMEOUT_SECONDS) { [int]$env:TIMEOUT_SECONDS } else { 120 }
+$RedirectedAnnotation = 'openservicemesh.io/hns-redirected="true"'
+
+$deadline = (Get-Date).AddSeconds($TimeoutSeconds)
+while ((Get-Date) -lt $deadline) {
+    if ((Test-Path $AnnotationsFile) -and (Select-String -Path $AnnotationsFile -Pattern $RedirectedAnnotation -SimpleMatch -Quiet)) {
+        Write-Output "The traffic of the pod is redirected to the Proxy"
+        exit 0
+    }
+    Start-Sleep -Seconds 1
+}
+
+throw "The traffic of the pod was not redirected to the Proxy within $TimeoutSeconds seconds; is the osm-hns-agent running on the node?"